@@ -0,0 +1,414 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"blockchain-parser/pkg/client"
+)
+
+const (
+	urlEnv    = "BLOCKCHAIN_PARSER_URL"
+	apiKeyEnv = "BLOCKCHAIN_PARSER_API_KEY"
+
+	defaultURL = "http://localhost:8000"
+
+	// maxPageSize is the max limit of transactions pages of the API
+	maxPageSize = 1000
+
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+)
+
+const usage = `Usage:
+  blockchain-parser-cli [flags] <command> [command flags]
+
+Commands:
+  subscribe <address>                          subscribe the tenant to the address
+  unsubscribe <address>                        remove the subscription of the tenant to the address
+  transactions --address <address> [--from-block <number>] [--to-block <number>] [--limit <count>]
+               [--unit hex|wei|ether] [--format table|json|csv]
+                                               transactions of the subscribed address, pages are followed up to the limit
+  block current [--format table|json|csv]      the last parsed block
+  jobs [--format table|json|csv]               background jobs (admin)
+  reparse create --from <number> --to <number> [--address <address>]... [--format table|json|csv]
+                                               queue parsing the blocks again for the addresses (admin)
+  reparse list [--format table|json|csv]       reparse tasks (admin)
+  reparse get <id> [--format table|json|csv]   reparse task (admin)
+  reparse cancel <id> [--format table|json|csv]
+                                               cancel the reparse task (admin)
+
+Block numbers are hex (0x prefixed) or decimal. Admin commands need the admin key as the API key.
+
+Flags:
+`
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	os.Exit(run(ctx, os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// cli runs one command, output of the command is written to stdout, errors to stderr
+type cli struct {
+	clnt   *client.Client
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// errUsage is returned by commands with invalid arguments, the usage is already printed
+var errUsage = errors.New("invalid usage")
+
+// run parses global flags and runs the command, it returns the exit code
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("blockchain-parser-cli", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	baseURL := flags.String("url", envOrDefault(urlEnv, defaultURL), "base URL of the API, "+urlEnv+" env var by default")
+	apiKey := flags.String("api-key", os.Getenv(apiKeyEnv), "API key of the tenant or the admin key, "+apiKeyEnv+" env var by default")
+	timeout := flags.Duration("timeout", 30*time.Second, "timeout of one request")
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), usage)
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+
+		return exitUsage
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+
+		return exitUsage
+	}
+
+	clnt, err := client.NewClient(client.Config{
+		BaseURL: *baseURL,
+		APIKey:  *apiKey,
+		Timeout: *timeout,
+	})
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+
+		return exitUsage
+	}
+
+	c := &cli{
+		clnt:   clnt,
+		stdout: stdout,
+		stderr: stderr,
+	}
+
+	commands := map[string]func(ctx context.Context, args []string) error{
+		"subscribe":    c.subscribe,
+		"unsubscribe":  c.unsubscribe,
+		"transactions": c.transactions,
+		"block":        c.block,
+		"jobs":         c.jobs,
+		"reparse":      c.reparse,
+	}
+
+	command, ok := commands[flags.Arg(0)]
+	if !ok {
+		fmt.Fprintf(stderr, "unknown command %q\n", flags.Arg(0))
+		flags.Usage()
+
+		return exitUsage
+	}
+
+	err = command(ctx, flags.Args()[1:])
+	if errors.Is(err, errUsage) {
+		return exitUsage
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, formatError(err))
+
+		return exitError
+	}
+
+	return exitOK
+}
+
+func (c *cli) subscribe(ctx context.Context, args []string) error {
+	flags := c.flagSet("subscribe <address>")
+	address, err := parseWithArg(flags, args)
+	if err != nil {
+		return err
+	}
+
+	if err := c.clnt.Subscribe(ctx, address); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.stdout, "subscribed to %s\n", address)
+
+	return nil
+}
+
+func (c *cli) unsubscribe(ctx context.Context, args []string) error {
+	flags := c.flagSet("unsubscribe <address>")
+	address, err := parseWithArg(flags, args)
+	if err != nil {
+		return err
+	}
+
+	if err := c.clnt.Unsubscribe(ctx, address); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.stdout, "unsubscribed from %s\n", address)
+
+	return nil
+}
+
+// transactions follows pages of transactions until the limit is reached or the last page is read
+func (c *cli) transactions(ctx context.Context, args []string) error {
+	flags := c.flagSet("transactions --address <address> [flags]")
+	address := flags.String("address", "", "subscribed address (required)")
+	fromBlock := flags.String("from-block", "", "first block, hex or decimal")
+	toBlock := flags.String("to-block", "", "last block, hex or decimal")
+	limit := flags.Int("limit", 100, "max count of transactions")
+	unit := flags.String("unit", "ether", "unit of values: hex, wei or ether")
+	format := formatFlag(flags)
+	if err := parse(flags, args); err != nil {
+		return err
+	}
+	if *address == "" || *limit < 1 {
+		return c.usageError(flags, "--address is required and --limit must be positive")
+	}
+
+	pageSize := *limit
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	it := c.clnt.Transactions(*address, client.TransactionFilter{
+		Limit:     pageSize,
+		FromBlock: *fromBlock,
+		ToBlock:   *toBlock,
+		Unit:      *unit,
+	})
+
+	txns := make([]client.Transaction, 0)
+	for len(txns) < *limit && it.Next(ctx) {
+		txns = append(txns, it.Transaction())
+	}
+	if err := it.Err(); err != nil {
+		return err
+	}
+
+	return writeOutput(c.stdout, *format, txns, transactionsTable(txns))
+}
+
+func (c *cli) block(ctx context.Context, args []string) error {
+	flags := c.flagSet("block current [flags]")
+	format := formatFlag(flags)
+	if len(args) == 0 || args[0] != "current" {
+		return c.usageError(flags, "unknown block command, use block current")
+	}
+	if err := parse(flags, args[1:]); err != nil {
+		return err
+	}
+
+	block, err := c.clnt.GetLatestBlock(ctx)
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(c.stdout, *format, block, blockTable(block))
+}
+
+func (c *cli) jobs(ctx context.Context, args []string) error {
+	flags := c.flagSet("jobs [flags]")
+	format := formatFlag(flags)
+	if err := parse(flags, args); err != nil {
+		return err
+	}
+
+	jobs, err := c.clnt.GetJobs(ctx)
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(c.stdout, *format, jobs, jobsTable(jobs))
+}
+
+func (c *cli) reparse(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return c.usageError(c.flagSet("reparse create|list|get|cancel [flags]"), "reparse command is required")
+	}
+
+	switch args[0] {
+	case "create":
+		return c.reparseCreate(ctx, args[1:])
+	case "list":
+		return c.reparseList(ctx, args[1:])
+	case "get":
+		return c.reparseTask(ctx, "get", c.clnt.GetReparseTask, args[1:])
+	case "cancel":
+		return c.reparseTask(ctx, "cancel", c.clnt.CancelReparseTask, args[1:])
+	}
+
+	return c.usageError(c.flagSet("reparse create|list|get|cancel [flags]"), fmt.Sprintf("unknown reparse command %q", args[0]))
+}
+
+func (c *cli) reparseCreate(ctx context.Context, args []string) error {
+	flags := c.flagSet("reparse create --from <number> --to <number> [flags]")
+	from := flags.String("from", "", "first block, hex or decimal (required)")
+	to := flags.String("to", "", "last block, hex or decimal (required)")
+	addresses := listFlag{}
+	flags.Var(&addresses, "address", "address to parse, repeat the flag or separate addresses by commas (required)")
+	format := formatFlag(flags)
+	if err := parse(flags, args); err != nil {
+		return err
+	}
+	if *from == "" || *to == "" || len(addresses) == 0 {
+		return c.usageError(flags, "--from, --to and --address are required")
+	}
+
+	task, err := c.clnt.CreateReparseTask(ctx, client.ReparseCreate{
+		From:      *from,
+		To:        *to,
+		Addresses: addresses,
+	})
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(c.stdout, *format, task, reparseTasksTable([]client.ReparseTask{task}))
+}
+
+func (c *cli) reparseList(ctx context.Context, args []string) error {
+	flags := c.flagSet("reparse list [flags]")
+	format := formatFlag(flags)
+	if err := parse(flags, args); err != nil {
+		return err
+	}
+
+	tasks, err := c.clnt.GetReparseTasks(ctx)
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(c.stdout, *format, tasks, reparseTasksTable(tasks))
+}
+
+func (c *cli) reparseTask(
+	ctx context.Context,
+	command string,
+	call func(ctx context.Context, id string) (client.ReparseTask, error),
+	args []string,
+) error {
+	flags := c.flagSet("reparse " + command + " <id> [flags]")
+	format := formatFlag(flags)
+	id, err := parseWithArg(flags, args)
+	if err != nil {
+		return err
+	}
+
+	task, err := call(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(c.stdout, *format, task, reparseTasksTable([]client.ReparseTask{task}))
+}
+
+func (c *cli) flagSet(usage string) *flag.FlagSet {
+	flags := flag.NewFlagSet(usage, flag.ContinueOnError)
+	flags.SetOutput(c.stderr)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage:\n  blockchain-parser-cli %s\n", usage)
+		flags.PrintDefaults()
+	}
+
+	return flags
+}
+
+func (c *cli) usageError(flags *flag.FlagSet, msg string) error {
+	fmt.Fprintln(c.stderr, msg)
+	flags.Usage()
+
+	return errUsage
+}
+
+// parse parses flags of the command, the command has no positional arguments
+func parse(flags *flag.FlagSet, args []string) error {
+	if err := flags.Parse(args); err != nil {
+		return errUsage
+	}
+	if flags.NArg() > 0 {
+		fmt.Fprintf(flags.Output(), "unexpected arguments %s\n", strings.Join(flags.Args(), " "))
+		flags.Usage()
+
+		return errUsage
+	}
+
+	return nil
+}
+
+// parseWithArg parses flags of the command with one positional argument, flags may follow the argument
+func parseWithArg(flags *flag.FlagSet, args []string) (string, error) {
+	if err := flags.Parse(args); err != nil {
+		return "", errUsage
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+
+		return "", errUsage
+	}
+
+	arg := flags.Arg(0)
+	if err := parse(flags, flags.Args()[1:]); err != nil {
+		return "", err
+	}
+
+	return arg, nil
+}
+
+// formatError prints the API error without wrapping of the client, the request id helps to find it in logs
+func formatError(err error) string {
+	apiErr := &client.Error{}
+	if errors.As(err, &apiErr) {
+		return "error: " + apiErr.Error()
+	}
+
+	return "error: " + err.Error()
+}
+
+func envOrDefault(name, defaultValue string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+
+	return defaultValue
+}
+
+// listFlag collects values of the repeated flag, values may be separated by commas
+type listFlag []string
+
+func (f *listFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *listFlag) Set(value string) error {
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			*f = append(*f, item)
+		}
+	}
+
+	return nil
+}
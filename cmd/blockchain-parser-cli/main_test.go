@@ -0,0 +1,237 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const address = "0xA855D1198C67839e596B9a5d7c46F8ea31cfEFdE"
+
+// request is the request seen by the test server
+type request struct {
+	method string
+	path   string
+	query  string
+	apiKey string
+	body   string
+}
+
+// response is the response of the test server to the request with the path and query
+type response struct {
+	status int
+	body   string
+}
+
+func TestRun(t *testing.T) {
+	txnPage1 := `{"transactions":[{"hash":"0x01","kind":"transaction","from":"0xa","to":"0xb","value":"1.5","blockNumber":"0x60","transactionIndex":0,"timestamp":"2024-01-01T00:00:00Z"}],"nextCursor":"c1"}`
+	txnPage2 := `{"transactions":[{"hash":"0x02","kind":"transaction","from":"0xb","to":"0xa","value":"0.5","blockNumber":"0x61","transactionIndex":1,"timestamp":"2024-01-01T00:00:12Z"}]}`
+	reparseTask := `{"id":"r1","from":"0x1","to":"0xa","addresses":["` + address + `"],"status":"queued","nextBlock":"0x1","parsedBlocks":0,"totalBlocks":10,"matchedTransactions":0,"createdAt":"2024-01-01T00:00:00Z"}`
+
+	tests := []struct {
+		name         string
+		args         []string
+		responses    map[string]response
+		wantCode     int
+		wantRequests []request
+		wantStdout   string
+		wantStderr   string
+	}{
+		{
+			name:      "subscribe",
+			args:      []string{"--api-key", "key-1", "subscribe", address},
+			responses: map[string]response{"/v1/addresses/" + address + "/subscription": {status: http.StatusNoContent}},
+			wantCode:  exitOK,
+			wantRequests: []request{
+				{method: http.MethodPut, path: "/v1/addresses/" + address + "/subscription", apiKey: "key-1"},
+			},
+			wantStdout: "subscribed to " + address + "\n",
+		},
+		{
+			name: "unsubscribe of address which isn't subscribed",
+			args: []string{"unsubscribe", address},
+			responses: map[string]response{"/v1/addresses/" + address + "/subscription": {
+				status: http.StatusNotFound,
+				body:   `{"code":"subscriber_not_found","message":"subscriber not found","requestId":"req-1"}`,
+			}},
+			wantCode: exitError,
+			wantRequests: []request{
+				{method: http.MethodDelete, path: "/v1/addresses/" + address + "/subscription"},
+			},
+			wantStderr: "error: api error 404 subscriber_not_found: subscriber not found, request id req-1\n",
+		},
+		{
+			name: "transactions follow pages in csv",
+			args: []string{"transactions", "--address", address, "--from-block", "0x60", "--format", "csv"},
+			responses: map[string]response{
+				"/v1/addresses/" + address + "/transactions?fromBlock=0x60&limit=100&unit=ether":           {status: http.StatusOK, body: txnPage1},
+				"/v1/addresses/" + address + "/transactions?cursor=c1&fromBlock=0x60&limit=100&unit=ether": {status: http.StatusOK, body: txnPage2},
+			},
+			wantCode: exitOK,
+			wantRequests: []request{
+				{method: http.MethodGet, path: "/v1/addresses/" + address + "/transactions", query: "fromBlock=0x60&limit=100&unit=ether"},
+				{method: http.MethodGet, path: "/v1/addresses/" + address + "/transactions", query: "cursor=c1&fromBlock=0x60&limit=100&unit=ether"},
+			},
+			wantStdout: "BLOCK,HASH,KIND,FROM,TO,VALUE,TIMESTAMP\n" +
+				"0x60,0x01,transaction,0xa,0xb,1.5,2024-01-01T00:00:00Z\n" +
+				"0x61,0x02,transaction,0xb,0xa,0.5,2024-01-01T00:00:12Z\n",
+		},
+		{
+			name: "transactions are limited",
+			args: []string{"transactions", "--address", address, "--limit", "1", "--unit", "wei", "--format", "table"},
+			responses: map[string]response{
+				"/v1/addresses/" + address + "/transactions?limit=1&unit=wei": {status: http.StatusOK, body: txnPage1},
+			},
+			wantCode: exitOK,
+			wantRequests: []request{
+				{method: http.MethodGet, path: "/v1/addresses/" + address + "/transactions", query: "limit=1&unit=wei"},
+			},
+			wantStdout: "BLOCK  HASH  KIND         FROM  TO   VALUE  TIMESTAMP\n" +
+				"0x60   0x01  transaction  0xa   0xb  1.5    2024-01-01T00:00:00Z\n",
+		},
+		{
+			name:       "transactions without address",
+			args:       []string{"transactions", "--from-block", "1"},
+			wantCode:   exitUsage,
+			wantStderr: "--address is required",
+		},
+		{
+			name:       "unknown format",
+			args:       []string{"transactions", "--address", address, "--format", "xml"},
+			wantCode:   exitUsage,
+			wantStderr: "format must be table, json or csv",
+		},
+		{
+			name: "block current in json",
+			args: []string{"block", "current", "--format", "json"},
+			responses: map[string]response{"/v1/blocks/latest": {
+				status: http.StatusOK,
+				body:   `{"number":"0x60","hash":"0xabc","status":"parsed","updatedAt":"2024-01-01T00:00:00Z"}`,
+			}},
+			wantCode:     exitOK,
+			wantRequests: []request{{method: http.MethodGet, path: "/v1/blocks/latest"}},
+			wantStdout:   "{\n  \"number\": \"0x60\",\n  \"hash\": \"0xabc\",\n  \"status\": \"parsed\",\n  \"updatedAt\": \"2024-01-01T00:00:00Z\"\n}\n",
+		},
+		{
+			name: "jobs show the last run",
+			args: []string{"--api-key", "admin", "jobs", "--format", "csv"},
+			responses: map[string]response{"/v1/admin/jobs": {
+				status: http.StatusOK,
+				body: `{"jobs":[{"id":"parser-worker-1","name":"parser worker","schedule":"@every 1s","jitter":"0s","overlap":"skip",` +
+					`"paused":false,"running":0,"skipped":2,"nextRunAt":"2024-01-01T00:00:01Z",` +
+					`"history":[{"id":2,"trigger":"schedule","status":"failed","error":"node timeout"},{"id":1,"trigger":"schedule","status":"succeeded"}]}]}`,
+			}},
+			wantCode:     exitOK,
+			wantRequests: []request{{method: http.MethodGet, path: "/v1/admin/jobs", apiKey: "admin"}},
+			wantStdout: "ID,NAME,SCHEDULE,PAUSED,RUNNING,SKIPPED,NEXT RUN AT,LAST RUN,LAST ERROR\n" +
+				"parser-worker-1,parser worker,@every 1s,false,0,2,2024-01-01T00:00:01Z,failed,node timeout\n",
+		},
+		{
+			name:      "reparse create",
+			args:      []string{"reparse", "create", "--from", "1", "--to", "10", "--address", address, "--format", "csv"},
+			responses: map[string]response{"/v1/admin/reparse": {status: http.StatusAccepted, body: reparseTask}},
+			wantCode:  exitOK,
+			wantRequests: []request{
+				{method: http.MethodPost, path: "/v1/admin/reparse", body: `{"from":"1","to":"10","addresses":["` + address + `"]}`},
+			},
+			wantStdout: "ID,STATUS,FROM,TO,PARSED,MATCHED,ADDRESSES,ERROR\n" +
+				"r1,queued,0x1,0xa,0/10,0," + address + ",\n",
+		},
+		{
+			name:         "reparse cancel",
+			args:         []string{"reparse", "cancel", "r1", "--format", "json"},
+			responses:    map[string]response{"/v1/admin/reparse/r1": {status: http.StatusOK, body: reparseTask}},
+			wantCode:     exitOK,
+			wantRequests: []request{{method: http.MethodDelete, path: "/v1/admin/reparse/r1"}},
+			wantStdout:   "\"id\": \"r1\"",
+		},
+		{
+			name: "reparse of unknown task",
+			args: []string{"reparse", "get", "r2"},
+			responses: map[string]response{"/v1/admin/reparse/r2": {
+				status: http.StatusNotFound,
+				body:   `{"code":"reparse_task_not_found","message":"reparse task not found"}`,
+			}},
+			wantCode:     exitError,
+			wantRequests: []request{{method: http.MethodGet, path: "/v1/admin/reparse/r2"}},
+			wantStderr:   "error: api error 404 reparse_task_not_found: reparse task not found\n",
+		},
+		{
+			name:      "response of a proxy is retried",
+			args:      []string{"jobs"},
+			responses: map[string]response{"/v1/admin/jobs": {status: http.StatusBadGateway, body: "bad gateway"}},
+			wantCode:  exitError,
+			wantRequests: []request{
+				{method: http.MethodGet, path: "/v1/admin/jobs"},
+				{method: http.MethodGet, path: "/v1/admin/jobs"},
+				{method: http.MethodGet, path: "/v1/admin/jobs"},
+			},
+			wantStderr: "error: api error 502: bad gateway\n",
+		},
+		{
+			name:       "unknown command",
+			args:       []string{"wallets"},
+			wantCode:   exitUsage,
+			wantStderr: "unknown command \"wallets\"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := make([]request, 0)
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				requests = append(requests, request{
+					method: r.Method,
+					path:   r.URL.Path,
+					query:  r.URL.RawQuery,
+					apiKey: r.Header.Get("X-API-Key"),
+					body:   strings.TrimSpace(string(body)),
+				})
+
+				key := r.URL.Path
+				if r.URL.RawQuery != "" {
+					key += "?" + r.URL.RawQuery
+				}
+				resp, ok := tt.responses[key]
+				if !ok {
+					w.WriteHeader(http.StatusNotFound)
+					_ = json.NewEncoder(w).Encode(map[string]string{"code": "not_found", "message": "not found"})
+
+					return
+				}
+
+				w.WriteHeader(resp.status)
+				_, _ = io.WriteString(w, resp.body)
+			}))
+			defer srv.Close()
+
+			t.Setenv(apiKeyEnv, "")
+
+			stdout, stderr := bytes.Buffer{}, bytes.Buffer{}
+			code := run(context.Background(), append([]string{"--url", srv.URL}, tt.args...), &stdout, &stderr)
+
+			if code != tt.wantCode {
+				t.Errorf("run() code = %d, want %d, stderr:\n%s", code, tt.wantCode, stderr.String())
+			}
+			if tt.wantRequests == nil {
+				tt.wantRequests = []request{}
+			}
+			if !reflect.DeepEqual(requests, tt.wantRequests) {
+				t.Errorf("run() requests got = %+v, want %+v", requests, tt.wantRequests)
+			}
+			if !strings.Contains(stdout.String(), tt.wantStdout) || tt.wantStdout == "" && stdout.Len() > 0 {
+				t.Errorf("run() stdout got:\n%s\nwant:\n%s", stdout.String(), tt.wantStdout)
+			}
+			if !strings.Contains(stderr.String(), tt.wantStderr) {
+				t.Errorf("run() stderr got:\n%s\nwant:\n%s", stderr.String(), tt.wantStderr)
+			}
+		})
+	}
+}
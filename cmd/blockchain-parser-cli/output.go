@@ -0,0 +1,140 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"blockchain-parser/pkg/client"
+)
+
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatCSV   = "csv"
+)
+
+// outputFormat is the value of --format flag, unknown formats are rejected when flags are parsed
+type outputFormat string
+
+func formatFlag(flags *flag.FlagSet) *outputFormat {
+	format := outputFormat(formatTable)
+	flags.Var(&format, "format", "output format: table, json or csv")
+
+	return &format
+}
+
+func (f *outputFormat) String() string {
+	return string(*f)
+}
+
+func (f *outputFormat) Set(value string) error {
+	switch value {
+	case formatTable, formatJSON, formatCSV:
+		*f = outputFormat(value)
+
+		return nil
+	}
+
+	return fmt.Errorf("format must be %s, %s or %s", formatTable, formatJSON, formatCSV)
+}
+
+// table is the output of table and csv formats, json format prints responses of the API as is
+type table struct {
+	header []string
+	rows   [][]string
+}
+
+func writeOutput(w io.Writer, format outputFormat, value interface{}, t table) error {
+	switch format {
+	case formatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(value)
+	case formatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(t.header); err != nil {
+			return err
+		}
+		if err := writer.WriteAll(t.rows); err != nil {
+			return err
+		}
+
+		return writer.Error()
+	}
+
+	writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, strings.Join(t.header, "\t"))
+	for _, row := range t.rows {
+		fmt.Fprintln(writer, strings.Join(row, "\t"))
+	}
+
+	return writer.Flush()
+}
+
+func transactionsTable(txns []client.Transaction) table {
+	t := table{header: []string{"BLOCK", "HASH", "KIND", "FROM", "TO", "VALUE", "TIMESTAMP"}}
+	for _, txn := range txns {
+		t.rows = append(t.rows, []string{txn.BlockNumber, txn.Hash, txn.Kind, txn.From, txn.To, txn.Value, txn.Timestamp})
+	}
+
+	return t
+}
+
+func blockTable(block client.Block) table {
+	return table{
+		header: []string{"NUMBER", "HASH", "STATUS", "UPDATED AT"},
+		rows:   [][]string{{block.Number, block.Hash, block.Status, block.UpdatedAt}},
+	}
+}
+
+// jobsTable shows the status of the last run, the history is printed by json format. The history is ordered
+// from the newest run.
+func jobsTable(jobs []client.Job) table {
+	t := table{header: []string{"ID", "NAME", "SCHEDULE", "PAUSED", "RUNNING", "SKIPPED", "NEXT RUN AT", "LAST RUN", "LAST ERROR"}}
+	for _, job := range jobs {
+		lastRun, lastError := "", ""
+		if len(job.History) > 0 {
+			run := job.History[0]
+			lastRun, lastError = run.Status, run.Error
+		}
+
+		t.rows = append(t.rows, []string{
+			job.ID,
+			job.Name,
+			job.Schedule,
+			strconv.FormatBool(job.Paused),
+			strconv.Itoa(job.Running),
+			strconv.FormatUint(job.Skipped, 10),
+			job.NextRunAt,
+			lastRun,
+			lastError,
+		})
+	}
+
+	return t
+}
+
+func reparseTasksTable(tasks []client.ReparseTask) table {
+	t := table{header: []string{"ID", "STATUS", "FROM", "TO", "PARSED", "MATCHED", "ADDRESSES", "ERROR"}}
+	for _, task := range tasks {
+		t.rows = append(t.rows, []string{
+			task.ID,
+			task.Status,
+			task.From,
+			task.To,
+			fmt.Sprintf("%d/%d", task.ParsedBlocks, task.TotalBlocks),
+			strconv.Itoa(task.MatchedTransactions),
+			strings.Join(task.Addresses, ","),
+			task.Error,
+		})
+	}
+
+	return t
+}
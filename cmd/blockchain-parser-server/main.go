@@ -1,22 +1,89 @@
 package main
 
 import (
+	"blockchain-parser/config"
 	"blockchain-parser/internal/setup"
 	"context"
+	"flag"
+	"fmt"
 	"os"
 	"os/signal"
+	"syscall"
 )
 
+const usage = `Usage:
+  blockchain-parser-server [--config <path>]               run the service, SIGHUP reloads the config
+  blockchain-parser-server config print [--config <path>]  print the effective config with secrets redacted
+
+Settings are read from the YAML or JSON config file and env vars, env vars override the file.
+
+Flags:
+`
+
 func main() {
+	configPath := flag.String("config", "", "path to YAML (.yaml, .yml) or JSON (.json) config file")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	printConfig := false
+	if flag.NArg() > 0 {
+		if flag.NArg() < 2 || flag.Arg(0) != "config" || flag.Arg(1) != "print" {
+			flag.Usage()
+			os.Exit(2)
+		}
+		printConfig = true
+
+		// flags may follow the command, e.g. config print --config config.yml
+		_ = flag.CommandLine.Parse(flag.Args()[2:])
+		if flag.NArg() > 0 {
+			flag.Usage()
+			os.Exit(2)
+		}
+	}
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if printConfig {
+		if err := cfg.WriteYAML(os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		return
+	}
+
 	srv := setup.Server{}
-	srv.Configure()
+	srv.Configure(cfg, *configPath)
 
 	srv.Start(context.Background())
 
 	sigch := make(chan os.Signal, 1)
-	signal.Notify(sigch, os.Interrupt, os.Kill)
+	signal.Notify(sigch, os.Interrupt, syscall.SIGTERM)
+
+	// SIGHUP reloads the config, the result is logged by the server
+	hupch := make(chan os.Signal, 1)
+	signal.Notify(hupch, syscall.SIGHUP)
+	go func() {
+		for range hupch {
+			_, _ = srv.Reload(context.Background())
+		}
+	}()
 
 	<-sigch
+	signal.Stop(hupch)
+
+	// the second signal exits without waiting for the graceful shutdown
+	go func() {
+		<-sigch
+		os.Exit(1)
+	}()
 
 	srv.Stop()
 }
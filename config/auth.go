@@ -0,0 +1,14 @@
+package config
+
+type Auth struct {
+	// AdminKey enables authentication, without it every request belongs to the default tenant
+	AdminKey string
+}
+
+func parseAuth(src *source) Auth {
+	adminKey, _ := src.lookup("BLOCKCHAIN_PARSER_AUTH_ADMIN_KEY")
+
+	return Auth{
+		AdminKey: adminKey,
+	}
+}
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"strconv"
+	"time"
+
+	"blockchain-parser/tools/job"
+)
+
+const (
+	defaultBalanceReconciliationInterval = 10 * time.Minute
+)
+
+type Balance struct {
+	ReconciliationSchedule job.Schedule
+	ReconciliationPolicy   job.Policy
+	TraceInternalTransfers bool
+}
+
+func parseBalance(src *source) Balance {
+	var (
+		ok  bool
+		err error
+	)
+
+	balanceCfg := Balance{}
+
+	reconciliationInterval := defaultBalanceReconciliationInterval
+	balanceCfgReconciliationInterval, ok := src.lookup("BLOCKCHAIN_PARSER_BALANCE_RECONCILIATION_INTERVAL")
+	if ok {
+		reconciliationInterval, err = time.ParseDuration(balanceCfgReconciliationInterval)
+		if err != nil {
+			src.errorf("BLOCKCHAIN_PARSER_BALANCE_RECONCILIATION_INTERVAL is not duration: %s", err)
+		} else if reconciliationInterval <= 0 {
+			src.errorf("BLOCKCHAIN_PARSER_BALANCE_RECONCILIATION_INTERVAL must be positive")
+		}
+	}
+	balanceCfg.ReconciliationSchedule = parseJobSchedule(src, "BLOCKCHAIN_PARSER_BALANCE_RECONCILIATION", reconciliationInterval)
+	balanceCfg.ReconciliationPolicy = parseJobPolicy(src, "BLOCKCHAIN_PARSER_BALANCE_RECONCILIATION", 0)
+
+	balanceCfgTraceInternalTransfers, ok := src.lookup("BLOCKCHAIN_PARSER_BALANCE_TRACE_INTERNAL_TRANSFERS")
+	if ok {
+		balanceCfg.TraceInternalTransfers, err = strconv.ParseBool(balanceCfgTraceInternalTransfers)
+		if err != nil {
+			src.errorf("BLOCKCHAIN_PARSER_BALANCE_TRACE_INTERNAL_TRANSFERS is not bool: %s", err)
+		}
+	}
+
+	return balanceCfg
+}
@@ -4,12 +4,53 @@ type Config struct {
 	EthereumHttpClient EthereumHttpClient
 	ParserWorker       ParserWorker
 	Server             Server
+	Stream             Stream
+	Balance            Balance
+	Reparse            Reparse
+	Auth               Auth
+	RateLimit          RateLimit
+	Quota              Quota
+	Health             Health
+	Log                Log
+	Tracing            Tracing
+	Job                Job
 }
 
-func Parse() Config {
-	return Config{
-		EthereumHttpClient: parseEthereumHttpClient(),
-		ParserWorker:       parseParserWorker(),
-		Server:             parseServer(),
+// Job has settings shared by all jobs
+type Job struct {
+	HistorySize int
+}
+
+// Load reads settings from the config file and env vars, env vars override the file. The path is optional,
+// all settings may be set by env vars. Every invalid setting is reported in *ValidationError.
+func Load(path string) (Config, error) {
+	src := newSource()
+	if path != "" {
+		if err := src.readFile(path); err != nil {
+			return Config{}, err
+		}
+	}
+
+	cfg := Config{
+		EthereumHttpClient: parseEthereumHttpClient(src),
+		ParserWorker:       parseParserWorker(src),
+		Server:             parseServer(src),
+		Stream:             parseStream(src),
+		Balance:            parseBalance(src),
+		Reparse:            parseReparse(src),
+		Auth:               parseAuth(src),
+		RateLimit:          parseRateLimit(src),
+		Quota:              parseQuota(src),
+		Health:             parseHealth(src),
+		Log:                parseLog(src),
+		Tracing:            parseTracing(src),
+		Job:                Job{HistorySize: parseJobHistorySize(src)},
 	}
+
+	src.checkUnknown()
+	if err := src.err(); err != nil {
+		return Config{}, err
+	}
+
+	return cfg, nil
 }
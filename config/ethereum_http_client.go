@@ -1,8 +1,8 @@
 package config
 
 import (
-	"log"
-	"os"
+	"errors"
+	"net/url"
 	"time"
 )
 
@@ -11,25 +11,48 @@ type EthereumHttpClient struct {
 	Timeout time.Duration
 }
 
-func parseEthereumHttpClient() EthereumHttpClient {
+func parseEthereumHttpClient(src *source) EthereumHttpClient {
 	var (
 		ok  bool
 		err error
 	)
 
 	ethereumHttpClientCfg := EthereumHttpClient{}
-	ethereumHttpClientCfg.Host, ok = os.LookupEnv("BLOCKCHAIN_PARSER_ETH_HTTP_CLIENT_HOST")
+	ethereumHttpClientCfg.Host, ok = src.lookup("BLOCKCHAIN_PARSER_ETH_HTTP_CLIENT_HOST")
 	if !ok {
-		log.Fatalf("BLOCKCHAIN_PARSER_ETH_HTTP_CLIENT_HOST is required")
+		src.errorf("BLOCKCHAIN_PARSER_ETH_HTTP_CLIENT_HOST is required")
+	} else if err := validateURL(ethereumHttpClientCfg.Host); err != nil {
+		src.errorf("BLOCKCHAIN_PARSER_ETH_HTTP_CLIENT_HOST is not http(s) URL: %s", err)
 	}
-	ethereumHttpClientCfgTimeout, ok := os.LookupEnv("BLOCKCHAIN_PARSER_ETH_HTTP_CLIENT_TIMEOUT")
+
+	ethereumHttpClientCfgTimeout, ok := src.lookup("BLOCKCHAIN_PARSER_ETH_HTTP_CLIENT_TIMEOUT")
 	if !ok {
-		log.Fatalf("BLOCKCHAIN_PARSER_ETH_HTTP_CLIENT_TIMEOUT is required")
+		src.errorf("BLOCKCHAIN_PARSER_ETH_HTTP_CLIENT_TIMEOUT is required")
+	} else {
+		ethereumHttpClientCfg.Timeout, err = time.ParseDuration(ethereumHttpClientCfgTimeout)
+		if err != nil {
+			src.errorf("BLOCKCHAIN_PARSER_ETH_HTTP_CLIENT_TIMEOUT is not duration: %s", err)
+		} else if ethereumHttpClientCfg.Timeout <= 0 {
+			src.errorf("BLOCKCHAIN_PARSER_ETH_HTTP_CLIENT_TIMEOUT must be positive")
+		}
 	}
-	ethereumHttpClientCfg.Timeout, err = time.ParseDuration(ethereumHttpClientCfgTimeout)
+
+	return ethereumHttpClientCfg
+}
+
+// validateURL checks that the value is absolute http or https URL with host
+func validateURL(value string) error {
+	u, err := url.Parse(value)
 	if err != nil {
-		log.Fatalf("BLOCKCHAIN_PARSER_ETH_HTTP_CLIENT_TIMEOUT is not duration: %s", err)
+		return err
 	}
 
-	return ethereumHttpClientCfg
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.New("scheme must be http or https")
+	}
+	if u.Host == "" {
+		return errors.New("host is missing")
+	}
+
+	return nil
 }
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"strconv"
+	"time"
+)
+
+const (
+	defaultHealthMaxParseAge = time.Minute
+	defaultHealthMaxBlockLag = 20
+)
+
+type Health struct {
+	// MaxParseAge is the age of the last parse attempt after which the instance isn't ready
+	MaxParseAge time.Duration
+	// MaxBlockLag is the count of blocks behind the node head after which the instance isn't ready
+	MaxBlockLag uint64
+}
+
+func parseHealth(src *source) Health {
+	var (
+		ok  bool
+		err error
+	)
+
+	healthCfg := Health{
+		MaxParseAge: defaultHealthMaxParseAge,
+		MaxBlockLag: defaultHealthMaxBlockLag,
+	}
+
+	healthCfgMaxParseAge, ok := src.lookup("BLOCKCHAIN_PARSER_HEALTH_MAX_PARSE_AGE")
+	if ok {
+		healthCfg.MaxParseAge, err = time.ParseDuration(healthCfgMaxParseAge)
+		if err != nil {
+			src.errorf("BLOCKCHAIN_PARSER_HEALTH_MAX_PARSE_AGE is not duration: %s", err)
+		} else if healthCfg.MaxParseAge <= 0 {
+			src.errorf("BLOCKCHAIN_PARSER_HEALTH_MAX_PARSE_AGE must be positive")
+		}
+	}
+
+	healthCfgMaxBlockLag, ok := src.lookup("BLOCKCHAIN_PARSER_HEALTH_MAX_BLOCK_LAG")
+	if ok {
+		healthCfg.MaxBlockLag, err = strconv.ParseUint(healthCfgMaxBlockLag, 0, 64)
+		if err != nil {
+			src.errorf("BLOCKCHAIN_PARSER_HEALTH_MAX_BLOCK_LAG is not integer: %s", err)
+		}
+	}
+
+	return healthCfg
+}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"strconv"
+	"time"
+
+	"blockchain-parser/tools/job"
+)
+
+// parseJobSchedule parses <prefix>_SCHEDULE (interval or cron expression), it overrides the interval of the job
+func parseJobSchedule(src *source, prefix string, interval time.Duration) job.Schedule {
+	value, ok := src.lookup(prefix + "_SCHEDULE")
+	if !ok {
+		return job.Every(interval)
+	}
+
+	schedule, err := job.ParseSchedule(value)
+	if err != nil {
+		src.errorf("%s_SCHEDULE is invalid: %s", prefix, err)
+
+		return job.Every(interval)
+	}
+
+	return schedule
+}
+
+// parseJobPolicy parses <prefix>_JITTER, <prefix>_OVERLAP, <prefix>_RUN_TIMEOUT and the common history size
+func parseJobPolicy(src *source, prefix string, defaultJitter time.Duration) job.Policy {
+	var err error
+
+	policy := job.Policy{
+		Jitter:  defaultJitter,
+		Overlap: job.OverlapSkip,
+	}
+
+	jitter, ok := src.lookup(prefix + "_JITTER")
+	if ok {
+		policy.Jitter, err = time.ParseDuration(jitter)
+		if err != nil {
+			src.errorf("%s_JITTER is not duration: %s", prefix, err)
+		} else if policy.Jitter < 0 {
+			src.errorf("%s_JITTER must not be negative", prefix)
+		}
+	}
+
+	overlap, ok := src.lookup(prefix + "_OVERLAP")
+	if ok {
+		policy.Overlap, err = job.ParseOverlapPolicy(overlap)
+		if err != nil {
+			src.errorf("%s_OVERLAP is invalid: %s", prefix, err)
+		}
+	}
+
+	runTimeout, ok := src.lookup(prefix + "_RUN_TIMEOUT")
+	if ok {
+		policy.Timeout, err = time.ParseDuration(runTimeout)
+		if err != nil {
+			src.errorf("%s_RUN_TIMEOUT is not duration: %s", prefix, err)
+		} else if policy.Timeout < 0 {
+			src.errorf("%s_RUN_TIMEOUT must not be negative", prefix)
+		}
+	}
+
+	policy.HistorySize = parseJobHistorySize(src)
+
+	return policy
+}
+
+// parseJobHistorySize parses the history size shared by all jobs
+func parseJobHistorySize(src *source) int {
+	historySize, ok := src.lookup("BLOCKCHAIN_PARSER_JOB_HISTORY_SIZE")
+	if !ok {
+		return 0
+	}
+
+	size, err := strconv.Atoi(historySize)
+	if err != nil || size < 0 {
+		src.errorf("BLOCKCHAIN_PARSER_JOB_HISTORY_SIZE is not non-negative integer: %s", historySize)
+
+		return 0
+	}
+
+	return size
+}
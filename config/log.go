@@ -0,0 +1,40 @@
+package config
+
+import (
+	"blockchain-parser/tools/logger"
+)
+
+type Log struct {
+	Level  logger.Level
+	Format logger.Format
+}
+
+func parseLog(src *source) Log {
+	var (
+		ok  bool
+		err error
+	)
+
+	logCfg := Log{
+		Level:  logger.LevelInfo,
+		Format: logger.FormatText,
+	}
+
+	logCfgLevel, ok := src.lookup("BLOCKCHAIN_PARSER_LOG_LEVEL")
+	if ok {
+		logCfg.Level, err = logger.ParseLevel(logCfgLevel)
+		if err != nil {
+			src.errorf("BLOCKCHAIN_PARSER_LOG_LEVEL is invalid: %s", err)
+		}
+	}
+
+	logCfgFormat, ok := src.lookup("BLOCKCHAIN_PARSER_LOG_FORMAT")
+	if ok {
+		logCfg.Format, err = logger.ParseFormat(logCfgFormat)
+		if err != nil {
+			src.errorf("BLOCKCHAIN_PARSER_LOG_FORMAT is invalid: %s", err)
+		}
+	}
+
+	return logCfg
+}
@@ -1,59 +1,108 @@
 package config
 
 import (
-	"log"
-	"os"
 	"strconv"
 	"strings"
 	"time"
+
+	"blockchain-parser/tools/job"
+)
+
+const (
+	defaultParserWorkerConfirmationDepth = 12
+	defaultParserWorkerMaxWorkers        = 32
 )
 
 type ParserWorker struct {
-	CountWorkers        int
-	Interval            time.Duration
+	CountWorkers int
+	// MaxWorkers limits scaling of workers at runtime
+	MaxWorkers int
+
+	Schedule            job.Schedule
+	Policy              job.Policy
 	StartBlockNumber    int64
 	PredefinedAddresses []string
+	ConfirmationDepth   int
 }
 
-func parseParserWorker() ParserWorker {
+func parseParserWorker(src *source) ParserWorker {
 	var (
 		ok  bool
 		err error
 	)
 
 	parserWorkerCfg := ParserWorker{}
-	parserWorkerCfgCountWorkers, ok := os.LookupEnv("BLOCKCHAIN_PARSER_PARSER_WORKER_COUNT_WORKERS")
+	countWorkersOk := false
+	parserWorkerCfgCountWorkers, ok := src.lookup("BLOCKCHAIN_PARSER_PARSER_WORKER_COUNT_WORKERS")
 	if !ok {
-		log.Fatalf("BLOCKCHAIN_PARSER_PARSER_WORKER_COUNT_WORKERS is required")
-	}
-	parserWorkerCfg.CountWorkers, err = strconv.Atoi(parserWorkerCfgCountWorkers)
-	if err != nil {
-		log.Fatalf("BLOCKCHAIN_PARSER_PARSER_WORKER_COUNT_WORKERS is not integer: %s", err)
+		src.errorf("BLOCKCHAIN_PARSER_PARSER_WORKER_COUNT_WORKERS is required")
+	} else {
+		parserWorkerCfg.CountWorkers, err = strconv.Atoi(parserWorkerCfgCountWorkers)
+		if err != nil {
+			src.errorf("BLOCKCHAIN_PARSER_PARSER_WORKER_COUNT_WORKERS is not integer: %s", err)
+		}
+		countWorkersOk = err == nil
 	}
 
-	parserWorkerCfgInterval, ok := os.LookupEnv("BLOCKCHAIN_PARSER_PARSER_WORKER_INTERVAL")
-	if !ok {
-		log.Fatalf("BLOCKCHAIN_PARSER_PARSER_WORKER_INTERVAL is required")
+	// the interval is required unless the schedule is set
+	var interval time.Duration
+	parserWorkerCfg.MaxWorkers = defaultParserWorkerMaxWorkers
+	parserWorkerCfgMaxWorkers, ok := src.lookup("BLOCKCHAIN_PARSER_PARSER_WORKER_MAX_WORKERS")
+	if ok {
+		parserWorkerCfg.MaxWorkers, err = strconv.Atoi(parserWorkerCfgMaxWorkers)
+		if err != nil {
+			src.errorf("BLOCKCHAIN_PARSER_PARSER_WORKER_MAX_WORKERS is not integer: %s", err)
+			countWorkersOk = false
+		} else if parserWorkerCfg.MaxWorkers < 1 {
+			src.errorf("BLOCKCHAIN_PARSER_PARSER_WORKER_MAX_WORKERS must be positive")
+			countWorkersOk = false
+		}
 	}
-	parserWorkerCfg.Interval, err = time.ParseDuration(parserWorkerCfgInterval)
-	if err != nil {
-		log.Fatalf("BLOCKCHAIN_PARSER_PARSER_WORKER_INTERVAL is not duration: %s", err)
+	// workers can be scaled to zero at runtime, but the service doesn't parse blocks without them
+	if countWorkersOk && (parserWorkerCfg.CountWorkers < 1 || parserWorkerCfg.CountWorkers > parserWorkerCfg.MaxWorkers) {
+		src.errorf("BLOCKCHAIN_PARSER_PARSER_WORKER_COUNT_WORKERS must be from 1 to BLOCKCHAIN_PARSER_PARSER_WORKER_MAX_WORKERS (%d)", parserWorkerCfg.MaxWorkers)
 	}
 
-	parserWorkerCfgStartBlockNumber, ok := os.LookupEnv("BLOCKCHAIN_PARSER_PARSER_WORKER_START_BLOCK_NUMBER")
+	parserWorkerCfgInterval, ok := src.lookup("BLOCKCHAIN_PARSER_PARSER_WORKER_INTERVAL")
+	_, scheduleOk := src.lookup("BLOCKCHAIN_PARSER_PARSER_WORKER_SCHEDULE")
+	if ok {
+		interval, err = time.ParseDuration(parserWorkerCfgInterval)
+		if err != nil {
+			src.errorf("BLOCKCHAIN_PARSER_PARSER_WORKER_INTERVAL is not duration: %s", err)
+		} else if interval <= 0 {
+			src.errorf("BLOCKCHAIN_PARSER_PARSER_WORKER_INTERVAL must be positive")
+		}
+	} else if !scheduleOk {
+		src.errorf("BLOCKCHAIN_PARSER_PARSER_WORKER_INTERVAL is required")
+	}
+	parserWorkerCfg.Schedule = parseJobSchedule(src, "BLOCKCHAIN_PARSER_PARSER_WORKER", interval)
+	parserWorkerCfg.Policy = parseJobPolicy(src, "BLOCKCHAIN_PARSER_PARSER_WORKER", interval/2)
+
+	parserWorkerCfgStartBlockNumber, ok := src.lookup("BLOCKCHAIN_PARSER_PARSER_WORKER_START_BLOCK_NUMBER")
 	if ok {
 		parserWorkerCfg.StartBlockNumber, err = strconv.ParseInt(parserWorkerCfgStartBlockNumber, 0, 64)
 		if err != nil {
-			log.Fatalf("BLOCKCHAIN_PARSER_PARSER_WORKER_START_BLOCK_NUMBER is not hex: %s", err)
+			src.errorf("BLOCKCHAIN_PARSER_PARSER_WORKER_START_BLOCK_NUMBER is not hex: %s", err)
 		}
 	} else {
 		parserWorkerCfg.StartBlockNumber = -1
 	}
 
-	parserWorkerCfgPredefinedAddress, ok := os.LookupEnv("BLOCKCHAIN_PARSER_PARSER_WORKER_PREDEFINED_ADDRESSES")
-	if ok {
+	parserWorkerCfgPredefinedAddress, ok := src.lookup("BLOCKCHAIN_PARSER_PARSER_WORKER_PREDEFINED_ADDRESSES")
+	if ok && parserWorkerCfgPredefinedAddress != "" {
 		parserWorkerCfg.PredefinedAddresses = strings.Split(parserWorkerCfgPredefinedAddress, ",")
 	}
 
+	parserWorkerCfg.ConfirmationDepth = defaultParserWorkerConfirmationDepth
+	parserWorkerCfgConfirmationDepth, ok := src.lookup("BLOCKCHAIN_PARSER_PARSER_WORKER_CONFIRMATION_DEPTH")
+	if ok {
+		parserWorkerCfg.ConfirmationDepth, err = strconv.Atoi(parserWorkerCfgConfirmationDepth)
+		if err != nil {
+			src.errorf("BLOCKCHAIN_PARSER_PARSER_WORKER_CONFIRMATION_DEPTH is not integer: %s", err)
+		} else if parserWorkerCfg.ConfirmationDepth < 1 {
+			src.errorf("BLOCKCHAIN_PARSER_PARSER_WORKER_CONFIRMATION_DEPTH must be positive")
+		}
+	}
+
 	return parserWorkerCfg
 }
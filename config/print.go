@@ -0,0 +1,272 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"blockchain-parser/tools/job"
+	"blockchain-parser/tools/yaml"
+)
+
+const redacted = "<redacted>"
+
+// setting is the effective value of one setting, lists are printed as sequences. Secrets have the redact
+// function, it's applied to the value and items of the list when the setting is printed.
+type setting struct {
+	key    string
+	value  string
+	list   []string
+	isList bool
+	redact func(value string) string
+}
+
+type section struct {
+	key      string
+	settings []setting
+}
+
+// WriteYAML writes the effective config in the format of the config file, secrets are redacted
+func (c Config) WriteYAML(w io.Writer) error {
+	buf := bufio.NewWriter(w)
+
+	for i, s := range c.sections() {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+		buf.WriteString(s.key + ":\n")
+
+		for _, st := range s.settings {
+			st = st.redacted()
+
+			if !st.isList {
+				fmt.Fprintf(buf, "  %s: %s\n", st.key, yaml.Scalar(st.value))
+
+				continue
+			}
+
+			if len(st.list) == 0 {
+				fmt.Fprintf(buf, "  %s: []\n", st.key)
+
+				continue
+			}
+
+			fmt.Fprintf(buf, "  %s:\n", st.key)
+			for _, item := range st.list {
+				fmt.Fprintf(buf, "    - %s\n", yaml.Scalar(item))
+			}
+		}
+	}
+
+	return buf.Flush()
+}
+
+// Changes returns env var names of settings which have other effective values in the next config
+func (c Config) Changes(next Config) []string {
+	current := map[string]setting{}
+	for _, s := range c.sections() {
+		for _, st := range s.settings {
+			current[s.key+"."+st.key] = st
+		}
+	}
+
+	changes := make([]string, 0)
+	for _, s := range next.sections() {
+		for _, st := range s.settings {
+			prev := current[s.key+"."+st.key]
+			if prev.value != st.value || strings.Join(prev.list, ",") != strings.Join(st.list, ",") {
+				changes = append(changes, envPrefix+strings.ToUpper(s.key+"_"+st.key))
+			}
+		}
+	}
+
+	return changes
+}
+
+func (c Config) sections() []section {
+	historySize := c.Job.HistorySize
+	if historySize <= 0 {
+		historySize = job.DefaultHistorySize
+	}
+
+	return []section{
+		{key: "eth_http_client", settings: []setting{
+			scalarSetting("host", c.EthereumHttpClient.Host).redactedBy(redactURL),
+			scalarSetting("timeout", c.EthereumHttpClient.Timeout.String()),
+		}},
+		{key: "parser_worker", settings: append([]setting{
+			scalarSetting("count_workers", strconv.Itoa(c.ParserWorker.CountWorkers)),
+			scalarSetting("max_workers", strconv.Itoa(c.ParserWorker.MaxWorkers)),
+			scalarSetting("start_block_number", strconv.FormatInt(c.ParserWorker.StartBlockNumber, 10)),
+			listSetting("predefined_addresses", c.ParserWorker.PredefinedAddresses),
+			scalarSetting("confirmation_depth", strconv.Itoa(c.ParserWorker.ConfirmationDepth)),
+		}, jobSettings("", c.ParserWorker.Schedule, c.ParserWorker.Policy)...)},
+		{key: "server", settings: []setting{
+			scalarSetting("host", c.Server.Host),
+			scalarSetting("port", c.Server.Port),
+			scalarSetting("request_timeout", c.Server.RequestTimeout.String()),
+			listSetting("route_timeouts", formatRouteTimeouts(c.Server.RouteTimeouts)),
+			scalarSetting("shutdown_timeout", c.Server.ShutdownTimeout.String()),
+		}},
+		{key: "stream", settings: []setting{
+			scalarSetting("retention", strconv.Itoa(c.Stream.Retention)),
+			scalarSetting("heartbeat_interval", c.Stream.HeartbeatInterval.String()),
+		}},
+		{key: "balance", settings: append([]setting{
+			scalarSetting("trace_internal_transfers", strconv.FormatBool(c.Balance.TraceInternalTransfers)),
+		}, jobSettings("reconciliation_", c.Balance.ReconciliationSchedule, c.Balance.ReconciliationPolicy)...)},
+		{key: "reparse", settings: append([]setting{
+			scalarSetting("blocks_per_second", strconv.Itoa(c.Reparse.BlocksPerSecond)),
+			scalarSetting("max_blocks", strconv.FormatUint(c.Reparse.MaxBlocks, 10)),
+		}, jobSettings("", c.Reparse.Schedule, c.Reparse.Policy)...)},
+		{key: "auth", settings: []setting{
+			scalarSetting("admin_key", c.Auth.AdminKey).redactedBy(redactSecret),
+		}},
+		{key: "rate_limit", settings: []setting{
+			scalarSetting("rate", formatFloat(c.RateLimit.Default.Rate)),
+			scalarSetting("burst", strconv.Itoa(c.RateLimit.Default.Burst)),
+			listSetting("routes", formatRouteRateLimits(c.RateLimit.Routes)),
+		}},
+		{key: "quota", settings: []setting{
+			scalarSetting("max_subscriptions", strconv.Itoa(c.Quota.MaxSubscriptions)),
+		}},
+		{key: "health", settings: []setting{
+			scalarSetting("max_parse_age", c.Health.MaxParseAge.String()),
+			scalarSetting("max_block_lag", strconv.FormatUint(c.Health.MaxBlockLag, 10)),
+		}},
+		{key: "log", settings: []setting{
+			scalarSetting("level", c.Log.Level.String()),
+			scalarSetting("format", string(c.Log.Format)),
+		}},
+		{key: "tracing", settings: []setting{
+			scalarSetting("exporter", c.Tracing.Exporter),
+			scalarSetting("service_name", c.Tracing.ServiceName),
+			scalarSetting("sample_ratio", formatFloat(c.Tracing.SampleRatio)),
+			scalarSetting("otlp_endpoint", c.Tracing.OTLPEndpoint).redactedBy(redactURL),
+			listSetting("otlp_headers", formatOTLPHeaders(c.Tracing.OTLPHeaders)).redactedBy(redactHeader),
+			scalarSetting("otlp_timeout", c.Tracing.OTLPTimeout.String()),
+		}},
+		{key: "job", settings: []setting{
+			scalarSetting("history_size", strconv.Itoa(historySize)),
+		}},
+	}
+}
+
+func scalarSetting(key, value string) setting {
+	return setting{key: key, value: value}
+}
+
+func listSetting(key string, items []string) setting {
+	return setting{key: key, list: items, isList: true}
+}
+
+func (s setting) redactedBy(redact func(value string) string) setting {
+	s.redact = redact
+
+	return s
+}
+
+func (s setting) redacted() setting {
+	if s.redact == nil {
+		return s
+	}
+
+	s.value = s.redact(s.value)
+	if s.list != nil {
+		items := make([]string, 0, len(s.list))
+		for _, item := range s.list {
+			items = append(items, s.redact(item))
+		}
+		s.list = items
+	}
+
+	return s
+}
+
+// jobSettings prints the effective schedule and policy, the schedule replaces the interval of the job
+func jobSettings(prefix string, schedule job.Schedule, policy job.Policy) []setting {
+	scheduleValue := ""
+	if schedule != nil {
+		scheduleValue = schedule.String()
+	}
+
+	return []setting{
+		scalarSetting(prefix+"schedule", scheduleValue),
+		scalarSetting(prefix+"jitter", policy.Jitter.String()),
+		scalarSetting(prefix+"overlap", string(policy.Overlap)),
+		scalarSetting(prefix+"run_timeout", policy.Timeout.String()),
+	}
+}
+
+func formatRouteTimeouts(timeouts map[string]time.Duration) []string {
+	items := make([]string, 0, len(timeouts))
+	for route, timeout := range timeouts {
+		items = append(items, route+"="+timeout.String())
+	}
+	sort.Strings(items)
+
+	return items
+}
+
+func formatRouteRateLimits(limits map[string]RouteRateLimit) []string {
+	items := make([]string, 0, len(limits))
+	for route, limit := range limits {
+		items = append(items, fmt.Sprintf("%s=%s:%d", route, formatFloat(limit.Rate), limit.Burst))
+	}
+	sort.Strings(items)
+
+	return items
+}
+
+func formatOTLPHeaders(headers map[string]string) []string {
+	items := make([]string, 0, len(headers))
+	for name, headerValue := range headers {
+		items = append(items, name+"="+headerValue)
+	}
+	sort.Strings(items)
+
+	return items
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// redactHeader keeps the name of the header only, values are usually credentials
+func redactHeader(header string) string {
+	name, headerValue, _ := strings.Cut(header, "=")
+
+	return name + "=" + redactSecret(headerValue)
+}
+
+func redactSecret(secret string) string {
+	if secret == "" {
+		return ""
+	}
+
+	return redacted
+}
+
+// redactURL hides the password and query values of the URL, node providers often pass API keys there.
+// The URL marker of url.URL.Redacted is used to keep the URL readable.
+func redactURL(value string) string {
+	u, err := url.Parse(value)
+	if err != nil {
+		return redacted
+	}
+
+	if u.RawQuery != "" {
+		query := u.Query()
+		for key := range query {
+			query[key] = []string{"xxxxx"}
+		}
+		u.RawQuery = query.Encode()
+	}
+
+	return u.Redacted()
+}
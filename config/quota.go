@@ -0,0 +1,31 @@
+package config
+
+import (
+	"strconv"
+)
+
+type Quota struct {
+	// MaxSubscriptions caps subscriptions of a tenant unless the tenant has own cap, zero means no cap
+	MaxSubscriptions int
+}
+
+func parseQuota(src *source) Quota {
+	var (
+		ok  bool
+		err error
+	)
+
+	quotaCfg := Quota{}
+
+	quotaCfgMaxSubscriptions, ok := src.lookup("BLOCKCHAIN_PARSER_QUOTA_MAX_SUBSCRIPTIONS")
+	if ok {
+		quotaCfg.MaxSubscriptions, err = strconv.Atoi(quotaCfgMaxSubscriptions)
+		if err != nil {
+			src.errorf("BLOCKCHAIN_PARSER_QUOTA_MAX_SUBSCRIPTIONS is not integer: %s", err)
+		} else if quotaCfg.MaxSubscriptions < 0 {
+			src.errorf("BLOCKCHAIN_PARSER_QUOTA_MAX_SUBSCRIPTIONS must not be negative")
+		}
+	}
+
+	return quotaCfg
+}
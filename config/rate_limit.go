@@ -0,0 +1,105 @@
+package config
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultRateLimitRate  = 50
+	defaultRateLimitBurst = 100
+)
+
+// RouteRateLimit is the refill rate in requests per second and the burst of requests of one client
+type RouteRateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+type RateLimit struct {
+	// Default is applied to routes without own limit, zero rate disables limiting
+	Default RouteRateLimit
+	// Routes are limits by route, e.g. "GET /v1/addresses/{address}/transactions"
+	Routes map[string]RouteRateLimit
+}
+
+func parseRateLimit(src *source) RateLimit {
+	var (
+		ok  bool
+		err error
+	)
+
+	rateLimitCfg := RateLimit{
+		Default: RouteRateLimit{
+			Rate:  defaultRateLimitRate,
+			Burst: defaultRateLimitBurst,
+		},
+		Routes: map[string]RouteRateLimit{},
+	}
+
+	rateLimitCfgRate, ok := src.lookup("BLOCKCHAIN_PARSER_RATE_LIMIT_RATE")
+	if ok {
+		rateLimitCfg.Default.Rate, err = strconv.ParseFloat(rateLimitCfgRate, 64)
+		if err != nil {
+			src.errorf("BLOCKCHAIN_PARSER_RATE_LIMIT_RATE is not number: %s", err)
+		} else if rateLimitCfg.Default.Rate < 0 {
+			src.errorf("BLOCKCHAIN_PARSER_RATE_LIMIT_RATE must not be negative")
+		}
+	}
+
+	rateLimitCfgBurst, ok := src.lookup("BLOCKCHAIN_PARSER_RATE_LIMIT_BURST")
+	if ok {
+		rateLimitCfg.Default.Burst, err = strconv.Atoi(rateLimitCfgBurst)
+		if err != nil {
+			src.errorf("BLOCKCHAIN_PARSER_RATE_LIMIT_BURST is not integer: %s", err)
+		} else if rateLimitCfg.Default.Burst < 1 {
+			src.errorf("BLOCKCHAIN_PARSER_RATE_LIMIT_BURST must be positive")
+		}
+	}
+
+	rateLimitCfgRoutes, ok := src.lookup("BLOCKCHAIN_PARSER_RATE_LIMIT_ROUTES")
+	if ok && rateLimitCfgRoutes != "" {
+		for _, value := range strings.Split(rateLimitCfgRoutes, ",") {
+			route, limit, err := parseRouteRateLimit(value)
+			if err != nil {
+				src.errorf("BLOCKCHAIN_PARSER_RATE_LIMIT_ROUTES has invalid limit (%s): %s", value, err)
+
+				continue
+			}
+
+			rateLimitCfg.Routes[route] = limit
+		}
+	}
+
+	return rateLimitCfg
+}
+
+// parseRouteRateLimit parses "<method> <path>=<rate>:<burst>"
+func parseRouteRateLimit(value string) (string, RouteRateLimit, error) {
+	i := strings.LastIndex(value, "=")
+	if i < 0 {
+		return "", RouteRateLimit{}, errors.New("format is <method> <path>=<rate>:<burst>")
+	}
+	route, limitValue := strings.TrimSpace(value[:i]), value[i+1:]
+
+	rateValue, burstValue, ok := strings.Cut(limitValue, ":")
+	if !ok || route == "" {
+		return "", RouteRateLimit{}, errors.New("format is <method> <path>=<rate>:<burst>")
+	}
+
+	rate, err := strconv.ParseFloat(rateValue, 64)
+	if err != nil {
+		return "", RouteRateLimit{}, err
+	}
+
+	burst, err := strconv.Atoi(burstValue)
+	if err != nil {
+		return "", RouteRateLimit{}, err
+	}
+	if rate < 0 || burst < 1 {
+		return "", RouteRateLimit{}, errors.New("rate must not be negative and burst must be positive")
+	}
+
+	return strings.Join(strings.Fields(route), " "), RouteRateLimit{Rate: rate, Burst: burst}, nil
+}
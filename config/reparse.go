@@ -0,0 +1,61 @@
+package config
+
+import (
+	"strconv"
+	"time"
+
+	"blockchain-parser/tools/job"
+)
+
+const (
+	defaultReparseInterval        = 5 * time.Second
+	defaultReparseBlocksPerSecond = 10
+	defaultReparseMaxBlocks       = 100000
+
+	// maxReparseBlocksPerSecond keeps the interval between fetched blocks above zero
+	maxReparseBlocksPerSecond = 1000
+)
+
+type Reparse struct {
+	// Schedule is how often the job checks for queued tasks, a run lasts until all queued tasks are done
+	Schedule        job.Schedule
+	Policy          job.Policy
+	BlocksPerSecond int
+	MaxBlocks       uint64
+}
+
+func parseReparse(src *source) Reparse {
+	var (
+		ok  bool
+		err error
+	)
+
+	reparseCfg := Reparse{
+		Schedule:        parseJobSchedule(src, "BLOCKCHAIN_PARSER_REPARSE", defaultReparseInterval),
+		Policy:          parseJobPolicy(src, "BLOCKCHAIN_PARSER_REPARSE", 0),
+		BlocksPerSecond: defaultReparseBlocksPerSecond,
+		MaxBlocks:       defaultReparseMaxBlocks,
+	}
+
+	reparseCfgBlocksPerSecond, ok := src.lookup("BLOCKCHAIN_PARSER_REPARSE_BLOCKS_PER_SECOND")
+	if ok {
+		reparseCfg.BlocksPerSecond, err = strconv.Atoi(reparseCfgBlocksPerSecond)
+		if err != nil {
+			src.errorf("BLOCKCHAIN_PARSER_REPARSE_BLOCKS_PER_SECOND is not integer: %s", err)
+		} else if reparseCfg.BlocksPerSecond <= 0 || reparseCfg.BlocksPerSecond > maxReparseBlocksPerSecond {
+			src.errorf("BLOCKCHAIN_PARSER_REPARSE_BLOCKS_PER_SECOND must be from 1 to %d", maxReparseBlocksPerSecond)
+		}
+	}
+
+	reparseCfgMaxBlocks, ok := src.lookup("BLOCKCHAIN_PARSER_REPARSE_MAX_BLOCKS")
+	if ok {
+		reparseCfg.MaxBlocks, err = strconv.ParseUint(reparseCfgMaxBlocks, 0, 64)
+		if err != nil {
+			src.errorf("BLOCKCHAIN_PARSER_REPARSE_MAX_BLOCKS is not integer: %s", err)
+		} else if reparseCfg.MaxBlocks == 0 {
+			src.errorf("BLOCKCHAIN_PARSER_REPARSE_MAX_BLOCKS must be positive")
+		}
+	}
+
+	return reparseCfg
+}
@@ -1,30 +1,130 @@
 package config
 
 import (
-	"log"
-	"os"
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultServerRequestTimeout  = 30 * time.Second
+	defaultServerShutdownTimeout = 30 * time.Second
 )
 
 type Server struct {
 	Host string
 	Port string
+	// RequestTimeout is the deadline of requests of routes without own timeout, zero disables the deadline.
+	// Streams have no deadline unless it's set for their route.
+	RequestTimeout time.Duration
+	// RouteTimeouts are deadlines by route, e.g. "POST /v1/transactions/query"
+	RouteTimeouts map[string]time.Duration
+	// ShutdownTimeout limits the time to finish in-flight blocks and requests on shutdown
+	ShutdownTimeout time.Duration
 }
 
-func parseServer() Server {
+func parseServer(src *source) Server {
 	var (
-		ok bool
+		ok  bool
+		err error
 	)
 
-	serverCfg := Server{}
-	serverCfg.Host, ok = os.LookupEnv("BLOCKCHAIN_PARSER_SERVER_HOST")
+	serverCfg := Server{
+		RequestTimeout:  defaultServerRequestTimeout,
+		RouteTimeouts:   map[string]time.Duration{},
+		ShutdownTimeout: defaultServerShutdownTimeout,
+	}
+	serverCfg.Host, ok = src.lookup("BLOCKCHAIN_PARSER_SERVER_HOST")
 	if !ok {
-		log.Fatalf("BLOCKCHAIN_PARSER_SERVER_HOST is required")
+		src.errorf("BLOCKCHAIN_PARSER_SERVER_HOST is required")
+	} else if err := validateHost(serverCfg.Host); err != nil {
+		src.errorf("BLOCKCHAIN_PARSER_SERVER_HOST is not host: %s", err)
 	}
 
-	serverCfg.Port, ok = os.LookupEnv("BLOCKCHAIN_PARSER_SERVER_PORT")
+	serverCfg.Port, ok = src.lookup("BLOCKCHAIN_PARSER_SERVER_PORT")
 	if !ok {
-		log.Fatalf("BLOCKCHAIN_PARSER_SERVER_PORT is required")
+		src.errorf("BLOCKCHAIN_PARSER_SERVER_PORT is required")
+	} else if port, err := strconv.Atoi(serverCfg.Port); err != nil || port < 1 || port > 65535 {
+		src.errorf("BLOCKCHAIN_PARSER_SERVER_PORT is not port from 1 to 65535: %s", serverCfg.Port)
+	}
+
+	serverCfgRequestTimeout, ok := src.lookup("BLOCKCHAIN_PARSER_SERVER_REQUEST_TIMEOUT")
+	if ok {
+		serverCfg.RequestTimeout, err = time.ParseDuration(serverCfgRequestTimeout)
+		if err != nil {
+			src.errorf("BLOCKCHAIN_PARSER_SERVER_REQUEST_TIMEOUT is not duration: %s", err)
+		} else if serverCfg.RequestTimeout < 0 {
+			src.errorf("BLOCKCHAIN_PARSER_SERVER_REQUEST_TIMEOUT must not be negative")
+		}
+	}
+
+	serverCfgRouteTimeouts, ok := src.lookup("BLOCKCHAIN_PARSER_SERVER_ROUTE_TIMEOUTS")
+	if ok && serverCfgRouteTimeouts != "" {
+		for _, value := range strings.Split(serverCfgRouteTimeouts, ",") {
+			route, timeout, err := parseRouteTimeout(value)
+			if err != nil {
+				src.errorf("BLOCKCHAIN_PARSER_SERVER_ROUTE_TIMEOUTS has invalid timeout (%s): %s", value, err)
+
+				continue
+			}
+
+			serverCfg.RouteTimeouts[route] = timeout
+		}
+	}
+
+	serverCfgShutdownTimeout, ok := src.lookup("BLOCKCHAIN_PARSER_SERVER_SHUTDOWN_TIMEOUT")
+	if ok {
+		serverCfg.ShutdownTimeout, err = time.ParseDuration(serverCfgShutdownTimeout)
+		if err != nil {
+			src.errorf("BLOCKCHAIN_PARSER_SERVER_SHUTDOWN_TIMEOUT is not duration: %s", err)
+		} else if serverCfg.ShutdownTimeout <= 0 {
+			src.errorf("BLOCKCHAIN_PARSER_SERVER_SHUTDOWN_TIMEOUT must be positive")
+		}
 	}
 
 	return serverCfg
 }
+
+// validateHost checks that the value is IP or host name, empty host listens on all interfaces
+func validateHost(value string) error {
+	if value == "" || net.ParseIP(value) != nil {
+		return nil
+	}
+
+	if len(value) > 253 {
+		return errors.New("host name is too long")
+	}
+	for _, label := range strings.Split(value, ".") {
+		if label == "" || len(label) > 63 || strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return errors.New("host name has invalid label")
+		}
+		for _, c := range label {
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-') {
+				return errors.New("host name has invalid character")
+			}
+		}
+	}
+
+	return nil
+}
+
+// parseRouteTimeout parses "<method> <path>=<duration>"
+func parseRouteTimeout(value string) (string, time.Duration, error) {
+	i := strings.LastIndex(value, "=")
+	if i < 0 {
+		return "", 0, errors.New("format is <method> <path>=<duration>")
+	}
+	route, timeoutValue := strings.TrimSpace(value[:i]), strings.TrimSpace(value[i+1:])
+	if route == "" {
+		return "", 0, errors.New("format is <method> <path>=<duration>")
+	}
+
+	timeout, err := time.ParseDuration(timeoutValue)
+	if err != nil {
+		return "", 0, err
+	}
+
+	return strings.Join(strings.Fields(route), " "), timeout, nil
+}
@@ -0,0 +1,186 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"blockchain-parser/tools/yaml"
+)
+
+const envPrefix = "BLOCKCHAIN_PARSER_"
+
+// ValidationError is the report of all invalid settings
+type ValidationError struct {
+	Errors []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e.Errors, "\n  - ")
+}
+
+// source looks settings up by env var name, env vars override the config file. Problems are collected
+// instead of stopping on the first one.
+type source struct {
+	// file values by env var name, paths keep keys of the file for messages
+	file  map[string]string
+	paths map[string]string
+	used  map[string]bool
+	errs  []string
+}
+
+func newSource() *source {
+	return &source{
+		file:  map[string]string{},
+		paths: map[string]string{},
+		used:  map[string]bool{},
+	}
+}
+
+func (s *source) lookup(name string) (string, bool) {
+	s.used[name] = true
+
+	if value, ok := os.LookupEnv(name); ok {
+		return value, true
+	}
+
+	value, ok := s.file[name]
+
+	return value, ok
+}
+
+// errorf adds the problem to the report, settings shared by sections are reported once
+func (s *source) errorf(format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	for _, err := range s.errs {
+		if err == msg {
+			return
+		}
+	}
+
+	s.errs = append(s.errs, msg)
+}
+
+// checkUnknown reports settings of the file which no section looked up, they're usually typos
+func (s *source) checkUnknown() {
+	names := make([]string, 0)
+	for name := range s.file {
+		if !s.used[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		s.errorf("%s of the config file is unknown setting (%s)", s.paths[name], name)
+	}
+}
+
+func (s *source) err() error {
+	if len(s.errs) == 0 {
+		return nil
+	}
+
+	return &ValidationError{Errors: s.errs}
+}
+
+// readFile reads YAML (.yaml, .yml) or JSON (.json) config file. Keys are nested by sections, the path of the key
+// joined with _ is the env var name without the prefix, e.g. server.port is BLOCKCHAIN_PARSER_SERVER_PORT.
+// Lists are joined with commas like in env vars, null values are left unset.
+func (s *source) readFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var values map[string]interface{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		values, err = yaml.Parse(data)
+	case ".json":
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		err = decoder.Decode(&values)
+	default:
+		return fmt.Errorf("unsupported extension of config file %s, use .yaml, .yml or .json", path)
+	}
+	if err != nil {
+		return fmt.Errorf("fail parse config file %s: %w", path, err)
+	}
+
+	return s.flatten("", values)
+}
+
+func (s *source) flatten(path string, values map[string]interface{}) error {
+	for key, value := range values {
+		keyPath := key
+		if path != "" {
+			keyPath = path + "." + key
+		}
+
+		if value == nil {
+			continue
+		}
+
+		if nested, ok := value.(map[string]interface{}); ok {
+			if err := s.flatten(keyPath, nested); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		text, err := formatFileValue(value)
+		if err != nil {
+			return fmt.Errorf("%s of the config file %w", keyPath, err)
+		}
+
+		name := envPrefix + strings.ToUpper(strings.ReplaceAll(keyPath, ".", "_"))
+		if other, ok := s.paths[name]; ok {
+			return fmt.Errorf("%s and %s of the config file are the same setting %s", other, keyPath, name)
+		}
+
+		s.file[name] = text
+		s.paths[name] = keyPath
+	}
+
+	return nil
+}
+
+func formatFileValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case json.Number:
+		return v.String(), nil
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			if _, ok := item.([]interface{}); ok {
+				return "", fmt.Errorf("must be a list of values")
+			}
+			if _, ok := item.(map[string]interface{}); ok {
+				return "", fmt.Errorf("must be a list of values")
+			}
+
+			text, err := formatFileValue(item)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, text)
+		}
+
+		return strings.Join(items, ","), nil
+	}
+
+	return "", fmt.Errorf("has unsupported value %v", value)
+}
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"strconv"
+	"time"
+)
+
+const (
+	defaultStreamRetention         = 10000
+	defaultStreamHeartbeatInterval = 15 * time.Second
+)
+
+type Stream struct {
+	Retention         int
+	HeartbeatInterval time.Duration
+}
+
+func parseStream(src *source) Stream {
+	var (
+		ok  bool
+		err error
+	)
+
+	streamCfg := Stream{
+		Retention:         defaultStreamRetention,
+		HeartbeatInterval: defaultStreamHeartbeatInterval,
+	}
+
+	streamCfgRetention, ok := src.lookup("BLOCKCHAIN_PARSER_STREAM_RETENTION")
+	if ok {
+		streamCfg.Retention, err = strconv.Atoi(streamCfgRetention)
+		if err != nil {
+			src.errorf("BLOCKCHAIN_PARSER_STREAM_RETENTION is not integer: %s", err)
+		} else if streamCfg.Retention < 1 {
+			src.errorf("BLOCKCHAIN_PARSER_STREAM_RETENTION must be positive")
+		}
+	}
+
+	streamCfgHeartbeatInterval, ok := src.lookup("BLOCKCHAIN_PARSER_STREAM_HEARTBEAT_INTERVAL")
+	if ok {
+		streamCfg.HeartbeatInterval, err = time.ParseDuration(streamCfgHeartbeatInterval)
+		if err != nil {
+			src.errorf("BLOCKCHAIN_PARSER_STREAM_HEARTBEAT_INTERVAL is not duration: %s", err)
+		} else if streamCfg.HeartbeatInterval <= 0 {
+			src.errorf("BLOCKCHAIN_PARSER_STREAM_HEARTBEAT_INTERVAL must be positive")
+		}
+	}
+
+	return streamCfg
+}
@@ -0,0 +1,106 @@
+package config
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	TracingExporterNone   = "none"
+	TracingExporterStdout = "stdout"
+	TracingExporterOTLP   = "otlp"
+
+	defaultTracingServiceName  = "blockchain-parser"
+	defaultTracingSampleRatio  = 1
+	defaultTracingOTLPEndpoint = "http://localhost:4318/v1/traces"
+	defaultTracingOTLPTimeout  = 10 * time.Second
+)
+
+type Tracing struct {
+	// Exporter is one of none, stdout or otlp, none disables tracing
+	Exporter    string
+	ServiceName string
+	// SampleRatio is the ratio of traced root spans, spans with parent follow the parent decision
+	SampleRatio  float64
+	OTLPEndpoint string
+	OTLPHeaders  map[string]string
+	OTLPTimeout  time.Duration
+}
+
+func parseTracing(src *source) Tracing {
+	var (
+		ok  bool
+		err error
+	)
+
+	tracingCfg := Tracing{
+		Exporter:     TracingExporterNone,
+		ServiceName:  defaultTracingServiceName,
+		SampleRatio:  defaultTracingSampleRatio,
+		OTLPEndpoint: defaultTracingOTLPEndpoint,
+		OTLPHeaders:  map[string]string{},
+		OTLPTimeout:  defaultTracingOTLPTimeout,
+	}
+
+	tracingCfgExporter, ok := src.lookup("BLOCKCHAIN_PARSER_TRACING_EXPORTER")
+	if ok {
+		switch tracingCfgExporter {
+		case TracingExporterNone, TracingExporterStdout, TracingExporterOTLP:
+			tracingCfg.Exporter = tracingCfgExporter
+		default:
+			src.errorf("BLOCKCHAIN_PARSER_TRACING_EXPORTER is one of none, stdout, otlp: %s", tracingCfgExporter)
+		}
+	}
+
+	tracingCfgServiceName, ok := src.lookup("BLOCKCHAIN_PARSER_TRACING_SERVICE_NAME")
+	if ok && tracingCfgServiceName != "" {
+		tracingCfg.ServiceName = tracingCfgServiceName
+	}
+
+	tracingCfgSampleRatio, ok := src.lookup("BLOCKCHAIN_PARSER_TRACING_SAMPLE_RATIO")
+	if ok {
+		tracingCfg.SampleRatio, err = strconv.ParseFloat(tracingCfgSampleRatio, 64)
+		if err != nil {
+			src.errorf("BLOCKCHAIN_PARSER_TRACING_SAMPLE_RATIO is not number: %s", err)
+		} else if tracingCfg.SampleRatio < 0 || tracingCfg.SampleRatio > 1 {
+			src.errorf("BLOCKCHAIN_PARSER_TRACING_SAMPLE_RATIO is not between 0 and 1: %s", tracingCfgSampleRatio)
+		}
+	}
+
+	tracingCfgOTLPEndpoint, ok := src.lookup("BLOCKCHAIN_PARSER_TRACING_OTLP_ENDPOINT")
+	if ok && tracingCfgOTLPEndpoint != "" {
+		tracingCfg.OTLPEndpoint = tracingCfgOTLPEndpoint
+	}
+	if tracingCfg.Exporter == TracingExporterOTLP {
+		if err := validateURL(tracingCfg.OTLPEndpoint); err != nil {
+			src.errorf("BLOCKCHAIN_PARSER_TRACING_OTLP_ENDPOINT is not http(s) URL: %s", err)
+		}
+	}
+
+	tracingCfgOTLPHeaders, ok := src.lookup("BLOCKCHAIN_PARSER_TRACING_OTLP_HEADERS")
+	if ok && tracingCfgOTLPHeaders != "" {
+		for _, header := range strings.Split(tracingCfgOTLPHeaders, ",") {
+			i := strings.Index(header, "=")
+			if i <= 0 {
+				src.errorf("BLOCKCHAIN_PARSER_TRACING_OTLP_HEADERS has invalid header: %s", header)
+
+				continue
+			}
+
+			tracingCfg.OTLPHeaders[strings.TrimSpace(header[:i])] = strings.TrimSpace(header[i+1:])
+		}
+	}
+
+	tracingCfgOTLPTimeout, ok := src.lookup("BLOCKCHAIN_PARSER_TRACING_OTLP_TIMEOUT")
+	if ok {
+		tracingCfg.OTLPTimeout, err = time.ParseDuration(tracingCfgOTLPTimeout)
+		if err != nil {
+			src.errorf("BLOCKCHAIN_PARSER_TRACING_OTLP_TIMEOUT is not duration: %s", err)
+		} else if tracingCfg.OTLPTimeout <= 0 {
+			src.errorf("BLOCKCHAIN_PARSER_TRACING_OTLP_TIMEOUT must be positive")
+		}
+	}
+
+	return tracingCfg
+}
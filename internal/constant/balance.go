@@ -0,0 +1,14 @@
+package constant
+
+const (
+	BalanceChangeReasonTransaction      = "transaction"
+	BalanceChangeReasonFee              = "fee"
+	BalanceChangeReasonWithdrawal       = "withdrawal"
+	BalanceChangeReasonInternalTransfer = "internal_transfer"
+	BalanceChangeReasonReconciliation   = "reconciliation"
+)
+
+const (
+	BalanceSourceTracked = "tracked"
+	BalanceSourceNode    = "node"
+)
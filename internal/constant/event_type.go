@@ -0,0 +1,7 @@
+package constant
+
+const (
+	EventTypeTransaction  = "transaction"
+	EventTypeConfirmation = "confirmation"
+	EventTypeRetraction   = "retraction"
+)
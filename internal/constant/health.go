@@ -0,0 +1,14 @@
+package constant
+
+const (
+	HealthStatusOK   = "ok"
+	HealthStatusFail = "fail"
+)
+
+const (
+	HealthCheckProcess  = "process"
+	HealthCheckNode     = "node"
+	HealthCheckParsing  = "parsing"
+	HealthCheckLag      = "lag"
+	HealthCheckShutdown = "shutdown"
+)
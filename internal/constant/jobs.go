@@ -1,5 +1,7 @@
 package constant
 
 const (
-	ParserWorkerJobName = "parser_worker"
+	ParserWorkerJobName      = "parser_worker"
+	BalanceReconcilerJobName = "balance_reconciler"
+	ReparseJobName           = "reparse"
 )
@@ -0,0 +1,9 @@
+package constant
+
+const (
+	ReparseStatusQueued    = "queued"
+	ReparseStatusRunning   = "running"
+	ReparseStatusSucceeded = "succeeded"
+	ReparseStatusFailed    = "failed"
+	ReparseStatusCanceled  = "canceled"
+)
@@ -0,0 +1,6 @@
+package constant
+
+// DefaultTenantID owns all requests when authentication is disabled and predefined addresses.
+const (
+	DefaultTenantID = "default"
+)
@@ -0,0 +1,22 @@
+package constant
+
+const (
+	TransactionKindTransaction = "transaction"
+	TransactionKindWithdrawal  = "withdrawal"
+)
+
+const (
+	TransactionDirectionIn  = "in"
+	TransactionDirectionOut = "out"
+
+	OrderAsc  = "asc"
+	OrderDesc = "desc"
+)
+
+const (
+	TransactionLookupReasonIndexed                = "indexed"
+	TransactionLookupReasonPending                = "pending"
+	TransactionLookupReasonBlockNotParsed         = "block_not_parsed"
+	TransactionLookupReasonNotSubscribed          = "not_subscribed"
+	TransactionLookupReasonSubscribedAfterParsing = "subscribed_after_parsing"
+)
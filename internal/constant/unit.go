@@ -0,0 +1,8 @@
+package constant
+
+// Units of amounts in API responses, hex wei is the node format and the default.
+const (
+	UnitHex   = "hex"
+	UnitWei   = "wei"
+	UnitEther = "ether"
+)
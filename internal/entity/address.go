@@ -0,0 +1,82 @@
+package entity
+
+import (
+	"encoding/hex"
+	"strings"
+
+	errorpkg "blockchain-parser/internal/error"
+	"blockchain-parser/tools/keccak"
+)
+
+const (
+	addressPrefix    = "0x"
+	addressHexLength = 40
+)
+
+// Address is an account address in canonical form: 0x prefix and 40 lowercase hex characters.
+// Addresses coming from users or from the node must be built with ParseAddress, Checksum renders the EIP-55 form.
+type Address string
+
+// ParseAddress validates the address and returns it in canonical form. Lowercase and uppercase addresses
+// are accepted as is, mixed case address must have valid EIP-55 checksum.
+func ParseAddress(s string) (Address, error) {
+	if !strings.HasPrefix(s, addressPrefix) && !strings.HasPrefix(s, "0X") {
+		return "", errorpkg.NewInvalidAddress("address", "address must start with 0x")
+	}
+
+	hexPart := s[len(addressPrefix):]
+	if len(hexPart) != addressHexLength {
+		return "", errorpkg.NewInvalidAddress("address", "address must contain 40 hex characters")
+	}
+	if _, err := hex.DecodeString(hexPart); err != nil {
+		return "", errorpkg.NewInvalidAddress("address", "address must contain only hex characters")
+	}
+
+	address := Address(addressPrefix + strings.ToLower(hexPart))
+	if isMixedCase(hexPart) && address.Checksum() != addressPrefix+hexPart {
+		return "", errorpkg.NewInvalidAddress("address", "address has invalid EIP-55 checksum")
+	}
+
+	return address, nil
+}
+
+// Canonical lowercases the address, it keeps repository keys canonical when the address is not built by ParseAddress.
+func (a Address) Canonical() Address {
+	return Address(strings.ToLower(string(a)))
+}
+
+// Checksum returns EIP-55 form of the address: a letter is uppercased when the matching nibble
+// of Keccak-256 hash of the lowercase hex is 8 or greater.
+func (a Address) Checksum() string {
+	if a == "" {
+		return ""
+	}
+
+	hexPart := strings.ToLower(strings.TrimPrefix(string(a), addressPrefix))
+	hash := keccak.Sum256([]byte(hexPart))
+
+	checksum := []byte(hexPart)
+	for i, c := range checksum {
+		if c < 'a' || c > 'f' {
+			continue
+		}
+
+		nibble := hash[i/2] >> 4
+		if i%2 == 1 {
+			nibble = hash[i/2] & 0x0f
+		}
+		if nibble >= 8 {
+			checksum[i] = c - 'a' + 'A'
+		}
+	}
+
+	return addressPrefix + string(checksum)
+}
+
+func (a Address) String() string {
+	return string(a)
+}
+
+func isMixedCase(s string) bool {
+	return strings.ToLower(s) != s && strings.ToUpper(s) != s
+}
@@ -0,0 +1,94 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+
+	errorpkg "blockchain-parser/internal/error"
+)
+
+func TestParseAddress(t *testing.T) {
+	tests := []struct {
+		name    string
+		address string
+		want    Address
+		wantErr error
+	}{
+		{
+			name:    "lowercase",
+			address: "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed",
+			want:    "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed",
+		},
+		{
+			name:    "uppercase",
+			address: "0X5AAEB6053F3E94C9B9A09F33669435E7EF1BEAED",
+			want:    "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed",
+		},
+		{
+			name:    "valid checksum",
+			address: "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+			want:    "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed",
+		},
+		{
+			name:    "invalid checksum",
+			address: "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD",
+			wantErr: errorpkg.InvalidAddress,
+		},
+		{
+			name:    "no prefix",
+			address: "5aaeb6053f3e94c9b9a09f33669435e7ef1beaed",
+			wantErr: errorpkg.InvalidAddress,
+		},
+		{
+			name:    "short",
+			address: "0x5aaeb6053f3e94c9b9a09f33669435e7ef1bea",
+			wantErr: errorpkg.InvalidAddress,
+		},
+		{
+			name:    "not hex",
+			address: "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beazz",
+			wantErr: errorpkg.InvalidAddress,
+		},
+		{
+			name:    "empty",
+			address: "",
+			wantErr: errorpkg.InvalidAddress,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAddress(tt.address)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ParseAddress() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseAddress() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddress_Checksum(t *testing.T) {
+	tests := []string{
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+		"0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB",
+		"0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb",
+		"0x52908400098527886E0F7030069857D2E4169EE7",
+		"0xde709f2102306220921060314715629080e2fb77",
+	}
+
+	for _, want := range tests {
+		t.Run(want, func(t *testing.T) {
+			address, err := ParseAddress(want)
+			if err != nil {
+				t.Fatalf("ParseAddress() error = %v", err)
+			}
+			if got := address.Checksum(); got != want {
+				t.Errorf("Checksum() got = %v, want %v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package entity
+
+import "time"
+
+// BalanceSnapshot is the balance of the address fetched from the node at subscription time.
+// Balance at the next blocks is the snapshot value plus balance changes recorded by the parser.
+type BalanceSnapshot struct {
+	Address               Address
+	BlockNumber           BlockNumber
+	Value                 Wei
+	ReconciledBlockNumber BlockNumber
+	CreatedAt             time.Time
+}
+
+// BalanceChange is a credit or a debit of the address in the block. ID is unique per address,
+// so reprocessing of the block overwrites changes instead of duplicating them.
+type BalanceChange struct {
+	ID          string
+	Address     Address
+	BlockNumber BlockNumber
+	Reason      string
+	Hash        string
+	Delta       Wei
+}
+
+type Balance struct {
+	Address     Address
+	BlockNumber BlockNumber
+	Value       Wei
+	Source      string
+	// Drift is the sum of reconciliation corrections up to the block, nil when the running balance never drifted
+	Drift *Wei
+}
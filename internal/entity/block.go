@@ -3,7 +3,17 @@ package entity
 import "time"
 
 type Block struct {
-	Number    int
+	Number    BlockNumber
+	Hash      string
 	Status    string
 	UpdatedAt time.Time
 }
+
+type ChainBlock struct {
+	Number       BlockNumber
+	Hash         string
+	ParentHash   string
+	Timestamp    time.Time
+	Transactions []Transaction
+	Withdrawals  []Withdrawal
+}
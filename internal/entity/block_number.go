@@ -0,0 +1,66 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// BlockNumber is a number of the block. It's encoded to JSON as hex string like the node does,
+// hex and decimal strings as well as JSON numbers are decoded.
+type BlockNumber uint64
+
+// ParseBlockNumber parses 0x prefixed hex or decimal block number.
+func ParseBlockNumber(s string) (BlockNumber, error) {
+	digits, base := trimHexPrefix(s)
+
+	number, err := strconv.ParseUint(digits, base, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid block number (%s): %w", s, err)
+	}
+
+	return BlockNumber(number), nil
+}
+
+func (n BlockNumber) Hex() string {
+	return fmt.Sprintf("0x%x", uint64(n))
+}
+
+func (n BlockNumber) String() string {
+	return strconv.FormatUint(uint64(n), 10)
+}
+
+func (n BlockNumber) MarshalJSON() ([]byte, error) {
+	return json.Marshal(n.Hex())
+}
+
+func (n *BlockNumber) UnmarshalJSON(data []byte) error {
+	value := string(data)
+	if value == "null" {
+		return nil
+	}
+
+	if strings.HasPrefix(value, `"`) {
+		if err := json.Unmarshal(data, &value); err != nil {
+			return err
+		}
+	}
+
+	number, err := ParseBlockNumber(value)
+	if err != nil {
+		return err
+	}
+	*n = number
+
+	return nil
+}
+
+// trimHexPrefix returns digits and the base, hex numbers must be 0x prefixed, others are decimal.
+func trimHexPrefix(s string) (string, int) {
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		return s[2:], 16
+	}
+
+	return s, 10
+}
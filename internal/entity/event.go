@@ -0,0 +1,12 @@
+package entity
+
+import "time"
+
+type Event struct {
+	ID            uint64
+	Type          string
+	Addresses     []Address
+	Transaction   Transaction
+	Confirmations int
+	CreatedAt     time.Time
+}
@@ -0,0 +1,15 @@
+package entity
+
+// HealthCheck is the result of one check, details explain the status, e.g. lag and its threshold
+type HealthCheck struct {
+	Name    string
+	Status  string
+	Message string
+	Details map[string]string
+}
+
+// HealthReport fails if one of the checks fails
+type HealthReport struct {
+	Status string
+	Checks []HealthCheck
+}
@@ -0,0 +1,10 @@
+package entity
+
+// InternalTransfer is a value transfer made by a contract call inside a transaction.
+type InternalTransfer struct {
+	Hash         string
+	TraceAddress string
+	From         Address
+	To           Address
+	Value        Wei
+}
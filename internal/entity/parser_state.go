@@ -0,0 +1,9 @@
+package entity
+
+// ParserState is the runtime state of parser workers. Running is the count of worker runs in progress,
+// after pause it drops to zero when workers finish their claimed blocks.
+type ParserState struct {
+	Workers int
+	Paused  bool
+	Running int
+}
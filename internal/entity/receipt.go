@@ -0,0 +1,8 @@
+package entity
+
+type TransactionReceipt struct {
+	Hash    string
+	Success bool
+	// Fee is gas used multiplied by effective gas price plus blob fee
+	Fee Wei
+}
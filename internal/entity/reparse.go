@@ -0,0 +1,28 @@
+package entity
+
+import "time"
+
+// ReparseTask re-parses the range of blocks From..To inclusive. Addresses limits matching to the given addresses,
+// all subscribed addresses are matched when it's empty. NextBlock is the next block to parse, it's From
+// before the task starts and To+1 when all blocks are parsed.
+type ReparseTask struct {
+	ID                  string
+	From                BlockNumber
+	To                  BlockNumber
+	Addresses           []Address
+	Status              string
+	NextBlock           BlockNumber
+	MatchedTransactions int
+	Error               string
+	CreatedAt           time.Time
+	StartedAt           time.Time
+	FinishedAt          time.Time
+}
+
+func (t ReparseTask) TotalBlocks() uint64 {
+	return uint64(t.To-t.From) + 1
+}
+
+func (t ReparseTask) ParsedBlocks() uint64 {
+	return uint64(t.NextBlock - t.From)
+}
@@ -1,5 +1,5 @@
 package entity
 
 type Subscriber struct {
-	Address string
+	Address Address
 }
@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+// Subscription is an address subscribed by the tenant. The parser tracks the address while
+// at least one tenant is subscribed, see Subscriber.
+// FromBlock is the first block shown to the tenant, blocks of a tracked address parsed before
+// the subscription are indexed for other tenants.
+type Subscription struct {
+	TenantID  string
+	Address   Address
+	FromBlock BlockNumber
+	CreatedAt time.Time
+}
@@ -0,0 +1,26 @@
+package entity
+
+import "time"
+
+// Tenant is an owner of API keys, subscriptions and wallets. Tenants don't see data of each other.
+type Tenant struct {
+	ID   string
+	Name string
+	// MaxSubscriptions caps subscriptions of the tenant, zero means the server default
+	MaxSubscriptions int
+	CreatedAt        time.Time
+}
+
+// APIKey authenticates requests of the tenant. Only SHA-256 hash of the key is stored,
+// the key itself is shown once when it's issued.
+type APIKey struct {
+	ID        string
+	TenantID  string
+	Hash      string
+	CreatedAt time.Time
+	RevokedAt *time.Time
+}
+
+func (k APIKey) Revoked() bool {
+	return k.RevokedAt != nil
+}
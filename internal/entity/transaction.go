@@ -1,9 +1,77 @@
 package entity
 
+import "time"
+
+// Transaction is a transfer of the address. Kind tells a regular transaction from a beacon chain withdrawal,
+// withdrawal has no hash and sender, its TransactionIndex places it after transactions of the block.
+// Pending transaction isn't included in a block yet, its block number and index are meaningless.
 type Transaction struct {
-	From             string
-	To               string
-	Value            string
-	BlockNumber      int
+	Hash             string
+	Kind             string
+	From             Address
+	To               Address
+	Value            Wei
+	Pending          bool
+	BlockNumber      BlockNumber
+	TransactionIndex int
+	Timestamp        time.Time
+	Withdrawal       *Withdrawal
+}
+
+func (t Transaction) Cursor() TransactionCursor {
+	return TransactionCursor{
+		BlockNumber:      t.BlockNumber,
+		TransactionIndex: t.TransactionIndex,
+	}
+}
+
+// TransactionCursor is a position of transaction in (block number, transaction index) order.
+type TransactionCursor struct {
+	BlockNumber      BlockNumber
 	TransactionIndex int
 }
+
+func (c TransactionCursor) Compare(other TransactionCursor) int {
+	switch {
+	case c.BlockNumber < other.BlockNumber:
+		return -1
+	case c.BlockNumber > other.BlockNumber:
+		return 1
+	case c.TransactionIndex < other.TransactionIndex:
+		return -1
+	case c.TransactionIndex > other.TransactionIndex:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// TransactionFilter restricts transactions of an address. Zero values mean no restriction.
+type TransactionFilter struct {
+	FromBlock BlockNumber
+	ToBlock   BlockNumber
+	FromTime  time.Time
+	ToTime    time.Time
+	Direction string
+	MinValue  *Wei
+	Order     string
+	After     *TransactionCursor
+	Limit     int
+}
+
+type TransactionPage struct {
+	Transactions []Transaction
+	Next         *TransactionCursor
+}
+
+// TransactionFeed is a page of deduplicated transactions of several addresses.
+type TransactionFeed struct {
+	Transactions []FeedTransaction
+	Next         *TransactionCursor
+}
+
+// FeedTransaction is marked as internal when both sender and receiver belong to the feed addresses.
+type FeedTransaction struct {
+	Transaction
+	Internal bool
+}
@@ -0,0 +1,7 @@
+package entity
+
+// TransactionLookup is a result of transaction search, Reason explains why the transaction is (not) stored.
+type TransactionLookup struct {
+	Transaction Transaction
+	Reason      string
+}
@@ -0,0 +1,12 @@
+package entity
+
+import "time"
+
+type Wallet struct {
+	ID        string
+	TenantID  string
+	Name      string
+	Addresses []Address
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
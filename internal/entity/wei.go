@@ -0,0 +1,139 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const etherDecimals = 18
+
+var weiPerEther = new(big.Int).Exp(big.NewInt(10), big.NewInt(etherDecimals), nil)
+
+// Wei is an amount in wei backed by big.Int. Zero value is 0. Operations return new values and never
+// modify the operands, so Wei can be copied freely. It's encoded to JSON as hex string like the node does,
+// hex and decimal strings as well as JSON numbers are decoded.
+type Wei struct {
+	value *big.Int
+}
+
+// NewWei copies the value, nil is 0.
+func NewWei(value *big.Int) Wei {
+	if value == nil {
+		return Wei{}
+	}
+
+	return Wei{value: new(big.Int).Set(value)}
+}
+
+func NewWeiFromInt64(value int64) Wei {
+	return Wei{value: big.NewInt(value)}
+}
+
+// ParseWei parses 0x prefixed hex or decimal amount, negative amounts are allowed for balance deltas.
+func ParseWei(s string) (Wei, error) {
+	negative := strings.HasPrefix(s, "-")
+	digits, base := trimHexPrefix(strings.TrimPrefix(s, "-"))
+
+	value, ok := new(big.Int).SetString(digits, base)
+	if !ok || strings.ContainsAny(digits, "+-") {
+		return Wei{}, fmt.Errorf("invalid amount (%s)", s)
+	}
+	if negative {
+		value.Neg(value)
+	}
+
+	return Wei{value: value}, nil
+}
+
+// BigInt returns a copy of the value.
+func (w Wei) BigInt() *big.Int {
+	if w.value == nil {
+		return new(big.Int)
+	}
+
+	return new(big.Int).Set(w.value)
+}
+
+func (w Wei) Add(other Wei) Wei {
+	return Wei{value: new(big.Int).Add(w.BigInt(), other.BigInt())}
+}
+
+func (w Wei) Sub(other Wei) Wei {
+	return Wei{value: new(big.Int).Sub(w.BigInt(), other.BigInt())}
+}
+
+func (w Wei) Neg() Wei {
+	return Wei{value: new(big.Int).Neg(w.BigInt())}
+}
+
+func (w Wei) Cmp(other Wei) int {
+	return w.BigInt().Cmp(other.BigInt())
+}
+
+func (w Wei) Sign() int {
+	if w.value == nil {
+		return 0
+	}
+
+	return w.value.Sign()
+}
+
+// Hex returns 0x prefixed hex, negative amount is prefixed with minus.
+func (w Wei) Hex() string {
+	return fmt.Sprintf("%#x", w.BigInt())
+}
+
+func (w Wei) Decimal() string {
+	return w.BigInt().String()
+}
+
+// Ether returns decimal amount in ether without trailing zeros of the fraction, e.g. 1.5 or 0.000000000000000001.
+func (w Wei) Ether() string {
+	value := w.BigInt()
+
+	sign := ""
+	if value.Sign() < 0 {
+		sign = "-"
+		value.Neg(value)
+	}
+
+	integer, fraction := new(big.Int).QuoRem(value, weiPerEther, new(big.Int))
+	if fraction.Sign() == 0 {
+		return sign + integer.String()
+	}
+
+	fractionDigits := fmt.Sprintf("%0*s", etherDecimals, fraction.String())
+
+	return sign + integer.String() + "." + strings.TrimRight(fractionDigits, "0")
+}
+
+func (w Wei) String() string {
+	return w.Decimal()
+}
+
+func (w Wei) MarshalJSON() ([]byte, error) {
+	return json.Marshal(w.Hex())
+}
+
+func (w *Wei) UnmarshalJSON(data []byte) error {
+	value := string(data)
+	if value == "null" {
+		return nil
+	}
+
+	if strings.HasPrefix(value, `"`) {
+		if err := json.Unmarshal(data, &value); err != nil {
+			return err
+		}
+	}
+
+	wei, err := ParseWei(value)
+	if err != nil {
+		return err
+	}
+	*w = wei
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package entity
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseWei(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{name: "hex", value: "0xde0b6b3a7640000", want: "1000000000000000000"},
+		{name: "decimal", value: "1000000000000000000", want: "1000000000000000000"},
+		{name: "decimal with leading zero", value: "010", want: "10"},
+		{name: "negative hex", value: "-0x10", want: "-16"},
+		{name: "empty", value: "", wantErr: true},
+		{name: "hex without digits", value: "0x", wantErr: true},
+		{name: "not a number", value: "1e18", wantErr: true},
+		{name: "double sign", value: "--1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseWei(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseWei() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.Decimal() != tt.want {
+				t.Errorf("ParseWei() got = %v, want %v", got.Decimal(), tt.want)
+			}
+		})
+	}
+}
+
+func TestWei_Ether(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{name: "zero", value: "0", want: "0"},
+		{name: "whole ether", value: "2000000000000000000", want: "2"},
+		{name: "fraction", value: "1500000000000000000", want: "1.5"},
+		{name: "one wei", value: "1", want: "0.000000000000000001"},
+		{name: "negative", value: "-250000000000000000", want: "-0.25"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wei, err := ParseWei(tt.value)
+			if err != nil {
+				t.Fatalf("ParseWei() error = %v", err)
+			}
+			if got := wei.Ether(); got != tt.want {
+				t.Errorf("Ether() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWei_JSON(t *testing.T) {
+	type payload struct {
+		Value       Wei         `json:"value"`
+		BlockNumber BlockNumber `json:"blockNumber"`
+	}
+
+	for _, data := range []string{
+		`{"value":"0x3e8","blockNumber":"0x10"}`,
+		`{"value":"1000","blockNumber":"16"}`,
+		`{"value":1000,"blockNumber":16}`,
+	} {
+		t.Run(data, func(t *testing.T) {
+			got := payload{}
+			if err := json.Unmarshal([]byte(data), &got); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if got.Value.Decimal() != "1000" || got.BlockNumber != 16 {
+				t.Errorf("Unmarshal() got = %v, %v", got.Value, got.BlockNumber)
+			}
+
+			encoded, err := json.Marshal(got)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			if want := `{"value":"0x3e8","blockNumber":"0x10"}`; string(encoded) != want {
+				t.Errorf("Marshal() got = %s, want %s", encoded, want)
+			}
+		})
+	}
+}
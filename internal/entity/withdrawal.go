@@ -0,0 +1,9 @@
+package entity
+
+// Withdrawal is a beacon chain withdrawal, Amount is converted from Gwei to Wei.
+type Withdrawal struct {
+	Index          int
+	ValidatorIndex int
+	Address        Address
+	Amount         Wei
+}
@@ -3,6 +3,7 @@ package error
 import (
 	"errors"
 	"fmt"
+	"strings"
 )
 
 var (
@@ -10,8 +11,85 @@ var (
 	TimeoutErr = fmt.Errorf("http timeout: %w", DomainErr)
 	HttpErr    = fmt.Errorf("http error: %w", DomainErr)
 
-	SubscriberNotFound = fmt.Errorf("subscriber not found: %w", DomainErr)
-	BlockNotFound      = fmt.Errorf("block not found: %w", DomainErr)
-	NoBlockForParsing  = fmt.Errorf("no block for parsing: %w", DomainErr)
-	UnknownBlockStatus = fmt.Errorf("unknown block status: %w", DomainErr)
+	SubscriberNotFound  = fmt.Errorf("subscriber not found: %w", DomainErr)
+	TransactionNotFound = fmt.Errorf("transaction not found: %w", DomainErr)
+	WalletNotFound      = fmt.Errorf("wallet not found: %w", DomainErr)
+	TenantNotFound      = fmt.Errorf("tenant not found: %w", DomainErr)
+	APIKeyNotFound      = fmt.Errorf("api key not found: %w", DomainErr)
+	BlockNotFound       = fmt.Errorf("block not found: %w", DomainErr)
+	BlockNotParsed      = fmt.Errorf("block is not parsed: %w", DomainErr)
+	BalanceNotTracked   = fmt.Errorf("balance is not tracked: %w", DomainErr)
+	NoBlockForParsing   = fmt.Errorf("no block for parsing: %w", DomainErr)
+	UnknownBlockStatus  = fmt.Errorf("unknown block status: %w", DomainErr)
+	ReorgDetected       = fmt.Errorf("reorg detected: %w", DomainErr)
+	ParentNotParsed     = fmt.Errorf("parent block is not parsed: %w", DomainErr)
+	InvalidArgument     = fmt.Errorf("invalid argument: %w", DomainErr)
+	SubscriptionLimit   = fmt.Errorf("subscription limit is exceeded: %w", DomainErr)
+	InvalidAddress      = fmt.Errorf("invalid address: %w", InvalidArgument)
+	JobNotFound         = fmt.Errorf("job not found: %w", DomainErr)
+	JobRunning          = fmt.Errorf("job is running: %w", DomainErr)
+	JobPaused           = fmt.Errorf("job is paused: %w", DomainErr)
+	ReparseTaskNotFound = fmt.Errorf("reparse task not found: %w", DomainErr)
+	ConfigRejected      = fmt.Errorf("config is rejected: %w", DomainErr)
+
+	NotFound         = errors.New("not found")
+	MethodNotAllowed = errors.New("method not allowed")
+	Unauthorized     = errors.New("unauthorized")
+	Forbidden        = errors.New("forbidden")
+	RateLimited      = errors.New("rate limited")
 )
+
+// InvalidArgumentError describes invalid input, Argument is the name of the parameter or the field.
+// Err is the specific kind of invalid argument, InvalidArgument when it's not set.
+type InvalidArgumentError struct {
+	Argument string
+	Message  string
+	Err      error
+}
+
+func NewInvalidArgument(argument, message string) error {
+	return &InvalidArgumentError{
+		Argument: argument,
+		Message:  message,
+	}
+}
+
+func NewInvalidAddress(argument, message string) error {
+	return &InvalidArgumentError{
+		Argument: argument,
+		Message:  message,
+		Err:      InvalidAddress,
+	}
+}
+
+func (e *InvalidArgumentError) Error() string {
+	return e.Message
+}
+
+func (e *InvalidArgumentError) Unwrap() error {
+	if e.Err != nil {
+		return e.Err
+	}
+
+	return InvalidArgument
+}
+
+// ConfigRejectedError lists problems of the config which isn't applied, e.g. invalid settings or settings
+// which can't be changed without restart
+type ConfigRejectedError struct {
+	Problems []string
+}
+
+func NewConfigRejected(problems []string) error {
+	return &ConfigRejectedError{
+		Problems: problems,
+	}
+}
+
+func (e *ConfigRejectedError) Error() string {
+	return strings.Join(e.Problems, "; ")
+}
+
+func (e *ConfigRejectedError) Unwrap() error {
+	return ConfigRejected
+}
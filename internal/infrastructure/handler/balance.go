@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"blockchain-parser/internal/entity"
+	errorpkg "blockchain-parser/internal/error"
+	"blockchain-parser/tools/router"
+)
+
+const (
+	balanceAddressParam = "address"
+	balanceBlockQuery   = "block"
+)
+
+type Balance struct {
+	balance BalanceGetter
+}
+
+func NewBalance(balance BalanceGetter) *Balance {
+	return &Balance{
+		balance: balance,
+	}
+}
+
+// GetBalance serves GET /v1/addresses/{address}/balance. Without block parameter the balance at the last parsed block is returned.
+func (h *Balance) GetBalance(w http.ResponseWriter, r *http.Request) {
+	address, err := parseAddress("address", router.Param(r, balanceAddressParam))
+	if err != nil {
+		WriteError(w, r, err)
+
+		return
+	}
+
+	var blockNumber *entity.BlockNumber
+	if value := r.URL.Query().Get(balanceBlockQuery); value != "" {
+		number, err := parseBlockNumber(value)
+		if err != nil {
+			WriteError(w, r, errorpkg.NewInvalidArgument(balanceBlockQuery, fmt.Sprintf("fail parse block: %s", err)))
+
+			return
+		}
+		blockNumber = &number
+	}
+
+	unit, err := parseUnit(r.URL.Query().Get("unit"))
+	if err != nil {
+		WriteError(w, r, err)
+
+		return
+	}
+
+	balance, err := h.balance.GetBalance(r.Context(), TenantIDFromContext(r.Context()), address, blockNumber)
+	if err != nil {
+		WriteError(w, r, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(mapBalanceToResponse(balance, unit))
+}
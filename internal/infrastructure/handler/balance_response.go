@@ -0,0 +1,26 @@
+package handler
+
+import "blockchain-parser/internal/entity"
+
+type balanceResponse struct {
+	Address     string `json:"address"`
+	BlockNumber string `json:"blockNumber"`
+	Balance     string `json:"balance"`
+	Source      string `json:"source"`
+	Drift       string `json:"drift,omitempty"`
+}
+
+func mapBalanceToResponse(balance entity.Balance, unit string) balanceResponse {
+	resp := balanceResponse{
+		Address:     balance.Address.Checksum(),
+		BlockNumber: balance.BlockNumber.Hex(),
+		Balance:     formatWei(balance.Value, unit),
+		Source:      balance.Source,
+	}
+
+	if balance.Drift != nil {
+		resp.Drift = formatWei(*balance.Drift, unit)
+	}
+
+	return resp
+}
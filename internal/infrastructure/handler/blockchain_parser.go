@@ -3,8 +3,26 @@ package handler
 import (
 	"encoding/json"
 	"fmt"
-	"log"
 	"net/http"
+	"regexp"
+	"strings"
+
+	"blockchain-parser/internal/entity"
+	errorpkg "blockchain-parser/internal/error"
+	"blockchain-parser/tools/logger"
+	"blockchain-parser/tools/router"
+)
+
+const (
+	addressParam     = "address"
+	transactionParam = "hash"
+	blockNumberParam = "number"
+
+	latestBlock = "latest"
+)
+
+var (
+	transactionHashRegexp = regexp.MustCompile(`^0x[0-9a-f]{64}$`)
 )
 
 type BlockChainParser struct {
@@ -17,72 +35,221 @@ func NewBlockChainParser(parser Parser) *BlockChainParser {
 	}
 }
 
-func (h *BlockChainParser) GetCurrentBlock(w http.ResponseWriter, _ *http.Request) {
-	blockNumber := h.parser.GetCurrentBlock()
-	if blockNumber == 0 {
-		resp := ErrorResponse{
-			Message: "fail get block",
-		}
-
-		w.WriteHeader(http.StatusUnprocessableEntity)
-		_ = json.NewEncoder(w).Encode(resp)
+// GetCurrentBlock serves deprecated GET /block/number, GET /v1/blocks/latest replaces it
+func (h *BlockChainParser) GetCurrentBlock(w http.ResponseWriter, r *http.Request) {
+	blockNumber, err := h.parser.GetCurrentBlock(r.Context())
+	if err != nil {
+		WriteError(w, r, err)
 
 		return
 	}
 
 	resp := blockChainParserGetCurrentBlockResponse{
-		Block: fmt.Sprintf("0x%x", blockNumber),
+		Block: blockNumber.Hex(),
 	}
 
 	w.WriteHeader(http.StatusOK)
 	_ = json.NewEncoder(w).Encode(resp)
 }
 
-func (h *BlockChainParser) Subscribe(w http.ResponseWriter, r *http.Request) {
-	blockChainParserSubscribe := BlockChainParserSubscribe{}
-	if err := json.NewDecoder(r.Body).Decode(&blockChainParserSubscribe); err != nil {
-		resp := ErrorResponse{
-			Message: fmt.Sprintf("fail decode request: %s", err),
+// GetBlock serves GET /v1/blocks/{number}, number is hex, decimal or latest for the last parsed block
+func (h *BlockChainParser) GetBlock(w http.ResponseWriter, r *http.Request) {
+	var (
+		blockNumber entity.BlockNumber
+		err         error
+	)
+	if value := router.Param(r, blockNumberParam); value == latestBlock {
+		blockNumber, err = h.parser.GetCurrentBlock(r.Context())
+		if err != nil {
+			WriteError(w, r, err)
+
+			return
 		}
+	} else {
+		blockNumber, err = parseBlockNumber(value)
+		if err != nil {
+			WriteError(w, r, errorpkg.NewInvalidArgument(blockNumberParam, fmt.Sprintf("fail parse block number: %s", err)))
+
+			return
+		}
+	}
 
-		w.WriteHeader(http.StatusBadRequest)
-		_ = json.NewEncoder(w).Encode(resp)
+	block, err := h.parser.GetBlock(r.Context(), blockNumber)
+	if err != nil {
+		WriteError(w, r, err)
 
 		return
 	}
 
-	if ok := h.parser.Subscribe(blockChainParserSubscribe.Address); !ok {
-		resp := ErrorResponse{
-			Message: "fail subscribe",
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(mapBlockToResponse(block))
+}
+
+// Subscribe serves PUT /v1/addresses/{address}/subscription, deprecated POST /address/subscribe
+// passes the address in the body
+func (h *BlockChainParser) Subscribe(w http.ResponseWriter, r *http.Request) {
+	value := router.Param(r, addressParam)
+	if value == "" {
+		blockChainParserSubscribe := BlockChainParserSubscribe{}
+		if err := json.NewDecoder(r.Body).Decode(&blockChainParserSubscribe); err != nil {
+			WriteError(w, r, errorpkg.NewInvalidArgument("body", fmt.Sprintf("fail decode request: %s", err)))
+
+			return
 		}
+		value = blockChainParserSubscribe.Address
+	}
+
+	address, err := parseAddress("address", value)
+	if err != nil {
+		WriteError(w, r, err)
+
+		return
+	}
+
+	if err := h.parser.Subscribe(r.Context(), TenantIDFromContext(r.Context()), address); err != nil {
+		WriteError(w, r, err)
 
-		w.WriteHeader(http.StatusUnprocessableEntity)
-		_ = json.NewEncoder(w).Encode(resp)
+		return
+	}
+
+	requestLogger(r).Info("address was subscribed", logger.String("address", address.Checksum()))
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Unsubscribe serves DELETE /v1/addresses/{address}/subscription
+func (h *BlockChainParser) Unsubscribe(w http.ResponseWriter, r *http.Request) {
+	address, err := parseAddress("address", router.Param(r, addressParam))
+	if err != nil {
+		WriteError(w, r, err)
+
+		return
+	}
+
+	if err := h.parser.Unsubscribe(r.Context(), TenantIDFromContext(r.Context()), address); err != nil {
+		WriteError(w, r, err)
 
 		return
 	}
 
-	log.Printf("address %s was subscribed", blockChainParserSubscribe.Address)
+	requestLogger(r).Info("address was unsubscribed", logger.String("address", address.Checksum()))
 
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// GetTransactions serves GET /v1/addresses/{address}/transactions, deprecated GET /address/transaction
+// passes the address in the query
 func (h *BlockChainParser) GetTransactions(w http.ResponseWriter, r *http.Request) {
-	address := r.URL.Query().Get("address")
-	if address == "" {
-		resp := ErrorResponse{
-			Message: "address is required",
-		}
+	value := router.Param(r, addressParam)
+	if value == "" {
+		value = r.URL.Query().Get("address")
+	}
+
+	address, err := parseAddress("address", value)
+	if err != nil {
+		WriteError(w, r, err)
+
+		return
+	}
 
-		w.WriteHeader(http.StatusBadRequest)
-		_ = json.NewEncoder(w).Encode(resp)
+	filter, err := parseTransactionFilter(r.URL.Query())
+	if err != nil {
+		WriteError(w, r, err)
 
 		return
 	}
 
-	txns := h.parser.GetTransactions(address)
+	unit, err := parseUnit(r.URL.Query().Get("unit"))
+	if err != nil {
+		WriteError(w, r, err)
+
+		return
+	}
+
+	page, err := h.parser.GetTransactions(r.Context(), TenantIDFromContext(r.Context()), address, filter)
+	if err != nil {
+		WriteError(w, r, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	resp := mapTransactionPageToGetTransactionsResponse(page, unit)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// QueryTransactions serves POST /v1/transactions/query
+func (h *BlockChainParser) QueryTransactions(w http.ResponseWriter, r *http.Request) {
+	query := BlockChainParserQueryTransactions{}
+	if err := json.NewDecoder(r.Body).Decode(&query); err != nil {
+		WriteError(w, r, errorpkg.NewInvalidArgument("body", fmt.Sprintf("fail decode request: %s", err)))
+
+		return
+	}
+
+	if len(query.Addresses) == 0 || len(query.Addresses) > maxQueryAddresses {
+		WriteError(w, r, errorpkg.NewInvalidArgument("addresses", fmt.Sprintf("addresses must contain from 1 to %d addresses", maxQueryAddresses)))
+
+		return
+	}
+
+	addresses, err := parseAddresses("addresses", query.Addresses)
+	if err != nil {
+		WriteError(w, r, err)
+
+		return
+	}
+
+	filter, err := parseTransactionFilter(query.filterValues())
+	if err != nil {
+		WriteError(w, r, err)
+
+		return
+	}
+
+	unit, err := parseUnit(query.Unit)
+	if err != nil {
+		WriteError(w, r, err)
+
+		return
+	}
+
+	feed, err := h.parser.QueryTransactions(r.Context(), TenantIDFromContext(r.Context()), addresses, filter)
+	if err != nil {
+		WriteError(w, r, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	resp := mapTransactionFeedToResponse(feed, unit)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// GetTransaction serves GET /v1/transactions/{hash}
+func (h *BlockChainParser) GetTransaction(w http.ResponseWriter, r *http.Request) {
+	hash := strings.ToLower(router.Param(r, transactionParam))
+	if !transactionHashRegexp.MatchString(hash) {
+		WriteError(w, r, errorpkg.NewInvalidArgument("hash", "hash must be 0x prefixed 32 bytes hex"))
+
+		return
+	}
+
+	unit, err := parseUnit(r.URL.Query().Get("unit"))
+	if err != nil {
+		WriteError(w, r, err)
+
+		return
+	}
+
+	lookup, err := h.parser.GetTransaction(r.Context(), TenantIDFromContext(r.Context()), hash)
+	if err != nil {
+		WriteError(w, r, err)
+
+		return
+	}
 
 	w.WriteHeader(http.StatusOK)
-	resp := mapTransactionsToGetTransactionsResponse(txns)
+	resp := mapTransactionLookupToGetTransactionResponse(lookup, unit)
 	_ = json.NewEncoder(w).Encode(resp)
 }
@@ -1,9 +1,80 @@
 package handler
 
-import "blockchain-parser/internal/entity"
+import (
+	"context"
+
+	"blockchain-parser/internal/entity"
+	"blockchain-parser/tools/job"
+	"blockchain-parser/tools/logger"
+)
 
 type Parser interface {
-	GetCurrentBlock() int
-	Subscribe(address string) bool
-	GetTransactions(address string) []entity.Transaction
+	GetCurrentBlock(ctx context.Context) (entity.BlockNumber, error)
+	GetBlock(ctx context.Context, blockNumber entity.BlockNumber) (entity.Block, error)
+	Subscribe(ctx context.Context, tenantID string, address entity.Address) error
+	Unsubscribe(ctx context.Context, tenantID string, address entity.Address) error
+	GetTransactions(ctx context.Context, tenantID string, address entity.Address, filter entity.TransactionFilter) (entity.TransactionPage, error)
+	GetTransaction(ctx context.Context, tenantID, hash string) (entity.TransactionLookup, error)
+	QueryTransactions(ctx context.Context, tenantID string, addresses []entity.Address, filter entity.TransactionFilter) (entity.TransactionFeed, error)
+}
+
+type WalletManager interface {
+	CreateWallet(ctx context.Context, tenantID, name string, addresses []entity.Address) (entity.Wallet, error)
+	GetWallet(ctx context.Context, tenantID, id string) (entity.Wallet, error)
+	GetWallets(ctx context.Context, tenantID string) ([]entity.Wallet, error)
+	UpdateWallet(ctx context.Context, tenantID, id, name string, addresses []entity.Address) (entity.Wallet, error)
+	DeleteWallet(ctx context.Context, tenantID, id string) error
+	GetWalletTransactions(ctx context.Context, tenantID, id string, filter entity.TransactionFilter) (entity.TransactionFeed, error)
+}
+
+type BalanceGetter interface {
+	GetBalance(ctx context.Context, tenantID string, address entity.Address, blockNumber *entity.BlockNumber) (entity.Balance, error)
+}
+
+type TenantManager interface {
+	CreateTenant(ctx context.Context, name string, maxSubscriptions int) (entity.Tenant, error)
+	GetTenants(ctx context.Context) ([]entity.Tenant, error)
+	IssueAPIKey(ctx context.Context, tenantID string) (entity.APIKey, string, error)
+	GetAPIKeys(ctx context.Context, tenantID string) ([]entity.APIKey, error)
+	RevokeAPIKey(ctx context.Context, tenantID, keyID string) error
+}
+
+type EventStreamer interface {
+	CheckSubscriptions(ctx context.Context, tenantID string, addresses []entity.Address) error
+	GetEvents(ctx context.Context, cursor uint64, addresses []entity.Address) ([]entity.Event, uint64, error)
+	WaitEvents(ctx context.Context, cursor uint64) error
+}
+
+type HealthChecker interface {
+	Liveness() entity.HealthReport
+	Readiness(ctx context.Context) entity.HealthReport
+}
+
+type LogLevelManager interface {
+	Level() logger.Level
+	Set(level logger.Level)
+}
+
+type JobManager interface {
+	Statuses() []job.Status
+	Status(id string) (job.Status, error)
+	Trigger(id string) (job.Run, error)
+}
+
+type ParserController interface {
+	State(ctx context.Context) (entity.ParserState, error)
+	Scale(ctx context.Context, count int) (entity.ParserState, error)
+	Pause(ctx context.Context) (entity.ParserState, error)
+	Resume(ctx context.Context) (entity.ParserState, error)
+}
+
+type ReparseManager interface {
+	CreateTask(ctx context.Context, from, to entity.BlockNumber, addresses []entity.Address) (entity.ReparseTask, error)
+	GetTask(ctx context.Context, id string) (entity.ReparseTask, error)
+	GetTasks(ctx context.Context) ([]entity.ReparseTask, error)
+	CancelTask(ctx context.Context, id string) (entity.ReparseTask, error)
+}
+
+type ConfigReloader interface {
+	Reload(ctx context.Context) ([]string, error)
 }
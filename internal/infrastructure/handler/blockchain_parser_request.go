@@ -1,5 +1,230 @@
 package handler
 
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"blockchain-parser/internal/constant"
+	"blockchain-parser/internal/entity"
+	errorpkg "blockchain-parser/internal/error"
+)
+
+const (
+	defaultTransactionsLimit = 100
+	maxTransactionsLimit     = 1000
+	maxQueryAddresses        = 100
+)
+
 type BlockChainParserSubscribe struct {
 	Address string `json:"address"`
 }
+
+type BlockChainParserQueryTransactions struct {
+	Addresses []string `json:"addresses"`
+	Limit     int      `json:"limit"`
+	Cursor    string   `json:"cursor"`
+	Order     string   `json:"order"`
+	FromBlock string   `json:"fromBlock"`
+	ToBlock   string   `json:"toBlock"`
+	FromTime  string   `json:"fromTime"`
+	ToTime    string   `json:"toTime"`
+	Direction string   `json:"direction"`
+	MinValue  string   `json:"minValue"`
+	Unit      string   `json:"unit"`
+}
+
+// filterValues converts body to query parameters, so GET and POST share the filter parsing
+func (q BlockChainParserQueryTransactions) filterValues() url.Values {
+	values := url.Values{}
+	if q.Limit != 0 {
+		values.Set("limit", strconv.Itoa(q.Limit))
+	}
+
+	for name, value := range map[string]string{
+		"cursor":    q.Cursor,
+		"order":     q.Order,
+		"fromBlock": q.FromBlock,
+		"toBlock":   q.ToBlock,
+		"fromTime":  q.FromTime,
+		"toTime":    q.ToTime,
+		"direction": q.Direction,
+		"minValue":  q.MinValue,
+	} {
+		if value != "" {
+			values.Set(name, value)
+		}
+	}
+
+	return values
+}
+
+func parseTransactionFilter(query url.Values) (entity.TransactionFilter, error) {
+	var err error
+
+	filter := entity.TransactionFilter{
+		Order: constant.OrderAsc,
+		Limit: defaultTransactionsLimit,
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		filter.Limit, err = strconv.Atoi(limit)
+		if err != nil || filter.Limit < 1 || filter.Limit > maxTransactionsLimit {
+			return entity.TransactionFilter{}, errorpkg.NewInvalidArgument("limit", fmt.Sprintf("limit must be integer from 1 to %d", maxTransactionsLimit))
+		}
+	}
+
+	if cursor := query.Get("cursor"); cursor != "" {
+		after, err := decodeTransactionCursor(cursor)
+		if err != nil {
+			return entity.TransactionFilter{}, errorpkg.NewInvalidArgument("cursor", fmt.Sprintf("invalid cursor: %s", err))
+		}
+		filter.After = &after
+	}
+
+	if fromBlock := query.Get("fromBlock"); fromBlock != "" {
+		filter.FromBlock, err = parseBlockNumber(fromBlock)
+		if err != nil {
+			return entity.TransactionFilter{}, errorpkg.NewInvalidArgument("fromBlock", fmt.Sprintf("invalid fromBlock: %s", err))
+		}
+	}
+
+	if toBlock := query.Get("toBlock"); toBlock != "" {
+		filter.ToBlock, err = parseBlockNumber(toBlock)
+		if err != nil {
+			return entity.TransactionFilter{}, errorpkg.NewInvalidArgument("toBlock", fmt.Sprintf("invalid toBlock: %s", err))
+		}
+	}
+
+	if fromTime := query.Get("fromTime"); fromTime != "" {
+		filter.FromTime, err = parseTime(fromTime)
+		if err != nil {
+			return entity.TransactionFilter{}, errorpkg.NewInvalidArgument("fromTime", fmt.Sprintf("invalid fromTime: %s", err))
+		}
+	}
+
+	if toTime := query.Get("toTime"); toTime != "" {
+		filter.ToTime, err = parseTime(toTime)
+		if err != nil {
+			return entity.TransactionFilter{}, errorpkg.NewInvalidArgument("toTime", fmt.Sprintf("invalid toTime: %s", err))
+		}
+	}
+
+	switch direction := query.Get("direction"); direction {
+	case "", constant.TransactionDirectionIn, constant.TransactionDirectionOut:
+		filter.Direction = direction
+	default:
+		return entity.TransactionFilter{}, errorpkg.NewInvalidArgument("direction", fmt.Sprintf("direction must be %s or %s", constant.TransactionDirectionIn, constant.TransactionDirectionOut))
+	}
+
+	if minValue := query.Get("minValue"); minValue != "" {
+		value, err := entity.ParseWei(minValue)
+		if err != nil || value.Sign() < 0 {
+			return entity.TransactionFilter{}, errorpkg.NewInvalidArgument("minValue", "minValue must be non negative integer in wei")
+		}
+		filter.MinValue = &value
+	}
+
+	switch order := query.Get("order"); order {
+	case "":
+	case constant.OrderAsc, constant.OrderDesc:
+		filter.Order = order
+	default:
+		return entity.TransactionFilter{}, errorpkg.NewInvalidArgument("order", fmt.Sprintf("order must be %s or %s", constant.OrderAsc, constant.OrderDesc))
+	}
+
+	return filter, nil
+}
+
+// parseBlockNumber accepts hex (0x prefixed) and decimal numbers.
+func parseBlockNumber(value string) (entity.BlockNumber, error) {
+	return entity.ParseBlockNumber(value)
+}
+
+// parseUnit validates unit of amounts in the response, hex is the default.
+func parseUnit(value string) (string, error) {
+	switch value {
+	case "":
+		return constant.UnitHex, nil
+	case constant.UnitHex, constant.UnitWei, constant.UnitEther:
+		return value, nil
+	default:
+		return "", errorpkg.NewInvalidArgument("unit", fmt.Sprintf("unit must be %s, %s or %s", constant.UnitHex, constant.UnitWei, constant.UnitEther))
+	}
+}
+
+// parseTime accepts RFC 3339 and unix seconds.
+func parseTime(value string) (time.Time, error) {
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(seconds, 0).UTC(), nil
+	}
+
+	return time.Parse(time.RFC3339, value)
+}
+
+// cursors are opaque for clients, the format can be changed without breaking them
+func encodeTransactionCursor(cursor entity.TransactionCursor) string {
+	raw := fmt.Sprintf("%d_%d", cursor.BlockNumber, cursor.TransactionIndex)
+
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeTransactionCursor(value string) (entity.TransactionCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return entity.TransactionCursor{}, err
+	}
+
+	parts := strings.Split(string(raw), "_")
+	if len(parts) != 2 {
+		return entity.TransactionCursor{}, errors.New("malformed cursor")
+	}
+
+	blockNumber, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return entity.TransactionCursor{}, err
+	}
+
+	txnIndex, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return entity.TransactionCursor{}, err
+	}
+
+	return entity.TransactionCursor{
+		BlockNumber:      entity.BlockNumber(blockNumber),
+		TransactionIndex: txnIndex,
+	}, nil
+}
+
+// parseAddress validates the address, argument names the parameter in the error response.
+func parseAddress(argument, value string) (entity.Address, error) {
+	if value == "" {
+		return "", errorpkg.NewInvalidArgument(argument, "address is required")
+	}
+
+	address, err := entity.ParseAddress(value)
+	invalidArgumentErr := &errorpkg.InvalidArgumentError{}
+	if errors.As(err, &invalidArgumentErr) {
+		invalidArgumentErr.Argument = argument
+	}
+
+	return address, err
+}
+
+func parseAddresses(argument string, values []string) ([]entity.Address, error) {
+	addresses := make([]entity.Address, 0, len(values))
+	for i, value := range values {
+		address, err := parseAddress(fmt.Sprintf("%s[%d]", argument, i), value)
+		if err != nil {
+			return nil, err
+		}
+
+		addresses = append(addresses, address)
+	}
+
+	return addresses, nil
+}
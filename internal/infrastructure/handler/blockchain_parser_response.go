@@ -1,33 +1,168 @@
 package handler
 
-import "blockchain-parser/internal/entity"
+import (
+	"time"
+
+	"blockchain-parser/internal/constant"
+	"blockchain-parser/internal/entity"
+)
 
 type blockChainParserGetCurrentBlockResponse struct {
 	Block string `json:"block"`
 }
 
+type blockChainParserBlockResponse struct {
+	Number    string `json:"number"`
+	Hash      string `json:"hash,omitempty"`
+	Status    string `json:"status"`
+	UpdatedAt string `json:"updatedAt"`
+}
+
+func mapBlockToResponse(block entity.Block) blockChainParserBlockResponse {
+	return blockChainParserBlockResponse{
+		Number:    block.Number.Hex(),
+		Hash:      block.Hash,
+		Status:    block.Status,
+		UpdatedAt: formatTimestamp(block.UpdatedAt),
+	}
+}
+
 type blockChainParserGetTransactionsTransactions struct {
-	From  string `json:"from"`
-	To    string `json:"to"`
-	Value string `json:"value"`
+	Hash             string `json:"hash,omitempty"`
+	Kind             string `json:"kind"`
+	From             string `json:"from"`
+	To               string `json:"to"`
+	Value            string `json:"value"`
+	BlockNumber      string `json:"blockNumber,omitempty"`
+	TransactionIndex *int   `json:"transactionIndex,omitempty"`
+	Timestamp        string `json:"timestamp,omitempty"`
+	WithdrawalIndex  *int   `json:"withdrawalIndex,omitempty"`
+	ValidatorIndex   *int   `json:"validatorIndex,omitempty"`
 }
 
 type blockChainParserGetTransactionsResponse struct {
 	Transactions []blockChainParserGetTransactionsTransactions `json:"transactions"`
+	NextCursor   string                                        `json:"nextCursor,omitempty"`
 }
 
-func mapTransactionsToGetTransactionsResponse(txns []entity.Transaction) blockChainParserGetTransactionsResponse {
+func mapTransactionPageToGetTransactionsResponse(page entity.TransactionPage, unit string) blockChainParserGetTransactionsResponse {
 	resp := blockChainParserGetTransactionsResponse{
-		Transactions: make([]blockChainParserGetTransactionsTransactions, 0, len(txns)),
+		Transactions: make([]blockChainParserGetTransactionsTransactions, 0, len(page.Transactions)),
+	}
+
+	for _, txn := range page.Transactions {
+		resp.Transactions = append(resp.Transactions, mapTransactionToResponse(txn, unit))
+	}
+
+	if page.Next != nil {
+		resp.NextCursor = encodeTransactionCursor(*page.Next)
+	}
+
+	return resp
+}
+
+type blockChainParserFeedTransaction struct {
+	blockChainParserGetTransactionsTransactions
+	Internal bool `json:"internal"`
+}
+
+type blockChainParserTransactionFeedResponse struct {
+	Transactions []blockChainParserFeedTransaction `json:"transactions"`
+	NextCursor   string                            `json:"nextCursor,omitempty"`
+}
+
+func mapTransactionFeedToResponse(feed entity.TransactionFeed, unit string) blockChainParserTransactionFeedResponse {
+	resp := blockChainParserTransactionFeedResponse{
+		Transactions: make([]blockChainParserFeedTransaction, 0, len(feed.Transactions)),
 	}
 
-	for _, txn := range txns {
-		resp.Transactions = append(resp.Transactions, blockChainParserGetTransactionsTransactions{
-			From:  txn.From,
-			To:    txn.To,
-			Value: txn.Value,
+	for _, txn := range feed.Transactions {
+		resp.Transactions = append(resp.Transactions, blockChainParserFeedTransaction{
+			blockChainParserGetTransactionsTransactions: mapTransactionToResponse(txn.Transaction, unit),
+			Internal: txn.Internal,
 		})
 	}
 
+	if feed.Next != nil {
+		resp.NextCursor = encodeTransactionCursor(*feed.Next)
+	}
+
 	return resp
 }
+
+type blockChainParserGetTransactionResponse struct {
+	Indexed     bool                                        `json:"indexed"`
+	Reason      string                                      `json:"reason"`
+	Explanation string                                      `json:"explanation"`
+	Transaction blockChainParserGetTransactionsTransactions `json:"transaction"`
+}
+
+var transactionLookupExplanations = map[string]string{
+	constant.TransactionLookupReasonIndexed:                "transaction is stored",
+	constant.TransactionLookupReasonPending:                "transaction is pending, it isn't included in a block yet",
+	constant.TransactionLookupReasonBlockNotParsed:         "block of the transaction hasn't been parsed: it's newer than the last parsed block, older than the start block or is being processed",
+	constant.TransactionLookupReasonNotSubscribed:          "neither sender nor receiver is subscribed",
+	constant.TransactionLookupReasonSubscribedAfterParsing: "address was subscribed after the block of the transaction had been parsed",
+}
+
+func mapTransactionLookupToGetTransactionResponse(lookup entity.TransactionLookup, unit string) blockChainParserGetTransactionResponse {
+	return blockChainParserGetTransactionResponse{
+		Indexed:     lookup.Reason == constant.TransactionLookupReasonIndexed,
+		Reason:      lookup.Reason,
+		Explanation: transactionLookupExplanations[lookup.Reason],
+		Transaction: mapTransactionToResponse(lookup.Transaction, unit),
+	}
+}
+
+// mapTransactionToResponse omits block fields of pending transaction. Withdrawal has withdrawal and validator
+// indexes instead of transaction index. Value is formatted in the unit.
+func mapTransactionToResponse(txn entity.Transaction, unit string) blockChainParserGetTransactionsTransactions {
+	resp := blockChainParserGetTransactionsTransactions{
+		Hash:      txn.Hash,
+		Kind:      txn.Kind,
+		From:      txn.From.Checksum(),
+		To:        txn.To.Checksum(),
+		Value:     formatWei(txn.Value, unit),
+		Timestamp: formatTimestamp(txn.Timestamp),
+	}
+
+	if txn.Withdrawal != nil {
+		withdrawalIndex := txn.Withdrawal.Index
+		validatorIndex := txn.Withdrawal.ValidatorIndex
+
+		resp.BlockNumber = txn.BlockNumber.Hex()
+		resp.WithdrawalIndex = &withdrawalIndex
+		resp.ValidatorIndex = &validatorIndex
+
+		return resp
+	}
+
+	if !txn.Pending {
+		txnIndex := txn.TransactionIndex
+
+		resp.BlockNumber = txn.BlockNumber.Hex()
+		resp.TransactionIndex = &txnIndex
+	}
+
+	return resp
+}
+
+// formatWei formats the amount as hex wei, decimal wei or decimal ether.
+func formatWei(value entity.Wei, unit string) string {
+	switch unit {
+	case constant.UnitWei:
+		return value.Decimal()
+	case constant.UnitEther:
+		return value.Ether()
+	default:
+		return value.Hex()
+	}
+}
+
+func formatTimestamp(timestamp time.Time) string {
+	if timestamp.IsZero() {
+		return ""
+	}
+
+	return timestamp.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Config serves admin endpoints of the service config, access is checked by the auth middleware
+type Config struct {
+	reloader ConfigReloader
+}
+
+func NewConfig(reloader ConfigReloader) *Config {
+	return &Config{
+		reloader: reloader,
+	}
+}
+
+// ReloadConfig serves POST /v1/admin/config/reload, the reload is logged by the reloader
+func (h *Config) ReloadConfig(w http.ResponseWriter, r *http.Request) {
+	changed, err := h.reloader.Reload(r.Context())
+	if err != nil {
+		WriteError(w, r, err)
+
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(configReloadResponse{Changed: changed})
+}
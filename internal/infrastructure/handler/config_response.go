@@ -0,0 +1,5 @@
+package handler
+
+type configReloadResponse struct {
+	Changed []string `json:"changed"`
+}
@@ -1,5 +1,131 @@
 package handler
 
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	errorpkg "blockchain-parser/internal/error"
+	"blockchain-parser/tools/logger"
+)
+
+const (
+	ErrorCodeInvalidArgument     = "invalid_argument"
+	ErrorCodeInvalidAddress      = "invalid_address"
+	ErrorCodeNotFound            = "not_found"
+	ErrorCodeMethodNotAllowed    = "method_not_allowed"
+	ErrorCodeUnauthorized        = "unauthorized"
+	ErrorCodeForbidden           = "forbidden"
+	ErrorCodeRateLimited         = "rate_limited"
+	ErrorCodeSubscriptionLimit   = "subscription_limit_exceeded"
+	ErrorCodeSubscriberNotFound  = "subscriber_not_found"
+	ErrorCodeTransactionNotFound = "transaction_not_found"
+	ErrorCodeWalletNotFound      = "wallet_not_found"
+	ErrorCodeTenantNotFound      = "tenant_not_found"
+	ErrorCodeAPIKeyNotFound      = "api_key_not_found"
+	ErrorCodeJobNotFound         = "job_not_found"
+	ErrorCodeJobRunning          = "job_running"
+	ErrorCodeJobPaused           = "job_paused"
+	ErrorCodeReparseTaskNotFound = "reparse_task_not_found"
+	ErrorCodeConfigRejected      = "config_rejected"
+	ErrorCodeBlockNotFound       = "block_not_found"
+	ErrorCodeBlockNotParsed      = "block_not_parsed"
+	ErrorCodeBalanceNotTracked   = "balance_not_tracked"
+	ErrorCodeNodeTimeout         = "node_timeout"
+	ErrorCodeNodeError           = "node_error"
+	ErrorCodeRequestTimeout      = "request_timeout"
+	ErrorCodeRequestCanceled     = "request_canceled"
+	ErrorCodeInternal            = "internal_error"
+
+	// StatusClientClosedRequest is written when the client has gone, it's seen only in logs and metrics
+	StatusClientClosedRequest = 499
+)
+
 type ErrorResponse struct {
-	Message string `json:"message"`
+	Code      string            `json:"code"`
+	Message   string            `json:"message"`
+	Details   map[string]string `json:"details,omitempty"`
+	RequestID string            `json:"requestId,omitempty"`
+}
+
+type errorMapping struct {
+	err     error
+	status  int
+	code    string
+	message string
+}
+
+// errorMappings binds errors of internal/error and context errors of the request to HTTP statuses and stable codes.
+// Unknown errors are internal errors.
+var errorMappings = []errorMapping{
+	{errorpkg.InvalidAddress, http.StatusBadRequest, ErrorCodeInvalidAddress, "invalid address"},
+	{errorpkg.InvalidArgument, http.StatusBadRequest, ErrorCodeInvalidArgument, "invalid argument"},
+	{errorpkg.NotFound, http.StatusNotFound, ErrorCodeNotFound, "not found"},
+	{errorpkg.MethodNotAllowed, http.StatusMethodNotAllowed, ErrorCodeMethodNotAllowed, "method not allowed"},
+	{errorpkg.Unauthorized, http.StatusUnauthorized, ErrorCodeUnauthorized, "api key is missing, unknown or revoked"},
+	{errorpkg.Forbidden, http.StatusForbidden, ErrorCodeForbidden, "admin key is required"},
+	{errorpkg.RateLimited, http.StatusTooManyRequests, ErrorCodeRateLimited, "too many requests, retry after Retry-After seconds"},
+	{errorpkg.SubscriptionLimit, http.StatusForbidden, ErrorCodeSubscriptionLimit, "subscription limit of the tenant is exceeded"},
+	{errorpkg.SubscriberNotFound, http.StatusNotFound, ErrorCodeSubscriberNotFound, "subscriber not found"},
+	{errorpkg.TransactionNotFound, http.StatusNotFound, ErrorCodeTransactionNotFound, "transaction not found"},
+	{errorpkg.WalletNotFound, http.StatusNotFound, ErrorCodeWalletNotFound, "wallet not found"},
+	{errorpkg.TenantNotFound, http.StatusNotFound, ErrorCodeTenantNotFound, "tenant not found"},
+	{errorpkg.APIKeyNotFound, http.StatusNotFound, ErrorCodeAPIKeyNotFound, "api key not found"},
+	{errorpkg.JobNotFound, http.StatusNotFound, ErrorCodeJobNotFound, "job not found"},
+	{errorpkg.JobRunning, http.StatusConflict, ErrorCodeJobRunning, "job is running and skips overlapping runs"},
+	{errorpkg.JobPaused, http.StatusConflict, ErrorCodeJobPaused, "job is paused"},
+	{errorpkg.ReparseTaskNotFound, http.StatusNotFound, ErrorCodeReparseTaskNotFound, "reparse task not found"},
+	{errorpkg.ConfigRejected, http.StatusUnprocessableEntity, ErrorCodeConfigRejected, "config is rejected"},
+	{errorpkg.BlockNotFound, http.StatusNotFound, ErrorCodeBlockNotFound, "block not found"},
+	{errorpkg.BlockNotParsed, http.StatusNotFound, ErrorCodeBlockNotParsed, "block is not parsed yet"},
+	{errorpkg.BalanceNotTracked, http.StatusNotFound, ErrorCodeBalanceNotTracked, "balance of the address is not tracked, subscribe the address first"},
+	{errorpkg.TimeoutErr, http.StatusGatewayTimeout, ErrorCodeNodeTimeout, "node doesn't respond"},
+	{errorpkg.HttpErr, http.StatusBadGateway, ErrorCodeNodeError, "node responded with error"},
+	{context.DeadlineExceeded, http.StatusGatewayTimeout, ErrorCodeRequestTimeout, "request isn't processed in time"},
+	{context.Canceled, StatusClientClosedRequest, ErrorCodeRequestCanceled, "request is canceled"},
+}
+
+// WriteError writes the error response, invalid argument error puts its message and argument to the response,
+// rejected config error puts its problems to the message.
+func WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	mapping := errorMapping{
+		status:  http.StatusInternalServerError,
+		code:    ErrorCodeInternal,
+		message: "internal server error",
+	}
+	for _, errMapping := range errorMappings {
+		if errors.Is(err, errMapping.err) {
+			mapping = errMapping
+
+			break
+		}
+	}
+
+	resp := ErrorResponse{
+		Code:      mapping.code,
+		Message:   mapping.message,
+		RequestID: RequestIDFromContext(r.Context()),
+	}
+
+	invalidArgumentErr := &errorpkg.InvalidArgumentError{}
+	if errors.As(err, &invalidArgumentErr) {
+		resp.Message = invalidArgumentErr.Message
+		resp.Details = map[string]string{
+			"argument": invalidArgumentErr.Argument,
+		}
+	}
+
+	configRejectedErr := &errorpkg.ConfigRejectedError{}
+	if errors.As(err, &configRejectedErr) {
+		resp.Message = configRejectedErr.Error()
+	}
+
+	if mapping.status >= http.StatusInternalServerError {
+		requestLogger(r).Error("request failed",
+			logger.String("method", r.Method), logger.String("path", r.URL.Path), logger.Err(err))
+	}
+
+	w.WriteHeader(mapping.status)
+	_ = json.NewEncoder(w).Encode(resp)
 }
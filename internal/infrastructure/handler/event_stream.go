@@ -0,0 +1,254 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"blockchain-parser/internal/entity"
+	errorpkg "blockchain-parser/internal/error"
+	"blockchain-parser/tools/logger"
+	"blockchain-parser/tools/router"
+	"blockchain-parser/tools/websocket"
+)
+
+const (
+	lastEventIDHeader = "Last-Event-ID"
+	lastEventIDQuery  = "lastEventId"
+
+	streamAddressParam = "address"
+)
+
+type EventStream struct {
+	stream            EventStreamer
+	heartbeatInterval time.Duration
+
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func NewEventStream(stream EventStreamer, heartbeatInterval time.Duration) *EventStream {
+	return &EventStream{
+		stream:            stream,
+		heartbeatInterval: heartbeatInterval,
+		done:              make(chan struct{}),
+	}
+}
+
+// Close ends all opened streams, it's used on server shutdown because streams never finish by themselves.
+func (h *EventStream) Close() {
+	h.closeOnce.Do(func() {
+		close(h.done)
+	})
+}
+
+// Stream serves GET /v1/addresses/{address}/stream. Several addresses can be passed split with ','
+// or as address query parameters. WebSocket is used when the request asks for upgrade, otherwise SSE.
+func (h *EventStream) Stream(w http.ResponseWriter, r *http.Request) {
+	addresses, err := parseStreamAddresses(r)
+	if err != nil {
+		WriteError(w, r, err)
+
+		return
+	}
+
+	cursor, err := parseLastEventID(r)
+	if err != nil {
+		WriteError(w, r, errorpkg.NewInvalidArgument(lastEventIDQuery, fmt.Sprintf("fail parse last event id: %s", err)))
+
+		return
+	}
+
+	unit, err := parseUnit(r.URL.Query().Get("unit"))
+	if err != nil {
+		WriteError(w, r, err)
+
+		return
+	}
+
+	if err := h.stream.CheckSubscriptions(r.Context(), TenantIDFromContext(r.Context()), addresses); err != nil {
+		WriteError(w, r, err)
+
+		return
+	}
+
+	if websocket.IsUpgrade(r) {
+		h.serveWebSocket(w, r, addresses, cursor, unit)
+
+		return
+	}
+
+	h.serveSSE(w, r, addresses, cursor, unit)
+}
+
+func (h *EventStream) serveSSE(w http.ResponseWriter, r *http.Request, addresses []entity.Address, cursor uint64, unit string) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		WriteError(w, r, errors.New("streaming is not supported"))
+
+		return
+	}
+
+	w.Header().Set("content-type", "text/event-stream")
+	w.Header().Set("cache-control", "no-cache")
+	w.Header().Set("connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	send := func(event entity.Event) error {
+		data, err := json.Marshal(mapEventToStreamEvent(event, unit))
+		if err != nil {
+			return err
+		}
+
+		if _, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data); err != nil {
+			return err
+		}
+		flusher.Flush()
+
+		return nil
+	}
+	heartbeat := func() error {
+		if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+			return err
+		}
+		flusher.Flush()
+
+		return nil
+	}
+
+	if err := h.serve(r.Context(), addresses, cursor, send, heartbeat); err != nil {
+		requestLogger(r).Warn("fail serve sse stream", logger.Err(err))
+	}
+}
+
+func (h *EventStream) serveWebSocket(w http.ResponseWriter, r *http.Request, addresses []entity.Address, cursor uint64, unit string) {
+	conn, err := websocket.Upgrade(w, r)
+	if err != nil {
+		WriteError(w, r, errorpkg.NewInvalidArgument("connection", fmt.Sprintf("fail upgrade connection: %s", err)))
+
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	// reading is required to answer pings and to notice closed connection
+	go func() {
+		defer cancel()
+
+		for {
+			if _, _, err := conn.Read(); err != nil {
+				return
+			}
+		}
+	}()
+
+	send := func(event entity.Event) error {
+		data, err := json.Marshal(mapEventToStreamEvent(event, unit))
+		if err != nil {
+			return err
+		}
+
+		return conn.WriteText(data)
+	}
+
+	if err := h.serve(ctx, addresses, cursor, send, conn.WritePing); err != nil && ctx.Err() == nil {
+		requestLogger(r).Warn("fail serve websocket stream", logger.Err(err))
+	}
+}
+
+func (h *EventStream) serve(
+	ctx context.Context,
+	addresses []entity.Address,
+	cursor uint64,
+	send func(event entity.Event) error,
+	heartbeat func() error,
+) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	go func() {
+		select {
+		case <-h.done:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	for {
+		events, nextCursor, err := h.stream.GetEvents(ctx, cursor, addresses)
+		if err != nil {
+			return err
+		}
+
+		for _, event := range events {
+			if err := send(event); err != nil {
+				return err
+			}
+		}
+
+		if nextCursor > cursor {
+			cursor = nextCursor
+
+			continue
+		}
+
+		waitCtx, waitCancel := context.WithTimeout(ctx, h.heartbeatInterval)
+		err = h.stream.WaitEvents(waitCtx, cursor)
+		waitCancel()
+
+		if ctx.Err() != nil {
+			return nil
+		}
+		if errors.Is(err, context.DeadlineExceeded) {
+			if err := heartbeat(); err != nil {
+				return err
+			}
+
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func parseStreamAddresses(r *http.Request) ([]entity.Address, error) {
+	addresses := make([]string, 0)
+
+	pathAddresses := router.Param(r, streamAddressParam)
+	for _, address := range strings.Split(pathAddresses, ",") {
+		if address != "" {
+			addresses = append(addresses, address)
+		}
+	}
+
+	addresses = append(addresses, r.URL.Query()["address"]...)
+	if len(addresses) == 0 {
+		return nil, errorpkg.NewInvalidArgument("address", "address is required")
+	}
+
+	return parseAddresses("address", addresses)
+}
+
+// parseLastEventID reads the cursor from the header, browsers can't set headers for WebSocket
+// so query parameter is supported as well.
+func parseLastEventID(r *http.Request) (uint64, error) {
+	lastEventID := r.Header.Get(lastEventIDHeader)
+	if lastEventID == "" {
+		lastEventID = r.URL.Query().Get(lastEventIDQuery)
+	}
+	if lastEventID == "" {
+		return 0, nil
+	}
+
+	return strconv.ParseUint(lastEventID, 10, 64)
+}
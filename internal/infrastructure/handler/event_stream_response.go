@@ -0,0 +1,19 @@
+package handler
+
+import "blockchain-parser/internal/entity"
+
+type eventStreamEvent struct {
+	ID            uint64                                      `json:"id"`
+	Type          string                                      `json:"type"`
+	Confirmations int                                         `json:"confirmations"`
+	Transaction   blockChainParserGetTransactionsTransactions `json:"transaction"`
+}
+
+func mapEventToStreamEvent(event entity.Event, unit string) eventStreamEvent {
+	return eventStreamEvent{
+		ID:            event.ID,
+		Type:          event.Type,
+		Confirmations: event.Confirmations,
+		Transaction:   mapTransactionToResponse(event.Transaction, unit),
+	}
+}
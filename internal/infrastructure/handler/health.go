@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"blockchain-parser/internal/constant"
+	"blockchain-parser/internal/entity"
+)
+
+// Health serves probes of the orchestrator, probes don't require API key
+type Health struct {
+	health HealthChecker
+}
+
+func NewHealth(health HealthChecker) *Health {
+	return &Health{
+		health: health,
+	}
+}
+
+// Liveness serves GET /healthz
+func (h *Health) Liveness(w http.ResponseWriter, _ *http.Request) {
+	writeHealthReport(w, h.health.Liveness())
+}
+
+// Readiness serves GET /readyz, failed report is answered with 503
+func (h *Health) Readiness(w http.ResponseWriter, r *http.Request) {
+	writeHealthReport(w, h.health.Readiness(r.Context()))
+}
+
+func writeHealthReport(w http.ResponseWriter, report entity.HealthReport) {
+	status := http.StatusOK
+	if report.Status != constant.HealthStatusOK {
+		status = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(mapHealthReportToResponse(report))
+}
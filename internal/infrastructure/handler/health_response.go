@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"blockchain-parser/internal/entity"
+)
+
+type healthCheckResponse struct {
+	Name    string            `json:"name"`
+	Status  string            `json:"status"`
+	Message string            `json:"message,omitempty"`
+	Details map[string]string `json:"details,omitempty"`
+}
+
+type healthReportResponse struct {
+	Status string                `json:"status"`
+	Checks []healthCheckResponse `json:"checks"`
+}
+
+func mapHealthReportToResponse(report entity.HealthReport) healthReportResponse {
+	resp := healthReportResponse{
+		Status: report.Status,
+		Checks: make([]healthCheckResponse, 0, len(report.Checks)),
+	}
+
+	for _, check := range report.Checks {
+		resp.Checks = append(resp.Checks, healthCheckResponse{
+			Name:    check.Name,
+			Status:  check.Status,
+			Message: check.Message,
+			Details: check.Details,
+		})
+	}
+
+	return resp
+}
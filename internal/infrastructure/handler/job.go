@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	errorpkg "blockchain-parser/internal/error"
+	"blockchain-parser/tools/job"
+	"blockchain-parser/tools/logger"
+	"blockchain-parser/tools/router"
+)
+
+const (
+	jobIDParam = "jobId"
+)
+
+// Job serves admin endpoints of background jobs, access is checked by the auth middleware
+type Job struct {
+	jobs JobManager
+}
+
+func NewJob(jobs JobManager) *Job {
+	return &Job{
+		jobs: jobs,
+	}
+}
+
+// GetJobs serves GET /v1/admin/jobs
+func (h *Job) GetJobs(w http.ResponseWriter, _ *http.Request) {
+	_ = json.NewEncoder(w).Encode(mapJobsToResponse(h.jobs.Statuses()))
+}
+
+// GetJob serves GET /v1/admin/jobs/{jobId}
+func (h *Job) GetJob(w http.ResponseWriter, r *http.Request) {
+	status, err := h.jobs.Status(router.Param(r, jobIDParam))
+	if err != nil {
+		WriteError(w, r, mapJobError(err))
+
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(mapJobToResponse(status))
+}
+
+// TriggerJob serves POST /v1/admin/jobs/{jobId}/runs, the run is started or queued by the overlap policy of the job
+func (h *Job) TriggerJob(w http.ResponseWriter, r *http.Request) {
+	jobID := router.Param(r, jobIDParam)
+
+	run, err := h.jobs.Trigger(jobID)
+	if err != nil {
+		WriteError(w, r, mapJobError(err))
+
+		return
+	}
+
+	requestLogger(r).Info("job run was triggered", logger.String("job_id", jobID), logger.Uint64("run_id", run.ID))
+
+	w.WriteHeader(http.StatusAccepted)
+	_ = json.NewEncoder(w).Encode(mapJobRunToResponse(run))
+}
+
+func mapJobError(err error) error {
+	switch {
+	case errors.Is(err, job.ErrNotFound):
+		return errorpkg.JobNotFound
+	case errors.Is(err, job.ErrRunning):
+		return errorpkg.JobRunning
+	case errors.Is(err, job.ErrPaused):
+		return errorpkg.JobPaused
+	}
+
+	return err
+}
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"time"
+
+	"blockchain-parser/tools/job"
+)
+
+type jobResponse struct {
+	ID         string           `json:"id"`
+	Name       string           `json:"name"`
+	Schedule   string           `json:"schedule"`
+	Jitter     string           `json:"jitter"`
+	Overlap    string           `json:"overlap"`
+	RunTimeout string           `json:"runTimeout,omitempty"`
+	Paused     bool             `json:"paused"`
+	Running    int              `json:"running"`
+	Skipped    uint64           `json:"skipped"`
+	NextRunAt  string           `json:"nextRunAt,omitempty"`
+	History    []jobRunResponse `json:"history"`
+}
+
+type jobsResponse struct {
+	Jobs []jobResponse `json:"jobs"`
+}
+
+type jobRunResponse struct {
+	ID         uint64 `json:"id"`
+	Trigger    string `json:"trigger"`
+	Status     string `json:"status"`
+	QueuedAt   string `json:"queuedAt,omitempty"`
+	StartedAt  string `json:"startedAt,omitempty"`
+	FinishedAt string `json:"finishedAt,omitempty"`
+	Duration   string `json:"duration,omitempty"`
+	Error      string `json:"error,omitempty"`
+	Panic      string `json:"panic,omitempty"`
+}
+
+func mapJobToResponse(status job.Status) jobResponse {
+	resp := jobResponse{
+		ID:        status.ID,
+		Name:      status.Name,
+		Schedule:  status.Schedule,
+		Jitter:    status.Policy.Jitter.String(),
+		Overlap:   string(status.Policy.Overlap),
+		Paused:    status.Paused,
+		Running:   status.Running,
+		Skipped:   status.Skipped,
+		NextRunAt: formatTimestamp(status.NextRunAt),
+		History:   make([]jobRunResponse, 0, len(status.History)),
+	}
+
+	if status.Policy.Timeout > 0 {
+		resp.RunTimeout = status.Policy.Timeout.String()
+	}
+
+	for _, run := range status.History {
+		resp.History = append(resp.History, mapJobRunToResponse(run))
+	}
+
+	return resp
+}
+
+func mapJobsToResponse(statuses []job.Status) jobsResponse {
+	resp := jobsResponse{
+		Jobs: make([]jobResponse, 0, len(statuses)),
+	}
+
+	for _, status := range statuses {
+		resp.Jobs = append(resp.Jobs, mapJobToResponse(status))
+	}
+
+	return resp
+}
+
+func mapJobRunToResponse(run job.Run) jobRunResponse {
+	resp := jobRunResponse{
+		ID:         run.ID,
+		Trigger:    string(run.Trigger),
+		Status:     string(run.Status),
+		QueuedAt:   formatTimestamp(run.QueuedAt),
+		StartedAt:  formatTimestamp(run.StartedAt),
+		FinishedAt: formatTimestamp(run.FinishedAt),
+		Error:      run.Error,
+		Panic:      run.Panic,
+	}
+
+	if !run.StartedAt.IsZero() && !run.FinishedAt.IsZero() {
+		resp.Duration = run.FinishedAt.Sub(run.StartedAt).Truncate(time.Millisecond).String()
+	}
+
+	return resp
+}
@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	errorpkg "blockchain-parser/internal/error"
+	"blockchain-parser/tools/logger"
+)
+
+// LogLevel serves admin endpoints which change the log level at runtime, access is checked by the auth middleware
+type LogLevel struct {
+	level LogLevelManager
+}
+
+func NewLogLevel(level LogLevelManager) *LogLevel {
+	return &LogLevel{
+		level: level,
+	}
+}
+
+// GetLogLevel serves GET /v1/admin/log-level
+func (h *LogLevel) GetLogLevel(w http.ResponseWriter, _ *http.Request) {
+	_ = json.NewEncoder(w).Encode(logLevelResponse{Level: h.level.Level().String()})
+}
+
+// SetLogLevel serves PUT /v1/admin/log-level
+func (h *LogLevel) SetLogLevel(w http.ResponseWriter, r *http.Request) {
+	logLevelUpdate := LogLevelUpdate{}
+	if err := json.NewDecoder(r.Body).Decode(&logLevelUpdate); err != nil {
+		WriteError(w, r, errorpkg.NewInvalidArgument("body", fmt.Sprintf("fail decode request: %s", err)))
+
+		return
+	}
+
+	level, err := logger.ParseLevel(logLevelUpdate.Level)
+	if err != nil {
+		WriteError(w, r, errorpkg.NewInvalidArgument("level", err.Error()))
+
+		return
+	}
+
+	previous := h.level.Level()
+	h.level.Set(level)
+
+	requestLogger(r).Info("log level was changed", logger.Stringer("from", previous), logger.Stringer("to", level))
+
+	_ = json.NewEncoder(w).Encode(logLevelResponse{Level: level.String()})
+}
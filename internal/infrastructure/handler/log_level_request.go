@@ -0,0 +1,5 @@
+package handler
+
+type LogLevelUpdate struct {
+	Level string `json:"level"`
+}
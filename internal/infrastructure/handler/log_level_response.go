@@ -0,0 +1,5 @@
+package handler
+
+type logLevelResponse struct {
+	Level string `json:"level"`
+}
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"blockchain-parser/internal/entity"
+	errorpkg "blockchain-parser/internal/error"
+)
+
+// ParserControl serves admin endpoints which scale and pause parser workers, access is checked by the auth middleware
+type ParserControl struct {
+	control ParserController
+}
+
+func NewParserControl(control ParserController) *ParserControl {
+	return &ParserControl{
+		control: control,
+	}
+}
+
+// GetParserState serves GET /v1/admin/parser
+func (h *ParserControl) GetParserState(w http.ResponseWriter, r *http.Request) {
+	state, err := h.control.State(r.Context())
+	writeParserState(w, r, state, err)
+}
+
+// ScaleWorkers serves PUT /v1/admin/parser/workers
+func (h *ParserControl) ScaleWorkers(w http.ResponseWriter, r *http.Request) {
+	workersUpdate := WorkersUpdate{}
+	if err := json.NewDecoder(r.Body).Decode(&workersUpdate); err != nil {
+		WriteError(w, r, errorpkg.NewInvalidArgument("body", fmt.Sprintf("fail decode request: %s", err)))
+
+		return
+	}
+
+	if workersUpdate.Count == nil {
+		WriteError(w, r, errorpkg.NewInvalidArgument("count", "count is required"))
+
+		return
+	}
+
+	state, err := h.control.Scale(r.Context(), *workersUpdate.Count)
+	writeParserState(w, r, state, err)
+}
+
+// PauseParsing serves POST /v1/admin/parser/pause
+func (h *ParserControl) PauseParsing(w http.ResponseWriter, r *http.Request) {
+	state, err := h.control.Pause(r.Context())
+	writeParserState(w, r, state, err)
+}
+
+// ResumeParsing serves POST /v1/admin/parser/resume
+func (h *ParserControl) ResumeParsing(w http.ResponseWriter, r *http.Request) {
+	state, err := h.control.Resume(r.Context())
+	writeParserState(w, r, state, err)
+}
+
+func writeParserState(w http.ResponseWriter, r *http.Request, state entity.ParserState, err error) {
+	if err != nil {
+		WriteError(w, r, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(mapParserStateToResponse(state))
+}
@@ -0,0 +1,6 @@
+package handler
+
+type WorkersUpdate struct {
+	// Count is a pointer to tell zero workers from the missing field
+	Count *int `json:"count"`
+}
@@ -0,0 +1,19 @@
+package handler
+
+import (
+	"blockchain-parser/internal/entity"
+)
+
+type parserStateResponse struct {
+	Workers int  `json:"workers"`
+	Paused  bool `json:"paused"`
+	Running int  `json:"running"`
+}
+
+func mapParserStateToResponse(state entity.ParserState) parserStateResponse {
+	return parserStateResponse{
+		Workers: state.Workers,
+		Paused:  state.Paused,
+		Running: state.Running,
+	}
+}
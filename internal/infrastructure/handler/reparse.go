@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"blockchain-parser/internal/entity"
+	errorpkg "blockchain-parser/internal/error"
+	"blockchain-parser/tools/logger"
+	"blockchain-parser/tools/router"
+)
+
+const (
+	reparseTaskIDParam = "taskId"
+)
+
+// Reparse serves admin endpoints of reparse tasks, access is checked by the auth middleware
+type Reparse struct {
+	reparse ReparseManager
+}
+
+func NewReparse(reparse ReparseManager) *Reparse {
+	return &Reparse{
+		reparse: reparse,
+	}
+}
+
+// GetReparseTasks serves GET /v1/admin/reparse
+func (h *Reparse) GetReparseTasks(w http.ResponseWriter, r *http.Request) {
+	tasks, err := h.reparse.GetTasks(r.Context())
+	if err != nil {
+		WriteError(w, r, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(mapReparseTasksToResponse(tasks))
+}
+
+// CreateReparseTask serves POST /v1/admin/reparse, the task is run by the reparse job
+func (h *Reparse) CreateReparseTask(w http.ResponseWriter, r *http.Request) {
+	reparseCreate := ReparseCreate{}
+	if err := json.NewDecoder(r.Body).Decode(&reparseCreate); err != nil {
+		WriteError(w, r, errorpkg.NewInvalidArgument("body", fmt.Sprintf("fail decode request: %s", err)))
+
+		return
+	}
+
+	from, err := parseRequiredBlockNumber("from", reparseCreate.From)
+	if err != nil {
+		WriteError(w, r, err)
+
+		return
+	}
+
+	to, err := parseRequiredBlockNumber("to", reparseCreate.To)
+	if err != nil {
+		WriteError(w, r, err)
+
+		return
+	}
+
+	addresses, err := parseAddresses("addresses", reparseCreate.Addresses)
+	if err != nil {
+		WriteError(w, r, err)
+
+		return
+	}
+
+	task, err := h.reparse.CreateTask(r.Context(), from, to, addresses)
+	if err != nil {
+		WriteError(w, r, err)
+
+		return
+	}
+
+	requestLogger(r).Info("reparse task was created", logger.String("task_id", task.ID),
+		logger.Uint64("from", uint64(task.From)), logger.Uint64("to", uint64(task.To)))
+
+	w.WriteHeader(http.StatusAccepted)
+	_ = json.NewEncoder(w).Encode(mapReparseTaskToResponse(task))
+}
+
+// GetReparseTask serves GET /v1/admin/reparse/{taskId}
+func (h *Reparse) GetReparseTask(w http.ResponseWriter, r *http.Request) {
+	task, err := h.reparse.GetTask(r.Context(), router.Param(r, reparseTaskIDParam))
+	if err != nil {
+		WriteError(w, r, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(mapReparseTaskToResponse(task))
+}
+
+// CancelReparseTask serves DELETE /v1/admin/reparse/{taskId}, finished tasks are returned as is
+func (h *Reparse) CancelReparseTask(w http.ResponseWriter, r *http.Request) {
+	task, err := h.reparse.CancelTask(r.Context(), router.Param(r, reparseTaskIDParam))
+	if err != nil {
+		WriteError(w, r, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(mapReparseTaskToResponse(task))
+}
+
+func parseRequiredBlockNumber(argument, value string) (entity.BlockNumber, error) {
+	if value == "" {
+		return 0, errorpkg.NewInvalidArgument(argument, fmt.Sprintf("%s is required", argument))
+	}
+
+	number, err := parseBlockNumber(value)
+	if err != nil {
+		return 0, errorpkg.NewInvalidArgument(argument, fmt.Sprintf("invalid %s: %s", argument, err))
+	}
+
+	return number, nil
+}
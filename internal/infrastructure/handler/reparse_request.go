@@ -0,0 +1,8 @@
+package handler
+
+// ReparseCreate is the range of blocks to parse again, block numbers are 0x prefixed hex or decimal strings
+type ReparseCreate struct {
+	From      string   `json:"from"`
+	To        string   `json:"to"`
+	Addresses []string `json:"addresses"`
+}
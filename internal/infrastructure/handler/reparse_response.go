@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"blockchain-parser/internal/entity"
+)
+
+type reparseTaskResponse struct {
+	ID                  string   `json:"id"`
+	From                string   `json:"from"`
+	To                  string   `json:"to"`
+	Addresses           []string `json:"addresses"`
+	Status              string   `json:"status"`
+	NextBlock           string   `json:"nextBlock"`
+	ParsedBlocks        uint64   `json:"parsedBlocks"`
+	TotalBlocks         uint64   `json:"totalBlocks"`
+	MatchedTransactions int      `json:"matchedTransactions"`
+	Error               string   `json:"error,omitempty"`
+	CreatedAt           string   `json:"createdAt"`
+	StartedAt           string   `json:"startedAt,omitempty"`
+	FinishedAt          string   `json:"finishedAt,omitempty"`
+}
+
+type reparseTasksResponse struct {
+	Tasks []reparseTaskResponse `json:"tasks"`
+}
+
+func mapReparseTaskToResponse(task entity.ReparseTask) reparseTaskResponse {
+	return reparseTaskResponse{
+		ID:                  task.ID,
+		From:                task.From.Hex(),
+		To:                  task.To.Hex(),
+		Addresses:           checksumAddresses(task.Addresses),
+		Status:              task.Status,
+		NextBlock:           task.NextBlock.Hex(),
+		ParsedBlocks:        task.ParsedBlocks(),
+		TotalBlocks:         task.TotalBlocks(),
+		MatchedTransactions: task.MatchedTransactions,
+		Error:               task.Error,
+		CreatedAt:           formatTimestamp(task.CreatedAt),
+		StartedAt:           formatTimestamp(task.StartedAt),
+		FinishedAt:          formatTimestamp(task.FinishedAt),
+	}
+}
+
+func mapReparseTasksToResponse(tasks []entity.ReparseTask) reparseTasksResponse {
+	resp := reparseTasksResponse{
+		Tasks: make([]reparseTaskResponse, 0, len(tasks)),
+	}
+
+	for _, task := range tasks {
+		resp.Tasks = append(resp.Tasks, mapReparseTaskToResponse(task))
+	}
+
+	return resp
+}
@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"blockchain-parser/tools/logger"
+)
+
+const (
+	RequestIDHeader = "X-Request-ID"
+)
+
+type requestIDKey struct{}
+
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+
+	return requestID
+}
+
+// requestLogger returns the logger of the request, it carries request ID set by the middleware
+func requestLogger(r *http.Request) logger.Logger {
+	return logger.FromContext(r.Context(), logger.Nop())
+}
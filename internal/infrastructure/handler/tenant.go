@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	errorpkg "blockchain-parser/internal/error"
+	"blockchain-parser/tools/router"
+)
+
+const (
+	tenantIDParam = "tenantId"
+	apiKeyIDParam = "keyId"
+)
+
+// Tenant serves admin endpoints, access is checked by the auth middleware
+type Tenant struct {
+	tenant TenantManager
+}
+
+func NewTenant(tenant TenantManager) *Tenant {
+	return &Tenant{
+		tenant: tenant,
+	}
+}
+
+// CreateTenant serves POST /v1/admin/tenants
+func (h *Tenant) CreateTenant(w http.ResponseWriter, r *http.Request) {
+	tenantCreate := TenantCreate{}
+	if err := json.NewDecoder(r.Body).Decode(&tenantCreate); err != nil {
+		WriteError(w, r, errorpkg.NewInvalidArgument("body", fmt.Sprintf("fail decode request: %s", err)))
+
+		return
+	}
+
+	if tenantCreate.Name == "" {
+		WriteError(w, r, errorpkg.NewInvalidArgument("name", "name is required"))
+
+		return
+	}
+
+	if tenantCreate.MaxSubscriptions < 0 {
+		WriteError(w, r, errorpkg.NewInvalidArgument("maxSubscriptions", "maxSubscriptions must not be negative"))
+
+		return
+	}
+
+	tenant, err := h.tenant.CreateTenant(r.Context(), tenantCreate.Name, tenantCreate.MaxSubscriptions)
+	if err != nil {
+		WriteError(w, r, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(mapTenantToResponse(tenant))
+}
+
+// GetTenants serves GET /v1/admin/tenants
+func (h *Tenant) GetTenants(w http.ResponseWriter, r *http.Request) {
+	tenants, err := h.tenant.GetTenants(r.Context())
+	if err != nil {
+		WriteError(w, r, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(mapTenantsToResponse(tenants))
+}
+
+// IssueAPIKey serves POST /v1/admin/tenants/{tenantId}/keys, the key is returned only in this response
+func (h *Tenant) IssueAPIKey(w http.ResponseWriter, r *http.Request) {
+	apiKey, key, err := h.tenant.IssueAPIKey(r.Context(), router.Param(r, tenantIDParam))
+	if err != nil {
+		WriteError(w, r, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(mapAPIKeyToResponse(apiKey, key))
+}
+
+// GetAPIKeys serves GET /v1/admin/tenants/{tenantId}/keys
+func (h *Tenant) GetAPIKeys(w http.ResponseWriter, r *http.Request) {
+	keys, err := h.tenant.GetAPIKeys(r.Context(), router.Param(r, tenantIDParam))
+	if err != nil {
+		WriteError(w, r, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(mapAPIKeysToResponse(keys))
+}
+
+// RevokeAPIKey serves DELETE /v1/admin/tenants/{tenantId}/keys/{keyId}
+func (h *Tenant) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	if err := h.tenant.RevokeAPIKey(r.Context(), router.Param(r, tenantIDParam), router.Param(r, apiKeyIDParam)); err != nil {
+		WriteError(w, r, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
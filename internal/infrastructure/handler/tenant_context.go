@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+const (
+	APIKeyHeader        = "X-API-Key"
+	authorizationHeader = "Authorization"
+	bearerPrefix        = "Bearer "
+)
+
+type tenantIDKey struct{}
+
+func ContextWithTenantID(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantIDKey{}, tenantID)
+}
+
+func TenantIDFromContext(ctx context.Context) string {
+	tenantID, _ := ctx.Value(tenantIDKey{}).(string)
+
+	return tenantID
+}
+
+// APIKeyFromRequest reads the key from X-API-Key header or from Authorization header with Bearer scheme
+func APIKeyFromRequest(r *http.Request) string {
+	if key := r.Header.Get(APIKeyHeader); key != "" {
+		return key
+	}
+
+	authorization := r.Header.Get(authorizationHeader)
+	if strings.HasPrefix(authorization, bearerPrefix) {
+		return strings.TrimPrefix(authorization, bearerPrefix)
+	}
+
+	return ""
+}
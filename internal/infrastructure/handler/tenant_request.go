@@ -0,0 +1,7 @@
+package handler
+
+type TenantCreate struct {
+	Name string `json:"name"`
+	// MaxSubscriptions caps subscriptions of the tenant, zero means the server default
+	MaxSubscriptions int `json:"maxSubscriptions"`
+}
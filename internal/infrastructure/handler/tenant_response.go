@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"time"
+
+	"blockchain-parser/internal/entity"
+)
+
+type tenantResponse struct {
+	ID               string `json:"id"`
+	Name             string `json:"name"`
+	MaxSubscriptions int    `json:"maxSubscriptions,omitempty"`
+	CreatedAt        string `json:"createdAt"`
+}
+
+type tenantsResponse struct {
+	Tenants []tenantResponse `json:"tenants"`
+}
+
+type apiKeyResponse struct {
+	ID        string `json:"id"`
+	TenantID  string `json:"tenantId"`
+	Key       string `json:"key,omitempty"`
+	CreatedAt string `json:"createdAt"`
+	RevokedAt string `json:"revokedAt,omitempty"`
+}
+
+type apiKeysResponse struct {
+	Keys []apiKeyResponse `json:"keys"`
+}
+
+func mapTenantToResponse(tenant entity.Tenant) tenantResponse {
+	return tenantResponse{
+		ID:               tenant.ID,
+		Name:             tenant.Name,
+		MaxSubscriptions: tenant.MaxSubscriptions,
+		CreatedAt:        tenant.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func mapTenantsToResponse(tenants []entity.Tenant) tenantsResponse {
+	resp := tenantsResponse{
+		Tenants: make([]tenantResponse, 0, len(tenants)),
+	}
+
+	for _, tenant := range tenants {
+		resp.Tenants = append(resp.Tenants, mapTenantToResponse(tenant))
+	}
+
+	return resp
+}
+
+// mapAPIKeyToResponse maps the key description, the key itself is passed only when it's issued
+func mapAPIKeyToResponse(apiKey entity.APIKey, key string) apiKeyResponse {
+	resp := apiKeyResponse{
+		ID:        apiKey.ID,
+		TenantID:  apiKey.TenantID,
+		Key:       key,
+		CreatedAt: apiKey.CreatedAt.UTC().Format(time.RFC3339),
+	}
+
+	if apiKey.RevokedAt != nil {
+		resp.RevokedAt = apiKey.RevokedAt.UTC().Format(time.RFC3339)
+	}
+
+	return resp
+}
+
+func mapAPIKeysToResponse(keys []entity.APIKey) apiKeysResponse {
+	resp := apiKeysResponse{
+		Keys: make([]apiKeyResponse, 0, len(keys)),
+	}
+
+	for _, key := range keys {
+		resp.Keys = append(resp.Keys, mapAPIKeyToResponse(key, ""))
+	}
+
+	return resp
+}
@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"blockchain-parser/internal/entity"
+	errorpkg "blockchain-parser/internal/error"
+	"blockchain-parser/tools/router"
+)
+
+const (
+	walletIDParam = "id"
+)
+
+type Wallet struct {
+	wallet WalletManager
+}
+
+func NewWallet(wallet WalletManager) *Wallet {
+	return &Wallet{
+		wallet: wallet,
+	}
+}
+
+// GetWallets serves GET /v1/wallets
+func (h *Wallet) GetWallets(w http.ResponseWriter, r *http.Request) {
+	wallets, err := h.wallet.GetWallets(r.Context(), TenantIDFromContext(r.Context()))
+	if err != nil {
+		WriteError(w, r, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(mapWalletsToResponse(wallets))
+}
+
+// CreateWallet serves POST /v1/wallets
+func (h *Wallet) CreateWallet(w http.ResponseWriter, r *http.Request) {
+	walletSave, addresses, ok := decodeWalletSave(w, r)
+	if !ok {
+		return
+	}
+
+	wallet, err := h.wallet.CreateWallet(r.Context(), TenantIDFromContext(r.Context()), walletSave.Name, addresses)
+	if err != nil {
+		WriteError(w, r, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(mapWalletToResponse(wallet))
+}
+
+// GetWallet serves GET /v1/wallets/{id}
+func (h *Wallet) GetWallet(w http.ResponseWriter, r *http.Request) {
+	id := router.Param(r, walletIDParam)
+
+	wallet, err := h.wallet.GetWallet(r.Context(), TenantIDFromContext(r.Context()), id)
+	if err != nil {
+		WriteError(w, r, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(mapWalletToResponse(wallet))
+}
+
+// UpdateWallet serves PUT /v1/wallets/{id}
+func (h *Wallet) UpdateWallet(w http.ResponseWriter, r *http.Request) {
+	id := router.Param(r, walletIDParam)
+
+	walletSave, addresses, ok := decodeWalletSave(w, r)
+	if !ok {
+		return
+	}
+
+	wallet, err := h.wallet.UpdateWallet(r.Context(), TenantIDFromContext(r.Context()), id, walletSave.Name, addresses)
+	if err != nil {
+		WriteError(w, r, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(mapWalletToResponse(wallet))
+}
+
+// DeleteWallet serves DELETE /v1/wallets/{id}
+func (h *Wallet) DeleteWallet(w http.ResponseWriter, r *http.Request) {
+	id := router.Param(r, walletIDParam)
+
+	if err := h.wallet.DeleteWallet(r.Context(), TenantIDFromContext(r.Context()), id); err != nil {
+		WriteError(w, r, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetWalletTransactions serves GET /v1/wallets/{id}/transactions
+func (h *Wallet) GetWalletTransactions(w http.ResponseWriter, r *http.Request) {
+	id := router.Param(r, walletIDParam)
+
+	filter, err := parseTransactionFilter(r.URL.Query())
+	if err != nil {
+		WriteError(w, r, err)
+
+		return
+	}
+
+	unit, err := parseUnit(r.URL.Query().Get("unit"))
+	if err != nil {
+		WriteError(w, r, err)
+
+		return
+	}
+
+	feed, err := h.wallet.GetWalletTransactions(r.Context(), TenantIDFromContext(r.Context()), id, filter)
+	if err != nil {
+		WriteError(w, r, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(mapTransactionFeedToResponse(feed, unit))
+}
+
+func decodeWalletSave(w http.ResponseWriter, r *http.Request) (WalletSave, []entity.Address, bool) {
+	walletSave := WalletSave{}
+	if err := json.NewDecoder(r.Body).Decode(&walletSave); err != nil {
+		WriteError(w, r, errorpkg.NewInvalidArgument("body", fmt.Sprintf("fail decode request: %s", err)))
+
+		return WalletSave{}, nil, false
+	}
+
+	if len(walletSave.Addresses) == 0 || len(walletSave.Addresses) > maxQueryAddresses {
+		WriteError(w, r, errorpkg.NewInvalidArgument("addresses", fmt.Sprintf("addresses must contain from 1 to %d addresses", maxQueryAddresses)))
+
+		return WalletSave{}, nil, false
+	}
+
+	addresses, err := parseAddresses("addresses", walletSave.Addresses)
+	if err != nil {
+		WriteError(w, r, err)
+
+		return WalletSave{}, nil, false
+	}
+
+	return walletSave, addresses, true
+}
@@ -0,0 +1,6 @@
+package handler
+
+type WalletSave struct {
+	Name      string   `json:"name"`
+	Addresses []string `json:"addresses"`
+}
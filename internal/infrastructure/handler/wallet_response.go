@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"time"
+
+	"blockchain-parser/internal/entity"
+)
+
+type walletResponse struct {
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	Addresses []string `json:"addresses"`
+	CreatedAt string   `json:"createdAt"`
+	UpdatedAt string   `json:"updatedAt"`
+}
+
+type walletsResponse struct {
+	Wallets []walletResponse `json:"wallets"`
+}
+
+func mapWalletToResponse(wallet entity.Wallet) walletResponse {
+	return walletResponse{
+		ID:        wallet.ID,
+		Name:      wallet.Name,
+		Addresses: checksumAddresses(wallet.Addresses),
+		CreatedAt: wallet.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt: wallet.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func mapWalletsToResponse(wallets []entity.Wallet) walletsResponse {
+	resp := walletsResponse{
+		Wallets: make([]walletResponse, 0, len(wallets)),
+	}
+
+	for _, wallet := range wallets {
+		resp.Wallets = append(resp.Wallets, mapWalletToResponse(wallet))
+	}
+
+	return resp
+}
+
+func checksumAddresses(addresses []entity.Address) []string {
+	checksums := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		checksums = append(checksums, address.Checksum())
+	}
+
+	return checksums
+}
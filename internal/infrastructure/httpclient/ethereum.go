@@ -9,26 +9,42 @@ import (
 	"math/rand"
 	"net/http"
 	"os"
-	"strconv"
+	"sync"
+	"time"
 
 	"blockchain-parser/config"
 	"blockchain-parser/internal/entity"
 	errorpkg "blockchain-parser/internal/error"
+	"blockchain-parser/internal/metrics"
+	"blockchain-parser/tools/logger"
+	"blockchain-parser/tools/tracing"
 )
 
 const (
-	ethJSONRPCVersion = "2.0"
+	ethJSONRPCVersion    = "2.0"
+	ethJSONRPCNullResult = "null"
 
-	ethGetBlockNumberMethod = "eth_blockNumber"
-	ethGetBlockByNumber     = "eth_getBlockByNumber"
+	ethGetBlockNumberMethod  = "eth_blockNumber"
+	ethGetBlockByNumber      = "eth_getBlockByNumber"
+	ethGetTransactionByHash  = "eth_getTransactionByHash"
+	ethGetTransactionReceipt = "eth_getTransactionReceipt"
+	ethGetBalance            = "eth_getBalance"
+	traceBlock               = "trace_block"
+)
+
+var (
+	errNullResult = errors.New("result is nil")
 )
 
 type Ethereum struct {
+	// mu guards the client and the config which are replaced by Configure
+	mu   sync.RWMutex
 	clnt http.Client
 	cfg  config.EthereumHttpClient
+	log  logger.Logger
 }
 
-func NewEthereum(cfg config.EthereumHttpClient) *Ethereum {
+func NewEthereum(cfg config.EthereumHttpClient, log logger.Logger) *Ethereum {
 	clnt := http.Client{
 		Timeout: cfg.Timeout,
 	}
@@ -36,111 +52,225 @@ func NewEthereum(cfg config.EthereumHttpClient) *Ethereum {
 	return &Ethereum{
 		clnt: clnt,
 		cfg:  cfg,
+		log:  log,
 	}
 }
 
-func (c *Ethereum) GetBlockNumber(ctx context.Context) (int, error) {
-	id := rand.Int31()
-	body := ethereumRequestBody{
-		Version: ethJSONRPCVersion,
-		Method:  ethGetBlockNumberMethod,
-		Params:  []interface{}{},
-		ID:      id,
+// Configure replaces the node endpoint and the timeout, calls in progress finish with the previous ones
+func (c *Ethereum) Configure(cfg config.EthereumHttpClient) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.clnt = http.Client{
+		Timeout: cfg.Timeout,
 	}
-	buf := bytes.Buffer{}
-	if err := json.NewEncoder(&buf).Encode(body); err != nil {
-		return 0, fmt.Errorf("fail marshal body in GetBlock: %w", err)
+	c.cfg = cfg
+}
+
+func (c *Ethereum) GetBlockNumber(ctx context.Context) (entity.BlockNumber, error) {
+	var result entity.BlockNumber
+	if err := c.call(ctx, ethGetBlockNumberMethod, []interface{}{}, &result); err != nil {
+		return 0, fmt.Errorf("fail get block number in GetBlock: %w", err)
+	}
+
+	return result, nil
+}
+
+func (c *Ethereum) GetBlockByNumber(ctx context.Context, blockNumber entity.BlockNumber) (entity.ChainBlock, error) {
+	params := []interface{}{
+		blockNumber,
+		true,
 	}
 
-	req, err := http.NewRequest(http.MethodPost, c.cfg.Host, &buf)
+	result := EthereumGetBlockByNumberResult{}
+	err := c.call(ctx, ethGetBlockByNumber, params, &result)
+	if errors.Is(err, errNullResult) {
+		return entity.ChainBlock{}, fmt.Errorf("block (%d) is not available in GetBlockByNumber: %w", blockNumber, errorpkg.BlockNotFound)
+	}
 	if err != nil {
-		return 0, fmt.Errorf("fail create request in GetBlock: %w", err)
+		return entity.ChainBlock{}, fmt.Errorf("fail get block in GetBlockByNumber: %w", err)
 	}
-	req = req.WithContext(ctx)
 
-	resp, err := c.clnt.Do(req)
-	if resp != nil {
-		defer resp.Body.Close()
+	block, err := mapResponseToChainBlock(result)
+	if err != nil {
+		return entity.ChainBlock{}, fmt.Errorf("fail map response in GetBlockByNumber: %w", err)
+	}
+
+	return block, nil
+}
+
+func (c *Ethereum) GetTransactionByHash(ctx context.Context, hash string) (entity.Transaction, error) {
+	result := EthereumTxn{}
+	err := c.call(ctx, ethGetTransactionByHash, []interface{}{hash}, &result)
+	if errors.Is(err, errNullResult) {
+		return entity.Transaction{}, fmt.Errorf("transaction (%s) is unknown in GetTransactionByHash: %w", hash, errorpkg.TransactionNotFound)
 	}
 	if err != nil {
-		if os.IsTimeout(err) || errors.Is(err, context.DeadlineExceeded) {
-			return 0, fmt.Errorf("fail get block number in GetBlock: %w", errorpkg.TimeoutErr)
+		return entity.Transaction{}, fmt.Errorf("fail get transaction in GetTransactionByHash: %w", err)
+	}
+
+	txn, err := mapResponseToTxn(result)
+	if err != nil {
+		return entity.Transaction{}, fmt.Errorf("fail map response in GetTransactionByHash: %w", err)
+	}
+
+	return txn, nil
+}
+
+func (c *Ethereum) GetTransactionReceipt(ctx context.Context, hash string) (entity.TransactionReceipt, error) {
+	result := EthereumReceipt{}
+	err := c.call(ctx, ethGetTransactionReceipt, []interface{}{hash}, &result)
+	if errors.Is(err, errNullResult) {
+		return entity.TransactionReceipt{}, fmt.Errorf("receipt of transaction (%s) is unknown in GetTransactionReceipt: %w", hash, errorpkg.TransactionNotFound)
+	}
+	if err != nil {
+		return entity.TransactionReceipt{}, fmt.Errorf("fail get receipt in GetTransactionReceipt: %w", err)
+	}
+
+	// the gas price of the transaction is paid when the receipt has no effective gas price
+	if result.EffectiveGasPrice == "" {
+		txn := EthereumTxn{}
+		if err := c.call(ctx, ethGetTransactionByHash, []interface{}{hash}, &txn); err != nil {
+			return entity.TransactionReceipt{}, fmt.Errorf("fail get transaction of receipt in GetTransactionReceipt: %w", err)
 		}
 
-		return 0, fmt.Errorf("fail get block number in GetBlock: %w", err)
+		result.EffectiveGasPrice = txn.GasPrice
 	}
 
-	ethGetBlockNumberResponse := ethereumGetBlockNumberResponse{}
-	if err := json.NewDecoder(resp.Body).Decode(&ethGetBlockNumberResponse); err != nil {
-		return 0, fmt.Errorf("fail unmarshal response in GetBlock: %w", err)
+	receipt, err := mapResponseToReceipt(result)
+	if err != nil {
+		return entity.TransactionReceipt{}, fmt.Errorf("fail map response in GetTransactionReceipt: %w", err)
 	}
 
-	if ethGetBlockNumberResponse.Error != nil {
-		return 0, fmt.Errorf("error code (%d), message (%s) in GetBlock: %w", ethGetBlockNumberResponse.Error.Code, ethGetBlockNumberResponse.Error.Message, errorpkg.HttpErr)
+	return receipt, nil
+}
+
+func (c *Ethereum) GetBalance(ctx context.Context, address entity.Address, blockNumber entity.BlockNumber) (entity.Wei, error) {
+	params := []interface{}{
+		address.String(),
+		blockNumber,
 	}
 
-	if ethGetBlockNumberResponse.Result == nil {
-		return 0, errors.New("result is nil in GetBlock response")
+	var result entity.Wei
+	if err := c.call(ctx, ethGetBalance, params, &result); err != nil {
+		return entity.Wei{}, fmt.Errorf("fail get balance in GetBalance: %w", err)
 	}
 
-	if ethGetBlockNumberResponse.ID != id {
-		return 0, errors.New("mismatch request and response IDs in GetBlock")
+	return result, nil
+}
+
+// GetInternalTransfers returns value transfers of contract calls in the block. It uses trace_block,
+// so the node must support trace API (e.g. Erigon, Nethermind).
+func (c *Ethereum) GetInternalTransfers(ctx context.Context, blockNumber entity.BlockNumber) ([]entity.InternalTransfer, error) {
+	result := []EthereumTrace{}
+	err := c.call(ctx, traceBlock, []interface{}{blockNumber}, &result)
+	if errors.Is(err, errNullResult) {
+		return nil, fmt.Errorf("traces of block (%d) are not available in GetInternalTransfers: %w", blockNumber, errorpkg.BlockNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fail get traces in GetInternalTransfers: %w", err)
 	}
 
-	blockNumber, err := strconv.ParseInt(*ethGetBlockNumberResponse.Result, 0, 64)
+	transfers, err := mapTracesToInternalTransfers(result)
 	if err != nil {
-		return 0, fmt.Errorf("parse block number in GetBlock response: %w", err)
+		return nil, fmt.Errorf("fail map response in GetInternalTransfers: %w", err)
+	}
+
+	return transfers, nil
+}
+
+// call makes JSON-RPC request and decodes result. errNullResult is returned when node responds with null.
+// Duration and errors are collected by method, null result and cancellation by the caller aren't errors of the node.
+func (c *Ethereum) call(ctx context.Context, method string, params []interface{}, result interface{}) error {
+	ctx, span := tracing.StartKind(ctx, "rpc "+method, tracing.SpanKindClient,
+		tracing.String("rpc.system", "jsonrpc"),
+		tracing.String("rpc.method", method),
+	)
+	defer span.End()
+
+	start := time.Now()
+
+	err := c.do(ctx, method, params, result)
+	duration := time.Since(start)
+
+	log := logger.FromContext(ctx, c.log)
+	metrics.RPCRequestDuration.Observe(duration.Seconds(), method)
+	switch {
+	case err == nil || errors.Is(err, errNullResult):
+		log.Debug("rpc call", logger.String("method", method), logger.Duration("duration", duration))
+	case ctx.Err() != nil:
+		span.RecordError(err)
+		log.Debug("rpc call cancelled", logger.String("method", method), logger.Duration("duration", duration), logger.Err(err))
+	default:
+		metrics.RPCErrors.Inc(method)
+		span.RecordError(err)
+		log.Warn("rpc call failed", logger.String("method", method), logger.Duration("duration", duration), logger.Err(err))
 	}
 
-	return int(blockNumber), nil
+	return err
 }
 
-func (c *Ethereum) GetTxnsByBlockByNumber(ctx context.Context, blockNumber int) ([]entity.Transaction, error) {
+func (c *Ethereum) do(ctx context.Context, method string, params []interface{}, result interface{}) error {
 	id := rand.Int31()
 	body := ethereumRequestBody{
 		Version: ethJSONRPCVersion,
-		Method:  ethGetBlockByNumber,
-		Params: []interface{}{
-			fmt.Sprintf("0x%x", blockNumber),
-			true,
-		},
-		ID: id,
+		Method:  method,
+		Params:  params,
+		ID:      id,
 	}
 	buf := bytes.Buffer{}
 	if err := json.NewEncoder(&buf).Encode(body); err != nil {
-		return nil, fmt.Errorf("fail marshal body in GetTxnsByBlockByNumber: %w", err)
+		return fmt.Errorf("fail marshal body of %s: %w", method, err)
 	}
 
-	req, err := http.NewRequest(http.MethodPost, c.cfg.Host, &buf)
+	c.mu.RLock()
+	clnt, host := c.clnt, c.cfg.Host
+	c.mu.RUnlock()
+
+	req, err := http.NewRequest(http.MethodPost, host, &buf)
 	if err != nil {
-		return nil, fmt.Errorf("fail create request in GetTxnsByBlockByNumber: %w", err)
+		return fmt.Errorf("fail create request of %s: %w", method, err)
 	}
 	req = req.WithContext(ctx)
+	tracing.Inject(ctx, req.Header)
+	req.Header.Set("content-type", "application/json")
 
-	resp, err := c.clnt.Do(req)
+	resp, err := clnt.Do(req)
 	if resp != nil {
 		defer resp.Body.Close()
 	}
 	if err != nil {
+		// cancelled or timed out caller isn't a failure of the node
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return fmt.Errorf("fail call %s: %w", method, ctxErr)
+		}
 		if os.IsTimeout(err) || errors.Is(err, context.DeadlineExceeded) {
-			return nil, fmt.Errorf("fail get transaction in GetTxnsByBlockByNumber: %w", errorpkg.TimeoutErr)
+			return fmt.Errorf("fail call %s: %w", method, errorpkg.TimeoutErr)
 		}
 
-		return nil, fmt.Errorf("fail get transaction GetTxnsByBlockByNumber: %w", err)
+		return fmt.Errorf("fail call %s (%s): %w", method, err, errorpkg.HttpErr)
+	}
+
+	ethResponse := ethereumResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(&ethResponse); err != nil {
+		return fmt.Errorf("fail unmarshal response of %s: %w", method, err)
+	}
+
+	if ethResponse.Error != nil {
+		return fmt.Errorf("error code (%d), message (%s) of %s: %w", ethResponse.Error.Code, ethResponse.Error.Message, method, errorpkg.HttpErr)
 	}
 
-	ethGetBlockByNumberResponse := ethereumGetBlockByNumberResponse{}
-	if err := json.NewDecoder(resp.Body).Decode(&ethGetBlockByNumberResponse); err != nil {
-		return nil, fmt.Errorf("fail unmarshal response in GetTxnsByBlockByNumber: %w", err)
+	if ethResponse.ID != id {
+		return fmt.Errorf("mismatch request and response IDs of %s", method)
 	}
 
-	if ethGetBlockByNumberResponse.Error != nil {
-		return nil, fmt.Errorf("error code (%d), message (%s) in GetTxnsByBlockByNumber: %w", ethGetBlockByNumberResponse.Error.Code, ethGetBlockByNumberResponse.Error.Message, errorpkg.HttpErr)
+	if len(ethResponse.Result) == 0 || string(ethResponse.Result) == ethJSONRPCNullResult {
+		return fmt.Errorf("%s: %w", method, errNullResult)
 	}
 
-	if ethGetBlockByNumberResponse.ID != id {
-		return nil, errors.New("mismatch request and response IDs in GetTxnsByBlockByNumber")
+	if err := json.Unmarshal(ethResponse.Result, result); err != nil {
+		return fmt.Errorf("fail unmarshal result of %s: %w", method, err)
 	}
 
-	return mapResponseToTxns(ethGetBlockByNumberResponse), nil
+	return nil
 }
@@ -1,19 +1,236 @@
 package httpclient
 
-import "blockchain-parser/internal/entity"
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
 
-func mapResponseToTxns(resp ethereumGetBlockByNumberResponse) []entity.Transaction {
-	txns := make([]entity.Transaction, 0, len(resp.Result.Transactions))
+	"blockchain-parser/internal/constant"
+	"blockchain-parser/internal/entity"
+)
 
-	for _, resptxn := range resp.Result.Transactions {
-		txn := entity.Transaction{
-			From:  resptxn.From,
-			To:    resptxn.To,
-			Value: resptxn.Value,
+func mapResponseToChainBlock(result EthereumGetBlockByNumberResult) (entity.ChainBlock, error) {
+	timestamp, err := strconv.ParseInt(result.Timestamp, 0, 64)
+	if err != nil {
+		return entity.ChainBlock{}, fmt.Errorf("fail parse block timestamp (%s): %w", result.Timestamp, err)
+	}
+
+	block := entity.ChainBlock{
+		Number:       result.Number,
+		Hash:         result.Hash,
+		ParentHash:   result.ParentHash,
+		Timestamp:    time.Unix(timestamp, 0).UTC(),
+		Transactions: make([]entity.Transaction, 0, len(result.Transactions)),
+		Withdrawals:  make([]entity.Withdrawal, 0, len(result.Withdrawals)),
+	}
+
+	for _, resptxn := range result.Transactions {
+		txn, err := mapResponseToTxn(resptxn)
+		if err != nil {
+			return entity.ChainBlock{}, err
+		}
+
+		txn.BlockNumber = block.Number
+		txn.Timestamp = block.Timestamp
+
+		block.Transactions = append(block.Transactions, txn)
+	}
+
+	for _, respWithdrawal := range result.Withdrawals {
+		withdrawal, err := mapResponseToWithdrawal(respWithdrawal)
+		if err != nil {
+			return entity.ChainBlock{}, err
 		}
 
-		txns = append(txns, txn)
+		block.Withdrawals = append(block.Withdrawals, withdrawal)
+	}
+
+	return block, nil
+}
+
+// mapResponseToTxn maps transaction, transaction without block number is pending.
+func mapResponseToTxn(resptxn EthereumTxn) (entity.Transaction, error) {
+	from, err := parseAddress(resptxn.From)
+	if err != nil {
+		return entity.Transaction{}, fmt.Errorf("fail parse sender of transaction (%s): %w", resptxn.Hash, err)
+	}
+
+	to, err := parseAddress(resptxn.To)
+	if err != nil {
+		return entity.Transaction{}, fmt.Errorf("fail parse receiver of transaction (%s): %w", resptxn.Hash, err)
+	}
+
+	txn := entity.Transaction{
+		Hash:             resptxn.Hash,
+		Kind:             constant.TransactionKindTransaction,
+		From:             from,
+		To:               to,
+		Value:            resptxn.Value,
+		Pending:          resptxn.BlockNumber == nil,
+		TransactionIndex: -1,
+	}
+
+	if resptxn.BlockNumber != nil {
+		txn.BlockNumber = *resptxn.BlockNumber
+	}
+
+	if resptxn.TransactionIndex != "" {
+		txnIndex, err := strconv.ParseInt(resptxn.TransactionIndex, 0, 64)
+		if err != nil {
+			return entity.Transaction{}, fmt.Errorf("fail parse transaction index (%s): %w", resptxn.TransactionIndex, err)
+		}
+		txn.TransactionIndex = int(txnIndex)
+	}
+
+	return txn, nil
+}
+
+const (
+	gweiToWei = 1_000_000_000
+
+	receiptStatusSuccess = "0x1"
+
+	traceTypeCall     = "call"
+	traceCallTypeCall = "call"
+)
+
+func mapResponseToWithdrawal(respWithdrawal EthereumWithdrawal) (entity.Withdrawal, error) {
+	index, err := strconv.ParseInt(respWithdrawal.Index, 0, 64)
+	if err != nil {
+		return entity.Withdrawal{}, fmt.Errorf("fail parse withdrawal index (%s): %w", respWithdrawal.Index, err)
+	}
+
+	validatorIndex, err := strconv.ParseInt(respWithdrawal.ValidatorIndex, 0, 64)
+	if err != nil {
+		return entity.Withdrawal{}, fmt.Errorf("fail parse withdrawal validator index (%s): %w", respWithdrawal.ValidatorIndex, err)
+	}
+
+	address, err := parseAddress(respWithdrawal.Address)
+	if err != nil {
+		return entity.Withdrawal{}, fmt.Errorf("fail parse withdrawal address: %w", err)
+	}
+
+	return entity.Withdrawal{
+		Index:          int(index),
+		ValidatorIndex: int(validatorIndex),
+		Address:        address,
+		Amount:         entity.NewWei(new(big.Int).Mul(respWithdrawal.Amount.BigInt(), big.NewInt(gweiToWei))),
+	}, nil
+}
+
+func mapResponseToReceipt(result EthereumReceipt) (entity.TransactionReceipt, error) {
+	gasUsed, err := parseHexBigInt(result.GasUsed)
+	if err != nil {
+		return entity.TransactionReceipt{}, fmt.Errorf("fail parse gas used: %w", err)
+	}
+
+	gasPrice, err := parseHexBigInt(result.EffectiveGasPrice)
+	if err != nil {
+		return entity.TransactionReceipt{}, fmt.Errorf("fail parse effective gas price: %w", err)
+	}
+
+	fee := new(big.Int).Mul(gasUsed, gasPrice)
+
+	if result.BlobGasUsed != "" && result.BlobGasPrice != "" {
+		blobGasUsed, err := parseHexBigInt(result.BlobGasUsed)
+		if err != nil {
+			return entity.TransactionReceipt{}, fmt.Errorf("fail parse blob gas used: %w", err)
+		}
+
+		blobGasPrice, err := parseHexBigInt(result.BlobGasPrice)
+		if err != nil {
+			return entity.TransactionReceipt{}, fmt.Errorf("fail parse blob gas price: %w", err)
+		}
+
+		fee.Add(fee, blobGasUsed.Mul(blobGasUsed, blobGasPrice))
+	}
+
+	return entity.TransactionReceipt{
+		Hash:    result.TransactionHash,
+		Success: result.Status == receiptStatusSuccess,
+		Fee:     entity.NewWei(fee),
+	}, nil
+}
+
+// mapTracesToInternalTransfers keeps value transfers of nested calls. Top level traces are transactions themselves,
+// traces which failed or have a failed parent are skipped because their transfers were reverted.
+func mapTracesToInternalTransfers(traces []EthereumTrace) ([]entity.InternalTransfer, error) {
+	failed := map[string]struct{}{}
+	for _, trace := range traces {
+		if trace.Error != "" {
+			failed[trace.TransactionHash+"/"+joinTraceAddress(trace.TraceAddress)] = struct{}{}
+		}
+	}
+
+	transfers := []entity.InternalTransfer{}
+	for _, trace := range traces {
+		if len(trace.TraceAddress) == 0 || trace.Type != traceTypeCall || trace.Action.CallType != traceCallTypeCall {
+			continue
+		}
+
+		reverted := false
+		for i := 0; i <= len(trace.TraceAddress); i++ {
+			if _, ok := failed[trace.TransactionHash+"/"+joinTraceAddress(trace.TraceAddress[:i])]; ok {
+				reverted = true
+
+				break
+			}
+		}
+		if reverted {
+			continue
+		}
+
+		if trace.Action.Value.Sign() == 0 {
+			continue
+		}
+
+		from, err := parseAddress(trace.Action.From)
+		if err != nil {
+			return nil, fmt.Errorf("fail parse trace sender of transaction (%s): %w", trace.TransactionHash, err)
+		}
+
+		to, err := parseAddress(trace.Action.To)
+		if err != nil {
+			return nil, fmt.Errorf("fail parse trace receiver of transaction (%s): %w", trace.TransactionHash, err)
+		}
+
+		transfers = append(transfers, entity.InternalTransfer{
+			Hash:         trace.TransactionHash,
+			TraceAddress: joinTraceAddress(trace.TraceAddress),
+			From:         from,
+			To:           to,
+			Value:        trace.Action.Value,
+		})
+	}
+
+	return transfers, nil
+}
+
+func joinTraceAddress(traceAddress []int) string {
+	parts := make([]string, 0, len(traceAddress))
+	for _, index := range traceAddress {
+		parts = append(parts, strconv.Itoa(index))
+	}
+
+	return strings.Join(parts, "_")
+}
+
+// parseAddress normalizes address of the node response, empty address is kept for contract creation.
+func parseAddress(value string) (entity.Address, error) {
+	if value == "" {
+		return "", nil
+	}
+
+	return entity.ParseAddress(value)
+}
+
+func parseHexBigInt(value string) (*big.Int, error) {
+	number, ok := new(big.Int).SetString(value, 0)
+	if !ok {
+		return nil, fmt.Errorf("invalid number (%s)", value)
 	}
 
-	return txns
+	return number, nil
 }
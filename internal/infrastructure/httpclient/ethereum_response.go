@@ -1,28 +1,71 @@
 package httpclient
 
+import (
+	"encoding/json"
+
+	"blockchain-parser/internal/entity"
+)
+
 type EthereumError struct {
 	Code    int64
 	Message string
 }
 
+// EthereumTxn has no block number and index while transaction is pending.
 type EthereumTxn struct {
-	From  string
-	To    string
-	Value string
+	Hash             string
+	From             string
+	To               string
+	Value            entity.Wei
+	GasPrice         string
+	BlockNumber      *entity.BlockNumber
+	TransactionIndex string
 }
 
 type EthereumGetBlockByNumberResult struct {
+	Number       entity.BlockNumber
+	Hash         string
+	ParentHash   string
+	Timestamp    string
 	Transactions []EthereumTxn
+	Withdrawals  []EthereumWithdrawal
 }
 
-type ethereumGetBlockNumberResponse struct {
-	ID     int32
-	Result *string        `json:",omitempty"`
-	Error  *EthereumError `json:",omitempty"`
+type EthereumWithdrawal struct {
+	Index          string
+	ValidatorIndex string
+	Address        string
+	// Amount is in Gwei
+	Amount entity.Wei
+}
+
+// EthereumReceipt has no effective gas price on nodes and blocks before London fork.
+type EthereumReceipt struct {
+	TransactionHash   string
+	Status            string
+	GasUsed           string
+	EffectiveGasPrice string
+	BlobGasUsed       string
+	BlobGasPrice      string
+}
+
+type EthereumTrace struct {
+	Action          EthereumTraceAction
+	TransactionHash string
+	TraceAddress    []int
+	Type            string
+	Error           string
+}
+
+type EthereumTraceAction struct {
+	CallType string
+	From     string
+	To       string
+	Value    entity.Wei
 }
 
-type ethereumGetBlockByNumberResponse struct {
+type ethereumResponse struct {
 	ID     int32
-	Result EthereumGetBlockByNumberResult `json:",omitempty"`
-	Error  *EthereumError                 `json:",omitempty"`
+	Result json.RawMessage `json:",omitempty"`
+	Error  *EthereumError  `json:",omitempty"`
 }
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"blockchain-parser/internal/entity"
+	errorpkg "blockchain-parser/internal/error"
+	"blockchain-parser/tools/tracing"
+)
+
+// InMemAPIKey keeps API keys by ID and indexes them by hash for authentication.
+type InMemAPIKey struct {
+	data   map[string]entity.APIKey
+	byHash map[string]string
+	mu     sync.RWMutex
+}
+
+func NewInMemAPIKey() *InMemAPIKey {
+	return &InMemAPIKey{
+		data:   map[string]entity.APIKey{},
+		byHash: map[string]string{},
+	}
+}
+
+func (r *InMemAPIKey) Save(ctx context.Context, key entity.APIKey) error {
+	_, span := tracing.Start(ctx, "InMemAPIKey.Save")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if previous, ok := r.data[key.ID]; ok {
+		delete(r.byHash, previous.Hash)
+	}
+
+	r.data[key.ID] = key
+	r.byHash[key.Hash] = key.ID
+
+	return nil
+}
+
+func (r *InMemAPIKey) Get(ctx context.Context, id string) (entity.APIKey, error) {
+	_, span := tracing.Start(ctx, "InMemAPIKey.Get")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return entity.APIKey{}, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	key, ok := r.data[id]
+	if !ok {
+		return entity.APIKey{}, errorpkg.APIKeyNotFound
+	}
+
+	return key, nil
+}
+
+func (r *InMemAPIKey) GetByHash(ctx context.Context, hash string) (entity.APIKey, error) {
+	_, span := tracing.Start(ctx, "InMemAPIKey.GetByHash")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return entity.APIKey{}, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	id, ok := r.byHash[hash]
+	if !ok {
+		return entity.APIKey{}, errorpkg.APIKeyNotFound
+	}
+
+	return r.data[id], nil
+}
+
+// GetByTenant returns keys of the tenant ordered by creation time, revoked keys are included
+func (r *InMemAPIKey) GetByTenant(ctx context.Context, tenantID string) ([]entity.APIKey, error) {
+	_, span := tracing.Start(ctx, "InMemAPIKey.GetByTenant")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	keys := make([]entity.APIKey, 0)
+	for _, key := range r.data {
+		if key.TenantID == tenantID {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].CreatedAt.Equal(keys[j].CreatedAt) {
+			return keys[i].ID < keys[j].ID
+		}
+
+		return keys[i].CreatedAt.Before(keys[j].CreatedAt)
+	})
+
+	return keys, nil
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"blockchain-parser/internal/entity"
+	errorpkg "blockchain-parser/internal/error"
+)
+
+func TestInMemAPIKey_GetByHash(t *testing.T) {
+	ctx := context.Background()
+	revokedAt := time.Now()
+
+	key := entity.APIKey{ID: "k1", TenantID: "t1", Hash: "a1"}
+	revokedKey := key
+	revokedKey.RevokedAt = &revokedAt
+
+	r := NewInMemAPIKey()
+	if err := r.Save(ctx, key); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	got, err := r.GetByHash(ctx, "a1")
+	if err != nil {
+		t.Errorf("GetByHash() error = %v, wantErr %v", err, nil)
+		return
+	}
+	if !reflect.DeepEqual(got, key) {
+		t.Errorf("GetByHash() got = %v, want %v", got, key)
+	}
+
+	if err := r.Save(ctx, revokedKey); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	got, err = r.GetByHash(ctx, "a1")
+	if err != nil {
+		t.Errorf("GetByHash() error = %v, wantErr %v", err, nil)
+		return
+	}
+	if !reflect.DeepEqual(got, revokedKey) {
+		t.Errorf("GetByHash() got = %v, want %v", got, revokedKey)
+	}
+
+	if _, err := r.GetByHash(ctx, "b2"); !errors.Is(err, errorpkg.APIKeyNotFound) {
+		t.Errorf("GetByHash() error = %v, wantErr %v", err, errorpkg.APIKeyNotFound)
+	}
+}
+
+func TestInMemAPIKey_GetByTenant(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	key1 := entity.APIKey{ID: "k2", TenantID: "t1", Hash: "a1", CreatedAt: now}
+	key2 := entity.APIKey{ID: "k1", TenantID: "t1", Hash: "a2", CreatedAt: now.Add(time.Second)}
+	otherTenantKey := entity.APIKey{ID: "k3", TenantID: "t2", Hash: "a3", CreatedAt: now}
+
+	r := NewInMemAPIKey()
+	for _, key := range []entity.APIKey{key2, otherTenantKey, key1} {
+		if err := r.Save(ctx, key); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+
+	got, err := r.GetByTenant(ctx, "t1")
+	if err != nil {
+		t.Errorf("GetByTenant() error = %v, wantErr %v", err, nil)
+		return
+	}
+
+	want := []entity.APIKey{key1, key2}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetByTenant() got = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"blockchain-parser/internal/entity"
+	errorpkg "blockchain-parser/internal/error"
+	"blockchain-parser/tools/tracing"
+)
+
+type InMemBalance struct {
+	snapshots map[entity.Address]entity.BalanceSnapshot
+	// changes are grouped by address and change ID
+	changes map[entity.Address]map[string]entity.BalanceChange
+	// blocks keeps addresses which have changes in the block, it's used for deleting on reorg
+	blocks map[entity.BlockNumber]map[entity.Address]struct{}
+	mu     sync.RWMutex
+}
+
+func NewInMemBalance() *InMemBalance {
+	return &InMemBalance{
+		snapshots: map[entity.Address]entity.BalanceSnapshot{},
+		changes:   map[entity.Address]map[string]entity.BalanceChange{},
+		blocks:    map[entity.BlockNumber]map[entity.Address]struct{}{},
+	}
+}
+
+func (r *InMemBalance) SaveSnapshot(ctx context.Context, snapshot entity.BalanceSnapshot) error {
+	_, span := tracing.Start(ctx, "InMemBalance.SaveSnapshot")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	snapshot.Address = snapshot.Address.Canonical()
+	r.snapshots[snapshot.Address] = snapshot
+
+	return nil
+}
+
+func (r *InMemBalance) GetSnapshot(ctx context.Context, address entity.Address) (entity.BalanceSnapshot, error) {
+	_, span := tracing.Start(ctx, "InMemBalance.GetSnapshot")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return entity.BalanceSnapshot{}, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	snapshot, ok := r.snapshots[address.Canonical()]
+	if !ok {
+		return entity.BalanceSnapshot{}, errorpkg.BalanceNotTracked
+	}
+
+	return snapshot, nil
+}
+
+// GetSnapshots returns snapshots ordered by address
+func (r *InMemBalance) GetSnapshots(ctx context.Context) ([]entity.BalanceSnapshot, error) {
+	_, span := tracing.Start(ctx, "InMemBalance.GetSnapshots")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	snapshots := make([]entity.BalanceSnapshot, 0, len(r.snapshots))
+	for _, snapshot := range r.snapshots {
+		snapshots = append(snapshots, snapshot)
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].Address < snapshots[j].Address
+	})
+
+	return snapshots, nil
+}
+
+// SaveChange inserts the change or replaces the change with the same ID of the address
+func (r *InMemBalance) SaveChange(ctx context.Context, change entity.BalanceChange) error {
+	_, span := tracing.Start(ctx, "InMemBalance.SaveChange")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	change.Address = change.Address.Canonical()
+	if _, ok := r.changes[change.Address]; !ok {
+		r.changes[change.Address] = map[string]entity.BalanceChange{}
+	}
+
+	if prevChange, ok := r.changes[change.Address][change.ID]; ok && prevChange.BlockNumber != change.BlockNumber {
+		r.unindex(prevChange)
+	}
+
+	r.changes[change.Address][change.ID] = change
+
+	if _, ok := r.blocks[change.BlockNumber]; !ok {
+		r.blocks[change.BlockNumber] = map[entity.Address]struct{}{}
+	}
+	r.blocks[change.BlockNumber][change.Address] = struct{}{}
+
+	return nil
+}
+
+// GetChanges returns changes of the address in blocks (fromBlock, toBlock] ordered by block number and ID
+func (r *InMemBalance) GetChanges(ctx context.Context, address entity.Address, fromBlock, toBlock entity.BlockNumber) ([]entity.BalanceChange, error) {
+	_, span := tracing.Start(ctx, "InMemBalance.GetChanges")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	changes := []entity.BalanceChange{}
+	for _, change := range r.changes[address.Canonical()] {
+		if change.BlockNumber > fromBlock && change.BlockNumber <= toBlock {
+			changes = append(changes, change)
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].BlockNumber == changes[j].BlockNumber {
+			return changes[i].ID < changes[j].ID
+		}
+
+		return changes[i].BlockNumber < changes[j].BlockNumber
+	})
+
+	return changes, nil
+}
+
+func (r *InMemBalance) DeleteByBlockNumber(ctx context.Context, blockNumber entity.BlockNumber) error {
+	_, span := tracing.Start(ctx, "InMemBalance.DeleteByBlockNumber")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for address := range r.blocks[blockNumber] {
+		for id, change := range r.changes[address] {
+			if change.BlockNumber == blockNumber {
+				delete(r.changes[address], id)
+			}
+		}
+	}
+
+	delete(r.blocks, blockNumber)
+
+	return nil
+}
+
+// unindex removes the address from the block index when the address has no other changes in the block
+func (r *InMemBalance) unindex(change entity.BalanceChange) {
+	for id, addressChange := range r.changes[change.Address] {
+		if id != change.ID && addressChange.BlockNumber == change.BlockNumber {
+			return
+		}
+	}
+
+	delete(r.blocks[change.BlockNumber], change.Address)
+}
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"blockchain-parser/internal/entity"
+	errorpkg "blockchain-parser/internal/error"
+)
+
+func TestInMemBalance_GetSnapshot(t *testing.T) {
+	ctx := context.Background()
+	address := entity.Address("0xd7def8de6bff40e7fa3a19b6749aca84bd5ba0ae")
+
+	r := NewInMemBalance()
+	if _, err := r.GetSnapshot(ctx, address); !errors.Is(err, errorpkg.BalanceNotTracked) {
+		t.Errorf("GetSnapshot() error = %v, wantErr %v", err, errorpkg.BalanceNotTracked)
+	}
+
+	snapshot := entity.BalanceSnapshot{
+		Address:     address,
+		BlockNumber: 10,
+		Value:       entity.NewWeiFromInt64(1000),
+	}
+	if err := r.SaveSnapshot(ctx, snapshot); err != nil {
+		t.Fatalf("SaveSnapshot() error = %v", err)
+	}
+
+	got, err := r.GetSnapshot(ctx, address)
+	if err != nil {
+		t.Errorf("GetSnapshot() error = %v, wantErr %v", err, nil)
+		return
+	}
+	if !reflect.DeepEqual(got, snapshot) {
+		t.Errorf("GetSnapshot() got = %v, want %v", got, snapshot)
+	}
+}
+
+func TestInMemBalance_GetChanges(t *testing.T) {
+	ctx := context.Background()
+	address := entity.Address("0xd7def8de6bff40e7fa3a19b6749aca84bd5ba0ae")
+
+	change1 := entity.BalanceChange{ID: "transaction_in_0x5a", Address: address, BlockNumber: 10, Delta: entity.NewWeiFromInt64(100)}
+	change2 := entity.BalanceChange{ID: "fee_out_0x5b", Address: address, BlockNumber: 11, Delta: entity.NewWeiFromInt64(-5)}
+	change3 := entity.BalanceChange{ID: "transaction_out_0x5b", Address: address, BlockNumber: 11, Delta: entity.NewWeiFromInt64(-50)}
+	change4 := entity.BalanceChange{ID: "withdrawal_in_7", Address: address, BlockNumber: 12, Delta: entity.NewWeiFromInt64(30)}
+	otherChange := entity.BalanceChange{ID: "transaction_in_0x5c", Address: "0x00000000006c3852cbef3e08e8df289169ede581", BlockNumber: 11, Delta: entity.NewWeiFromInt64(1)}
+
+	type args struct {
+		fromBlock entity.BlockNumber
+		toBlock   entity.BlockNumber
+	}
+	tests := []struct {
+		name string
+		args args
+		want []entity.BalanceChange
+	}{
+		{
+			name: "all changes",
+			args: args{
+				fromBlock: 0,
+				toBlock:   12,
+			},
+			want: []entity.BalanceChange{change1, change2, change3, change4},
+		},
+		{
+			name: "from block is excluded",
+			args: args{
+				fromBlock: 10,
+				toBlock:   11,
+			},
+			want: []entity.BalanceChange{change2, change3},
+		},
+		{
+			name: "no changes",
+			args: args{
+				fromBlock: 12,
+				toBlock:   20,
+			},
+			want: []entity.BalanceChange{},
+		},
+	}
+
+	r := NewInMemBalance()
+	for _, change := range []entity.BalanceChange{change4, change3, otherChange, change2, change1} {
+		if err := r.SaveChange(ctx, change); err != nil {
+			t.Fatalf("SaveChange() error = %v", err)
+		}
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.GetChanges(ctx, address, tt.args.fromBlock, tt.args.toBlock)
+			if err != nil {
+				t.Errorf("GetChanges() error = %v, wantErr %v", err, nil)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetChanges() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInMemBalance_DeleteByBlockNumber(t *testing.T) {
+	ctx := context.Background()
+	address := entity.Address("0xd7def8de6bff40e7fa3a19b6749aca84bd5ba0ae")
+
+	change1 := entity.BalanceChange{ID: "transaction_in_0x5a", Address: address, BlockNumber: 10, Delta: entity.NewWeiFromInt64(100)}
+	change2 := entity.BalanceChange{ID: "transaction_in_0x5b", Address: address, BlockNumber: 11, Delta: entity.NewWeiFromInt64(50)}
+	// the same change is saved again after reparsing in the other block
+	movedChange2 := entity.BalanceChange{ID: "transaction_in_0x5b", Address: address, BlockNumber: 12, Delta: entity.NewWeiFromInt64(50)}
+
+	r := NewInMemBalance()
+	for _, change := range []entity.BalanceChange{change1, change2, movedChange2} {
+		if err := r.SaveChange(ctx, change); err != nil {
+			t.Fatalf("SaveChange() error = %v", err)
+		}
+	}
+
+	if err := r.DeleteByBlockNumber(ctx, 10); err != nil {
+		t.Errorf("DeleteByBlockNumber() error = %v, wantErr %v", err, nil)
+		return
+	}
+	if err := r.DeleteByBlockNumber(ctx, 11); err != nil {
+		t.Errorf("DeleteByBlockNumber() error = %v, wantErr %v", err, nil)
+		return
+	}
+
+	got, err := r.GetChanges(ctx, address, 0, 20)
+	if err != nil {
+		t.Errorf("GetChanges() error = %v, wantErr %v", err, nil)
+		return
+	}
+
+	want := []entity.BalanceChange{movedChange2}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetChanges() got = %v, want %v", got, want)
+	}
+}
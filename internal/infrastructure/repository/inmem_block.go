@@ -8,6 +8,7 @@ import (
 	"blockchain-parser/internal/constant"
 	"blockchain-parser/internal/entity"
 	errorpkg "blockchain-parser/internal/error"
+	"blockchain-parser/tools/tracing"
 )
 
 const (
@@ -15,25 +16,32 @@ const (
 )
 
 type InMemBlock struct {
-	failedBlocks     map[int]entity.Block
-	processingBlocks map[int]entity.Block
-	parsedBlocks     map[int]entity.Block
+	failedBlocks     map[entity.BlockNumber]entity.Block
+	processingBlocks map[entity.BlockNumber]entity.Block
+	parsedBlocks     map[entity.BlockNumber]entity.Block
 
-	parsedBlockNumber     int
-	processingBlockNumber int
+	parsedBlockNumber     entity.BlockNumber
+	processingBlockNumber entity.BlockNumber
 
 	mu sync.Mutex
 }
 
 func NewInMemBlock() *InMemBlock {
 	return &InMemBlock{
-		failedBlocks:     map[int]entity.Block{},
-		processingBlocks: map[int]entity.Block{},
-		parsedBlocks:     map[int]entity.Block{},
+		failedBlocks:     map[entity.BlockNumber]entity.Block{},
+		processingBlocks: map[entity.BlockNumber]entity.Block{},
+		parsedBlocks:     map[entity.BlockNumber]entity.Block{},
 	}
 }
 
-func (r *InMemBlock) GetLastParsedBlock(_ context.Context) (entity.Block, error) {
+func (r *InMemBlock) GetLastParsedBlock(ctx context.Context) (entity.Block, error) {
+	_, span := tracing.Start(ctx, "InMemBlock.GetLastParsedBlock")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return entity.Block{}, err
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -45,7 +53,33 @@ func (r *InMemBlock) GetLastParsedBlock(_ context.Context) (entity.Block, error)
 	return block, nil
 }
 
-func (r *InMemBlock) GetLastBlock(_ context.Context) (entity.Block, error) {
+func (r *InMemBlock) GetParsedBlock(ctx context.Context, blockNumber entity.BlockNumber) (entity.Block, error) {
+	_, span := tracing.Start(ctx, "InMemBlock.GetParsedBlock")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return entity.Block{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	block, ok := r.parsedBlocks[blockNumber]
+	if !ok {
+		return entity.Block{}, errorpkg.BlockNotFound
+	}
+
+	return block, nil
+}
+
+func (r *InMemBlock) GetLastBlock(ctx context.Context) (entity.Block, error) {
+	_, span := tracing.Start(ctx, "InMemBlock.GetLastBlock")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return entity.Block{}, err
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -62,24 +96,54 @@ func (r *InMemBlock) GetLastBlock(_ context.Context) (entity.Block, error) {
 	return entity.Block{}, errorpkg.BlockNotFound
 }
 
-func (r *InMemBlock) GetFailedBlock(_ context.Context) (entity.Block, error) {
+func (r *InMemBlock) GetFailedBlock(ctx context.Context) (entity.Block, error) {
+	_, span := tracing.Start(ctx, "InMemBlock.GetFailedBlock")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return entity.Block{}, err
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	// the lowest block is returned, so parents are reparsed before their deferred children
+	var (
+		block entity.Block
+		found bool
+	)
 	for _, failedBlock := range r.failedBlocks {
-		return failedBlock, nil
+		if !found || failedBlock.Number < block.Number {
+			block, found = failedBlock, true
+		}
+	}
+	if found {
+		return block, nil
 	}
 
 	for _, processingBlock := range r.processingBlocks {
-		if time.Since(processingBlock.UpdatedAt) > processingTTL {
-			return processingBlock, nil
+		if time.Since(processingBlock.UpdatedAt) <= processingTTL {
+			continue
 		}
+		if !found || processingBlock.Number < block.Number {
+			block, found = processingBlock, true
+		}
+	}
+	if found {
+		return block, nil
 	}
 
 	return entity.Block{}, errorpkg.BlockNotFound
 }
 
-func (r *InMemBlock) Upsert(_ context.Context, block entity.Block) error {
+func (r *InMemBlock) Upsert(ctx context.Context, block entity.Block) error {
+	_, span := tracing.Start(ctx, "InMemBlock.Upsert")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -97,9 +161,21 @@ func (r *InMemBlock) Upsert(_ context.Context, block entity.Block) error {
 
 		r.failedBlocks[block.Number] = block
 
-		if r.processingBlockNumber == block.Number {
+		if r.processingBlockNumber == block.Number && block.Number > 0 {
 			r.processingBlockNumber--
 		}
+
+		// the parsed block fails on reorg, its stale hash mustn't be compared with parent hashes of next blocks
+		if _, ok := r.parsedBlocks[block.Number]; ok {
+			delete(r.parsedBlocks, block.Number)
+
+			for r.parsedBlockNumber > 0 {
+				if _, ok := r.parsedBlocks[r.parsedBlockNumber]; ok {
+					break
+				}
+				r.parsedBlockNumber--
+			}
+		}
 	case constant.BlockStatusParsed:
 		delete(r.processingBlocks, block.Number)
 
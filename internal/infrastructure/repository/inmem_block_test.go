@@ -14,8 +14,8 @@ import (
 
 func TestInMemBlock_GetLastParsedBlock(t *testing.T) {
 	type fields struct {
-		parsedBlocks      map[int]entity.Block
-		parsedBlockNumber int
+		parsedBlocks      map[entity.BlockNumber]entity.Block
+		parsedBlockNumber entity.BlockNumber
 	}
 	type args struct {
 		ctx context.Context
@@ -33,7 +33,7 @@ func TestInMemBlock_GetLastParsedBlock(t *testing.T) {
 		{
 			name: "block NOT found",
 			fields: fields{
-				parsedBlocks:      map[int]entity.Block{},
+				parsedBlocks:      map[entity.BlockNumber]entity.Block{},
 				parsedBlockNumber: 1,
 			},
 			args: args{
@@ -45,7 +45,7 @@ func TestInMemBlock_GetLastParsedBlock(t *testing.T) {
 		{
 			name: "block found",
 			fields: fields{
-				parsedBlocks: map[int]entity.Block{
+				parsedBlocks: map[entity.BlockNumber]entity.Block{
 					1: {
 						Number: 1,
 						Status: constant.BlockStatusParsed,
@@ -83,10 +83,10 @@ func TestInMemBlock_GetLastParsedBlock(t *testing.T) {
 
 func TestInMemBlock_GetLastBlock(t *testing.T) {
 	type fields struct {
-		processingBlocks      map[int]entity.Block
-		parsedBlocks          map[int]entity.Block
-		parsedBlockNumber     int
-		processingBlockNumber int
+		processingBlocks      map[entity.BlockNumber]entity.Block
+		parsedBlocks          map[entity.BlockNumber]entity.Block
+		parsedBlockNumber     entity.BlockNumber
+		processingBlockNumber entity.BlockNumber
 	}
 	type args struct {
 		ctx context.Context
@@ -104,8 +104,8 @@ func TestInMemBlock_GetLastBlock(t *testing.T) {
 		{
 			name: "block NOT found",
 			fields: fields{
-				parsedBlocks:          map[int]entity.Block{},
-				processingBlocks:      map[int]entity.Block{},
+				parsedBlocks:          map[entity.BlockNumber]entity.Block{},
+				processingBlocks:      map[entity.BlockNumber]entity.Block{},
 				parsedBlockNumber:     1,
 				processingBlockNumber: 2,
 			},
@@ -118,8 +118,8 @@ func TestInMemBlock_GetLastBlock(t *testing.T) {
 		{
 			name: "return processing block",
 			fields: fields{
-				parsedBlocks: map[int]entity.Block{},
-				processingBlocks: map[int]entity.Block{
+				parsedBlocks: map[entity.BlockNumber]entity.Block{},
+				processingBlocks: map[entity.BlockNumber]entity.Block{
 					2: {
 						Number: 2,
 						Status: constant.BlockStatusProcessing,
@@ -140,13 +140,13 @@ func TestInMemBlock_GetLastBlock(t *testing.T) {
 		{
 			name: "return parsed block",
 			fields: fields{
-				parsedBlocks: map[int]entity.Block{
+				parsedBlocks: map[entity.BlockNumber]entity.Block{
 					1: {
 						Number: 1,
 						Status: constant.BlockStatusParsed,
 					},
 				},
-				processingBlocks:      map[int]entity.Block{},
+				processingBlocks:      map[entity.BlockNumber]entity.Block{},
 				parsedBlockNumber:     1,
 				processingBlockNumber: 2,
 			},
@@ -162,13 +162,13 @@ func TestInMemBlock_GetLastBlock(t *testing.T) {
 		{
 			name: "return last parsed block, there is processing block though",
 			fields: fields{
-				parsedBlocks: map[int]entity.Block{
+				parsedBlocks: map[entity.BlockNumber]entity.Block{
 					2: {
 						Number: 2,
 						Status: constant.BlockStatusParsed,
 					},
 				},
-				processingBlocks: map[int]entity.Block{
+				processingBlocks: map[entity.BlockNumber]entity.Block{
 					1: {
 						Number: 1,
 						Status: constant.BlockStatusProcessing,
@@ -209,8 +209,8 @@ func TestInMemBlock_GetLastBlock(t *testing.T) {
 
 func TestInMemBlock_GetFailedBlock(t *testing.T) {
 	type fields struct {
-		failedBlocks     map[int]entity.Block
-		processingBlocks map[int]entity.Block
+		failedBlocks     map[entity.BlockNumber]entity.Block
+		processingBlocks map[entity.BlockNumber]entity.Block
 	}
 
 	ctx := context.Background()
@@ -229,8 +229,8 @@ func TestInMemBlock_GetFailedBlock(t *testing.T) {
 		{
 			name: "block NOT found (processing block is NOT expired)",
 			fields: fields{
-				failedBlocks: map[int]entity.Block{},
-				processingBlocks: map[int]entity.Block{
+				failedBlocks: map[entity.BlockNumber]entity.Block{},
+				processingBlocks: map[entity.BlockNumber]entity.Block{
 					2: {
 						Number:    2,
 						Status:    constant.BlockStatusProcessing,
@@ -247,8 +247,8 @@ func TestInMemBlock_GetFailedBlock(t *testing.T) {
 		{
 			name: "return processing block",
 			fields: fields{
-				failedBlocks: map[int]entity.Block{},
-				processingBlocks: map[int]entity.Block{
+				failedBlocks: map[entity.BlockNumber]entity.Block{},
+				processingBlocks: map[entity.BlockNumber]entity.Block{
 					2: {
 						Number:    2,
 						Status:    constant.BlockStatusProcessing,
@@ -269,13 +269,13 @@ func TestInMemBlock_GetFailedBlock(t *testing.T) {
 		{
 			name: "return failed block",
 			fields: fields{
-				failedBlocks: map[int]entity.Block{
+				failedBlocks: map[entity.BlockNumber]entity.Block{
 					2: {
 						Number: 2,
 						Status: constant.BlockStatusFailed,
 					},
 				},
-				processingBlocks: map[int]entity.Block{},
+				processingBlocks: map[entity.BlockNumber]entity.Block{},
 			},
 			args: args{
 				ctx: ctx,
@@ -286,6 +286,72 @@ func TestInMemBlock_GetFailedBlock(t *testing.T) {
 			},
 			wantErr: nil,
 		},
+		{
+			name: "return the lowest failed block",
+			fields: fields{
+				failedBlocks: map[entity.BlockNumber]entity.Block{
+					5: {
+						Number: 5,
+						Status: constant.BlockStatusFailed,
+					},
+					3: {
+						Number: 3,
+						Status: constant.BlockStatusFailed,
+					},
+					4: {
+						Number: 4,
+						Status: constant.BlockStatusFailed,
+					},
+				},
+				processingBlocks: map[entity.BlockNumber]entity.Block{
+					2: {
+						Number:    2,
+						Status:    constant.BlockStatusProcessing,
+						UpdatedAt: expiredUpdatedAt,
+					},
+				},
+			},
+			args: args{
+				ctx: ctx,
+			},
+			want: entity.Block{
+				Number: 3,
+				Status: constant.BlockStatusFailed,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "return the lowest expired processing block",
+			fields: fields{
+				failedBlocks: map[entity.BlockNumber]entity.Block{},
+				processingBlocks: map[entity.BlockNumber]entity.Block{
+					4: {
+						Number:    4,
+						Status:    constant.BlockStatusProcessing,
+						UpdatedAt: expiredUpdatedAt,
+					},
+					2: {
+						Number:    2,
+						Status:    constant.BlockStatusProcessing,
+						UpdatedAt: time.Now(),
+					},
+					3: {
+						Number:    3,
+						Status:    constant.BlockStatusProcessing,
+						UpdatedAt: expiredUpdatedAt,
+					},
+				},
+			},
+			args: args{
+				ctx: ctx,
+			},
+			want: entity.Block{
+				Number:    3,
+				Status:    constant.BlockStatusProcessing,
+				UpdatedAt: expiredUpdatedAt,
+			},
+			wantErr: nil,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -307,11 +373,11 @@ func TestInMemBlock_GetFailedBlock(t *testing.T) {
 
 func TestInMemBlock_Upsert(t *testing.T) {
 	type fields struct {
-		failedBlocks          map[int]entity.Block
-		processingBlocks      map[int]entity.Block
-		parsedBlocks          map[int]entity.Block
-		parsedBlockNumber     int
-		processingBlockNumber int
+		failedBlocks          map[entity.BlockNumber]entity.Block
+		processingBlocks      map[entity.BlockNumber]entity.Block
+		parsedBlocks          map[entity.BlockNumber]entity.Block
+		parsedBlockNumber     entity.BlockNumber
+		processingBlockNumber entity.BlockNumber
 	}
 
 	ctx := context.Background()
@@ -330,9 +396,9 @@ func TestInMemBlock_Upsert(t *testing.T) {
 		{
 			name: "unknown block status",
 			fields: fields{
-				failedBlocks:          map[int]entity.Block{},
-				processingBlocks:      map[int]entity.Block{},
-				parsedBlocks:          map[int]entity.Block{},
+				failedBlocks:          map[entity.BlockNumber]entity.Block{},
+				processingBlocks:      map[entity.BlockNumber]entity.Block{},
+				parsedBlocks:          map[entity.BlockNumber]entity.Block{},
 				processingBlockNumber: 2,
 			},
 			args: args{
@@ -343,9 +409,9 @@ func TestInMemBlock_Upsert(t *testing.T) {
 				},
 			},
 			want: fields{
-				failedBlocks:          map[int]entity.Block{},
-				processingBlocks:      map[int]entity.Block{},
-				parsedBlocks:          map[int]entity.Block{},
+				failedBlocks:          map[entity.BlockNumber]entity.Block{},
+				processingBlocks:      map[entity.BlockNumber]entity.Block{},
+				parsedBlocks:          map[entity.BlockNumber]entity.Block{},
 				processingBlockNumber: 2,
 			},
 			wantErr: errorpkg.UnknownBlockStatus,
@@ -353,14 +419,14 @@ func TestInMemBlock_Upsert(t *testing.T) {
 		{
 			name: "block became processing, NOT update processing block number",
 			fields: fields{
-				failedBlocks: map[int]entity.Block{
+				failedBlocks: map[entity.BlockNumber]entity.Block{
 					1: {
 						Number: 1,
 						Status: constant.BlockStatusFailed,
 					},
 				},
-				processingBlocks:      map[int]entity.Block{},
-				parsedBlocks:          map[int]entity.Block{},
+				processingBlocks:      map[entity.BlockNumber]entity.Block{},
+				parsedBlocks:          map[entity.BlockNumber]entity.Block{},
 				processingBlockNumber: 2,
 			},
 			args: args{
@@ -371,14 +437,14 @@ func TestInMemBlock_Upsert(t *testing.T) {
 				},
 			},
 			want: fields{
-				failedBlocks: map[int]entity.Block{},
-				processingBlocks: map[int]entity.Block{
+				failedBlocks: map[entity.BlockNumber]entity.Block{},
+				processingBlocks: map[entity.BlockNumber]entity.Block{
 					1: {
 						Number: 1,
 						Status: constant.BlockStatusProcessing,
 					},
 				},
-				parsedBlocks:          map[int]entity.Block{},
+				parsedBlocks:          map[entity.BlockNumber]entity.Block{},
 				processingBlockNumber: 2,
 			},
 			wantErr: nil,
@@ -386,9 +452,9 @@ func TestInMemBlock_Upsert(t *testing.T) {
 		{
 			name: "block became processing, update processing block number",
 			fields: fields{
-				failedBlocks:          map[int]entity.Block{},
-				processingBlocks:      map[int]entity.Block{},
-				parsedBlocks:          map[int]entity.Block{},
+				failedBlocks:          map[entity.BlockNumber]entity.Block{},
+				processingBlocks:      map[entity.BlockNumber]entity.Block{},
+				parsedBlocks:          map[entity.BlockNumber]entity.Block{},
 				processingBlockNumber: 1,
 			},
 			args: args{
@@ -399,14 +465,14 @@ func TestInMemBlock_Upsert(t *testing.T) {
 				},
 			},
 			want: fields{
-				failedBlocks: map[int]entity.Block{},
-				processingBlocks: map[int]entity.Block{
+				failedBlocks: map[entity.BlockNumber]entity.Block{},
+				processingBlocks: map[entity.BlockNumber]entity.Block{
 					2: {
 						Number: 2,
 						Status: constant.BlockStatusProcessing,
 					},
 				},
-				parsedBlocks:          map[int]entity.Block{},
+				parsedBlocks:          map[entity.BlockNumber]entity.Block{},
 				processingBlockNumber: 2,
 			},
 			wantErr: nil,
@@ -414,14 +480,14 @@ func TestInMemBlock_Upsert(t *testing.T) {
 		{
 			name: "block became parsed, NOT update parsed block number",
 			fields: fields{
-				failedBlocks: map[int]entity.Block{},
-				processingBlocks: map[int]entity.Block{
+				failedBlocks: map[entity.BlockNumber]entity.Block{},
+				processingBlocks: map[entity.BlockNumber]entity.Block{
 					1: {
 						Number: 1,
 						Status: constant.BlockStatusFailed,
 					},
 				},
-				parsedBlocks:      map[int]entity.Block{},
+				parsedBlocks:      map[entity.BlockNumber]entity.Block{},
 				parsedBlockNumber: 2,
 			},
 			args: args{
@@ -432,9 +498,9 @@ func TestInMemBlock_Upsert(t *testing.T) {
 				},
 			},
 			want: fields{
-				failedBlocks:     map[int]entity.Block{},
-				processingBlocks: map[int]entity.Block{},
-				parsedBlocks: map[int]entity.Block{
+				failedBlocks:     map[entity.BlockNumber]entity.Block{},
+				processingBlocks: map[entity.BlockNumber]entity.Block{},
+				parsedBlocks: map[entity.BlockNumber]entity.Block{
 					1: {
 						Number: 1,
 						Status: constant.BlockStatusParsed,
@@ -447,9 +513,9 @@ func TestInMemBlock_Upsert(t *testing.T) {
 		{
 			name: "block became parsed, update parsed block number",
 			fields: fields{
-				failedBlocks:      map[int]entity.Block{},
-				processingBlocks:  map[int]entity.Block{},
-				parsedBlocks:      map[int]entity.Block{},
+				failedBlocks:      map[entity.BlockNumber]entity.Block{},
+				processingBlocks:  map[entity.BlockNumber]entity.Block{},
+				parsedBlocks:      map[entity.BlockNumber]entity.Block{},
 				parsedBlockNumber: 1,
 			},
 			args: args{
@@ -460,9 +526,9 @@ func TestInMemBlock_Upsert(t *testing.T) {
 				},
 			},
 			want: fields{
-				failedBlocks:     map[int]entity.Block{},
-				processingBlocks: map[int]entity.Block{},
-				parsedBlocks: map[int]entity.Block{
+				failedBlocks:     map[entity.BlockNumber]entity.Block{},
+				processingBlocks: map[entity.BlockNumber]entity.Block{},
+				parsedBlocks: map[entity.BlockNumber]entity.Block{
 					2: {
 						Number: 2,
 						Status: constant.BlockStatusParsed,
@@ -475,14 +541,14 @@ func TestInMemBlock_Upsert(t *testing.T) {
 		{
 			name: "block became failed, NOT update processing block number",
 			fields: fields{
-				failedBlocks: map[int]entity.Block{},
-				processingBlocks: map[int]entity.Block{
+				failedBlocks: map[entity.BlockNumber]entity.Block{},
+				processingBlocks: map[entity.BlockNumber]entity.Block{
 					1: {
 						Number: 1,
 						Status: constant.BlockStatusProcessing,
 					},
 				},
-				parsedBlocks:          map[int]entity.Block{},
+				parsedBlocks:          map[entity.BlockNumber]entity.Block{},
 				processingBlockNumber: 2,
 			},
 			args: args{
@@ -493,14 +559,14 @@ func TestInMemBlock_Upsert(t *testing.T) {
 				},
 			},
 			want: fields{
-				failedBlocks: map[int]entity.Block{
+				failedBlocks: map[entity.BlockNumber]entity.Block{
 					1: {
 						Number: 1,
 						Status: constant.BlockStatusFailed,
 					},
 				},
-				processingBlocks:      map[int]entity.Block{},
-				parsedBlocks:          map[int]entity.Block{},
+				processingBlocks:      map[entity.BlockNumber]entity.Block{},
+				parsedBlocks:          map[entity.BlockNumber]entity.Block{},
 				processingBlockNumber: 2,
 			},
 			wantErr: nil,
@@ -508,14 +574,14 @@ func TestInMemBlock_Upsert(t *testing.T) {
 		{
 			name: "block became failed, update processing block number",
 			fields: fields{
-				failedBlocks: map[int]entity.Block{},
-				processingBlocks: map[int]entity.Block{
+				failedBlocks: map[entity.BlockNumber]entity.Block{},
+				processingBlocks: map[entity.BlockNumber]entity.Block{
 					2: {
 						Number: 2,
 						Status: constant.BlockStatusProcessing,
 					},
 				},
-				parsedBlocks:          map[int]entity.Block{},
+				parsedBlocks:          map[entity.BlockNumber]entity.Block{},
 				processingBlockNumber: 2,
 			},
 			args: args{
@@ -526,18 +592,67 @@ func TestInMemBlock_Upsert(t *testing.T) {
 				},
 			},
 			want: fields{
-				failedBlocks: map[int]entity.Block{
+				failedBlocks: map[entity.BlockNumber]entity.Block{
 					2: {
 						Number: 2,
 						Status: constant.BlockStatusFailed,
 					},
 				},
-				processingBlocks:      map[int]entity.Block{},
-				parsedBlocks:          map[int]entity.Block{},
+				processingBlocks:      map[entity.BlockNumber]entity.Block{},
+				parsedBlocks:          map[entity.BlockNumber]entity.Block{},
 				processingBlockNumber: 1,
 			},
 			wantErr: nil,
 		},
+		{
+			name: "parsed block became failed on reorg",
+			fields: fields{
+				failedBlocks:     map[entity.BlockNumber]entity.Block{},
+				processingBlocks: map[entity.BlockNumber]entity.Block{},
+				parsedBlocks: map[entity.BlockNumber]entity.Block{
+					1: {
+						Number: 1,
+						Hash:   "0x1",
+						Status: constant.BlockStatusParsed,
+					},
+					2: {
+						Number: 2,
+						Hash:   "0x2",
+						Status: constant.BlockStatusParsed,
+					},
+				},
+				parsedBlockNumber:     2,
+				processingBlockNumber: 3,
+			},
+			args: args{
+				ctx: ctx,
+				block: entity.Block{
+					Number: 2,
+					Hash:   "0x2",
+					Status: constant.BlockStatusFailed,
+				},
+			},
+			want: fields{
+				failedBlocks: map[entity.BlockNumber]entity.Block{
+					2: {
+						Number: 2,
+						Hash:   "0x2",
+						Status: constant.BlockStatusFailed,
+					},
+				},
+				processingBlocks: map[entity.BlockNumber]entity.Block{},
+				parsedBlocks: map[entity.BlockNumber]entity.Block{
+					1: {
+						Number: 1,
+						Hash:   "0x1",
+						Status: constant.BlockStatusParsed,
+					},
+				},
+				parsedBlockNumber:     1,
+				processingBlockNumber: 3,
+			},
+			wantErr: nil,
+		},
 	}
 
 	for _, tt := range tests {
@@ -571,3 +686,73 @@ func TestInMemBlock_Upsert(t *testing.T) {
 		})
 	}
 }
+
+func TestInMemBlock_GetParsedBlock(t *testing.T) {
+	type fields struct {
+		parsedBlocks map[entity.BlockNumber]entity.Block
+	}
+	type args struct {
+		ctx         context.Context
+		blockNumber entity.BlockNumber
+	}
+
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    entity.Block
+		wantErr error
+	}{
+		{
+			name: "block NOT found",
+			fields: fields{
+				parsedBlocks: map[entity.BlockNumber]entity.Block{},
+			},
+			args: args{
+				ctx:         ctx,
+				blockNumber: 1,
+			},
+			want:    entity.Block{},
+			wantErr: errorpkg.BlockNotFound,
+		},
+		{
+			name: "block found",
+			fields: fields{
+				parsedBlocks: map[entity.BlockNumber]entity.Block{
+					1: {
+						Number: 1,
+						Hash:   "0x1f",
+						Status: constant.BlockStatusParsed,
+					},
+				},
+			},
+			args: args{
+				ctx:         ctx,
+				blockNumber: 1,
+			},
+			want: entity.Block{
+				Number: 1,
+				Hash:   "0x1f",
+				Status: constant.BlockStatusParsed,
+			},
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := InMemBlock{
+				parsedBlocks: tt.fields.parsedBlocks,
+			}
+			got, err := r.GetParsedBlock(tt.args.ctx, tt.args.blockNumber)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetParsedBlock() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetParsedBlock() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
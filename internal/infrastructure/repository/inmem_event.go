@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"blockchain-parser/internal/entity"
+	"blockchain-parser/tools/tracing"
+)
+
+// InMemEvent is a bounded event log. Only the last retention events are kept,
+// readers resuming from an older cursor continue from the oldest retained one.
+// Older events are trimmed in batches, so saving an event doesn't copy the whole log.
+type InMemEvent struct {
+	events    []entity.Event
+	lastID    uint64
+	retention int
+	notify    chan struct{}
+
+	mu sync.RWMutex
+}
+
+func NewInMemEvent(retention int) *InMemEvent {
+	return &InMemEvent{
+		events:    make([]entity.Event, 0, 2*retention),
+		retention: retention,
+		notify:    make(chan struct{}),
+	}
+}
+
+func (r *InMemEvent) Save(ctx context.Context, event entity.Event) error {
+	_, span := tracing.Start(ctx, "InMemEvent.Save")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	event.Addresses = canonicalAddresses(event.Addresses)
+
+	r.lastID++
+	event.ID = r.lastID
+	event.CreatedAt = time.Now()
+
+	// the log grows up to twice the retention, then the retained events are moved to its beginning
+	if len(r.events) == 2*r.retention {
+		n := copy(r.events, r.events[len(r.events)-r.retention:])
+		r.events = r.events[:n]
+	}
+	r.events = append(r.events, event)
+
+	close(r.notify)
+	r.notify = make(chan struct{})
+
+	return nil
+}
+
+// GetEventsAfter returns up to limit events with ID greater than afterID which relate to
+// one of the addresses. The second value is the cursor to continue from,
+// it moves past filtered out events as well.
+func (r *InMemEvent) GetEventsAfter(
+	ctx context.Context,
+	afterID uint64,
+	addresses []entity.Address,
+	limit int,
+) ([]entity.Event, uint64, error) {
+	_, span := tracing.Start(ctx, "InMemEvent.GetEventsAfter")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	cursor := afterID
+	events := make([]entity.Event, 0)
+
+	for _, event := range r.retained() {
+		if event.ID <= afterID {
+			continue
+		}
+		if len(events) == limit {
+			break
+		}
+
+		cursor = event.ID
+
+		if matchAddresses(event.Addresses, canonicalAddresses(addresses)) {
+			events = append(events, event)
+		}
+	}
+
+	return events, cursor, nil
+}
+
+// WaitEvents blocks until an event with ID greater than afterID is saved or ctx is done.
+func (r *InMemEvent) WaitEvents(ctx context.Context, afterID uint64) error {
+	for {
+		r.mu.RLock()
+		lastID := r.lastID
+		notify := r.notify
+		r.mu.RUnlock()
+
+		if lastID > afterID {
+			return nil
+		}
+
+		select {
+		case <-notify:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// retained returns the last retention events, older ones are kept until the next trim
+func (r *InMemEvent) retained() []entity.Event {
+	if len(r.events) > r.retention {
+		return r.events[len(r.events)-r.retention:]
+	}
+
+	return r.events
+}
+
+func matchAddresses(eventAddresses, addresses []entity.Address) bool {
+	for _, eventAddress := range eventAddresses {
+		for _, address := range addresses {
+			if eventAddress == address {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func canonicalAddresses(addresses []entity.Address) []entity.Address {
+	canonical := make([]entity.Address, 0, len(addresses))
+	for _, address := range addresses {
+		canonical = append(canonical, address.Canonical())
+	}
+
+	return canonical
+}
@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"blockchain-parser/internal/constant"
+	"blockchain-parser/internal/entity"
+)
+
+func TestInMemEvent_Save(t *testing.T) {
+	ctx := context.Background()
+
+	r := NewInMemEvent(2)
+	for i := 0; i < 5; i++ {
+		event := entity.Event{
+			Type:      constant.EventTypeTransaction,
+			Addresses: []entity.Address{"0x42352", "0x245212"},
+		}
+		if err := r.Save(ctx, event); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+
+	if r.lastID != 5 {
+		t.Errorf("lastID got = %v, want %v", r.lastID, 5)
+	}
+
+	gotIDs := make([]uint64, 0, len(r.events))
+	for _, event := range r.retained() {
+		gotIDs = append(gotIDs, event.ID)
+	}
+	if want := []uint64{4, 5}; !reflect.DeepEqual(gotIDs, want) {
+		t.Errorf("retained events got = %v, want %v", gotIDs, want)
+	}
+
+	// older events are trimmed once the log reaches twice the retention
+	if len(r.events) > 4 {
+		t.Errorf("len(events) got = %v, want at most %v", len(r.events), 4)
+	}
+}
+
+func TestInMemEvent_GetEventsAfter(t *testing.T) {
+	ctx := context.Background()
+
+	events := []entity.Event{
+		{ID: 1, Type: constant.EventTypeTransaction, Addresses: []entity.Address{"0x1", "0x2"}},
+		{ID: 2, Type: constant.EventTypeTransaction, Addresses: []entity.Address{"0x3", "0x4"}},
+		{ID: 3, Type: constant.EventTypeConfirmation, Addresses: []entity.Address{"0x1", "0x2"}},
+		{ID: 4, Type: constant.EventTypeTransaction, Addresses: []entity.Address{"0x3", "0x4"}},
+	}
+
+	type args struct {
+		afterID   uint64
+		addresses []entity.Address
+		limit     int
+	}
+	tests := []struct {
+		name       string
+		args       args
+		want       []entity.Event
+		wantCursor uint64
+	}{
+		{
+			name: "filter by address",
+			args: args{
+				afterID:   0,
+				addresses: []entity.Address{"0x2"},
+				limit:     10,
+			},
+			want:       []entity.Event{events[0], events[2]},
+			wantCursor: 4,
+		},
+		{
+			name: "resume after cursor",
+			args: args{
+				afterID:   1,
+				addresses: []entity.Address{"0x2", "0x3"},
+				limit:     10,
+			},
+			want:       []entity.Event{events[1], events[2], events[3]},
+			wantCursor: 4,
+		},
+		{
+			name: "limit events",
+			args: args{
+				afterID:   0,
+				addresses: []entity.Address{"0x1"},
+				limit:     1,
+			},
+			want:       []entity.Event{events[0]},
+			wantCursor: 1,
+		},
+		{
+			name: "no matched events",
+			args: args{
+				afterID:   0,
+				addresses: []entity.Address{"0x5"},
+				limit:     10,
+			},
+			want:       []entity.Event{},
+			wantCursor: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &InMemEvent{
+				events:    events,
+				lastID:    4,
+				retention: len(events),
+			}
+			got, cursor, err := r.GetEventsAfter(ctx, tt.args.afterID, tt.args.addresses, tt.args.limit)
+			if err != nil {
+				t.Errorf("GetEventsAfter() error = %v, wantErr %v", err, nil)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetEventsAfter() got = %v, want %v", got, tt.want)
+			}
+			if cursor != tt.wantCursor {
+				t.Errorf("GetEventsAfter() cursor = %v, want %v", cursor, tt.wantCursor)
+			}
+		})
+	}
+}
+
+func TestInMemEvent_WaitEvents(t *testing.T) {
+	t.Run("wait new event", func(tt *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		r := NewInMemEvent(10)
+
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			_ = r.Save(ctx, entity.Event{Type: constant.EventTypeTransaction})
+		}()
+
+		if err := r.WaitEvents(ctx, 0); err != nil {
+			tt.Errorf("WaitEvents() error = %v, wantErr %v", err, nil)
+		}
+	})
+
+	t.Run("context done", func(tt *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		r := NewInMemEvent(10)
+		if err := r.WaitEvents(ctx, 0); !errors.Is(err, context.DeadlineExceeded) {
+			tt.Errorf("WaitEvents() error = %v, wantErr %v", err, context.DeadlineExceeded)
+		}
+	})
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"blockchain-parser/internal/entity"
+	errorpkg "blockchain-parser/internal/error"
+	"blockchain-parser/tools/tracing"
+)
+
+type InMemReparseTask struct {
+	data map[string]entity.ReparseTask
+	mu   sync.RWMutex
+}
+
+func NewInMemReparseTask() *InMemReparseTask {
+	return &InMemReparseTask{
+		data: map[string]entity.ReparseTask{},
+	}
+}
+
+func (r *InMemReparseTask) Save(ctx context.Context, task entity.ReparseTask) error {
+	_, span := tracing.Start(ctx, "InMemReparseTask.Save")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	task.Addresses = canonicalAddresses(task.Addresses)
+	r.data[task.ID] = task
+
+	return nil
+}
+
+func (r *InMemReparseTask) Get(ctx context.Context, id string) (entity.ReparseTask, error) {
+	_, span := tracing.Start(ctx, "InMemReparseTask.Get")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return entity.ReparseTask{}, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	task, ok := r.data[id]
+	if !ok {
+		return entity.ReparseTask{}, errorpkg.ReparseTaskNotFound
+	}
+
+	task.Addresses = append([]entity.Address(nil), task.Addresses...)
+
+	return task, nil
+}
+
+// GetAll returns tasks ordered by creation time
+func (r *InMemReparseTask) GetAll(ctx context.Context) ([]entity.ReparseTask, error) {
+	_, span := tracing.Start(ctx, "InMemReparseTask.GetAll")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tasks := make([]entity.ReparseTask, 0, len(r.data))
+	for _, task := range r.data {
+		task.Addresses = append([]entity.Address(nil), task.Addresses...)
+		tasks = append(tasks, task)
+	}
+
+	sort.Slice(tasks, func(i, j int) bool {
+		if tasks[i].CreatedAt.Equal(tasks[j].CreatedAt) {
+			return tasks[i].ID < tasks[j].ID
+		}
+
+		return tasks[i].CreatedAt.Before(tasks[j].CreatedAt)
+	})
+
+	return tasks, nil
+}
@@ -6,36 +6,66 @@ import (
 	"sync"
 
 	"blockchain-parser/internal/entity"
+	"blockchain-parser/tools/tracing"
 )
 
 type InMemSubscriber struct {
-	data map[string]entity.Subscriber
+	data map[entity.Address]entity.Subscriber
 	mu   sync.RWMutex
 }
 
 func NewInMemSubscriber() *InMemSubscriber {
 	return &InMemSubscriber{
-		data: map[string]entity.Subscriber{},
+		data: map[entity.Address]entity.Subscriber{},
 	}
 }
 
-func (r *InMemSubscriber) Save(_ context.Context, subscriber entity.Subscriber) error {
+func (r *InMemSubscriber) Save(ctx context.Context, subscriber entity.Subscriber) error {
+	_, span := tracing.Start(ctx, "InMemSubscriber.Save")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	subscriber.Address = subscriber.Address.Canonical()
 	r.data[subscriber.Address] = subscriber
 
 	return nil
 }
 
-func (r *InMemSubscriber) Get(_ context.Context, address string) (entity.Subscriber, error) {
+func (r *InMemSubscriber) Get(ctx context.Context, address entity.Address) (entity.Subscriber, error) {
+	_, span := tracing.Start(ctx, "InMemSubscriber.Get")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return entity.Subscriber{}, err
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	subscriber, ok := r.data[address]
+	subscriber, ok := r.data[address.Canonical()]
 	if !ok {
 		return subscriber, errorpkg.SubscriberNotFound
 	}
 
 	return subscriber, nil
 }
+
+func (r *InMemSubscriber) Count(ctx context.Context) (int, error) {
+	_, span := tracing.Start(ctx, "InMemSubscriber.Count")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return len(r.data), nil
+}
@@ -13,6 +13,9 @@ import (
 func TestInMemSubscriber_Save(t *testing.T) {
 	ctx := context.Background()
 
+	canceledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+
 	type args struct {
 		ctx        context.Context
 		subscriber entity.Subscriber
@@ -20,7 +23,7 @@ func TestInMemSubscriber_Save(t *testing.T) {
 	tests := []struct {
 		name    string
 		args    args
-		want    map[string]entity.Subscriber
+		want    map[entity.Address]entity.Subscriber
 		wantErr error
 	}{
 		{
@@ -31,13 +34,24 @@ func TestInMemSubscriber_Save(t *testing.T) {
 					Address: "0x41da31",
 				},
 			},
-			want: map[string]entity.Subscriber{
+			want: map[entity.Address]entity.Subscriber{
 				"0x41da31": {
 					Address: "0x41da31",
 				},
 			},
 			wantErr: nil,
 		},
+		{
+			name: "canceled context",
+			args: args{
+				ctx: canceledCtx,
+				subscriber: entity.Subscriber{
+					Address: "0x41da31",
+				},
+			},
+			want:    map[entity.Address]entity.Subscriber{},
+			wantErr: context.Canceled,
+		},
 	}
 
 	for _, tt := range tests {
@@ -56,14 +70,14 @@ func TestInMemSubscriber_Save(t *testing.T) {
 
 func TestInMemSubscriber_Get(t *testing.T) {
 	type fields struct {
-		data map[string]entity.Subscriber
+		data map[entity.Address]entity.Subscriber
 	}
 
 	ctx := context.Background()
 
 	type args struct {
 		ctx     context.Context
-		address string
+		address entity.Address
 	}
 	tests := []struct {
 		name    string
@@ -75,7 +89,7 @@ func TestInMemSubscriber_Get(t *testing.T) {
 		{
 			name: "subscriber not found",
 			fields: fields{
-				data: map[string]entity.Subscriber{},
+				data: map[entity.Address]entity.Subscriber{},
 			},
 			args: args{
 				ctx:     ctx,
@@ -87,7 +101,7 @@ func TestInMemSubscriber_Get(t *testing.T) {
 		{
 			name: "return subscriber",
 			fields: fields{
-				data: map[string]entity.Subscriber{
+				data: map[entity.Address]entity.Subscriber{
 					"0x41da31": {
 						Address: "0x41da31",
 					},
@@ -100,6 +114,22 @@ func TestInMemSubscriber_Get(t *testing.T) {
 			want:    entity.Subscriber{Address: "0x41da31"},
 			wantErr: nil,
 		},
+		{
+			name: "return subscriber by mixed case address",
+			fields: fields{
+				data: map[entity.Address]entity.Subscriber{
+					"0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed": {
+						Address: "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed",
+					},
+				},
+			},
+			args: args{
+				ctx:     ctx,
+				address: "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+			},
+			want:    entity.Subscriber{Address: "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"},
+			wantErr: nil,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"blockchain-parser/internal/entity"
+	errorpkg "blockchain-parser/internal/error"
+	"blockchain-parser/tools/tracing"
+)
+
+// InMemSubscription keeps addresses subscribed by every tenant.
+type InMemSubscription struct {
+	data map[string]map[entity.Address]entity.Subscription
+	mu   sync.RWMutex
+}
+
+func NewInMemSubscription() *InMemSubscription {
+	return &InMemSubscription{
+		data: map[string]map[entity.Address]entity.Subscription{},
+	}
+}
+
+// Save keeps the first subscription of the tenant to the address
+func (r *InMemSubscription) Save(ctx context.Context, subscription entity.Subscription) error {
+	_, span := tracing.Start(ctx, "InMemSubscription.Save")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	subscription.Address = subscription.Address.Canonical()
+
+	subscriptions, ok := r.data[subscription.TenantID]
+	if !ok {
+		subscriptions = map[entity.Address]entity.Subscription{}
+		r.data[subscription.TenantID] = subscriptions
+	}
+
+	if _, ok := subscriptions[subscription.Address]; !ok {
+		subscriptions[subscription.Address] = subscription
+	}
+
+	return nil
+}
+
+// SaveWithinLimit saves subscriptions if every tenant keeps at most limit subscriptions, zero limit means no limit.
+// The limit is checked under the same lock, so concurrent saves don't exceed it. Subscriptions kept already aren't
+// counted and saved again, the saved subscriptions are returned. Nothing is saved if the limit is exceeded.
+func (r *InMemSubscription) SaveWithinLimit(ctx context.Context, subscriptions []entity.Subscription, limit int) ([]entity.Subscription, error) {
+	_, span := tracing.Start(ctx, "InMemSubscription.SaveWithinLimit")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	newSubscriptions := make([]entity.Subscription, 0, len(subscriptions))
+	newByTenant := map[string]map[entity.Address]struct{}{}
+	for _, subscription := range subscriptions {
+		subscription.Address = subscription.Address.Canonical()
+
+		if _, ok := r.data[subscription.TenantID][subscription.Address]; ok {
+			continue
+		}
+		if _, ok := newByTenant[subscription.TenantID][subscription.Address]; ok {
+			continue
+		}
+
+		if _, ok := newByTenant[subscription.TenantID]; !ok {
+			newByTenant[subscription.TenantID] = map[entity.Address]struct{}{}
+		}
+		newByTenant[subscription.TenantID][subscription.Address] = struct{}{}
+		newSubscriptions = append(newSubscriptions, subscription)
+	}
+
+	if limit > 0 {
+		for tenantID, addresses := range newByTenant {
+			count := len(r.data[tenantID])
+			if count+len(addresses) > limit {
+				return nil, fmt.Errorf("tenant (%s) has %d of %d subscriptions: %w", tenantID, count, limit, errorpkg.SubscriptionLimit)
+			}
+		}
+	}
+
+	for _, subscription := range newSubscriptions {
+		if _, ok := r.data[subscription.TenantID]; !ok {
+			r.data[subscription.TenantID] = map[entity.Address]entity.Subscription{}
+		}
+		r.data[subscription.TenantID][subscription.Address] = subscription
+	}
+
+	return newSubscriptions, nil
+}
+
+func (r *InMemSubscription) Get(ctx context.Context, tenantID string, address entity.Address) (entity.Subscription, error) {
+	_, span := tracing.Start(ctx, "InMemSubscription.Get")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return entity.Subscription{}, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	subscription, ok := r.data[tenantID][address.Canonical()]
+	if !ok {
+		return entity.Subscription{}, errorpkg.SubscriberNotFound
+	}
+
+	return subscription, nil
+}
+
+func (r *InMemSubscription) CountByTenant(ctx context.Context, tenantID string) (int, error) {
+	_, span := tracing.Start(ctx, "InMemSubscription.CountByTenant")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return len(r.data[tenantID]), nil
+}
+
+// Delete removes the subscription of the tenant, SubscriberNotFound is returned if the tenant isn't subscribed
+func (r *InMemSubscription) Delete(ctx context.Context, tenantID string, address entity.Address) error {
+	_, span := tracing.Start(ctx, "InMemSubscription.Delete")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	address = address.Canonical()
+	if _, ok := r.data[tenantID][address]; !ok {
+		return errorpkg.SubscriberNotFound
+	}
+	delete(r.data[tenantID], address)
+
+	return nil
+}
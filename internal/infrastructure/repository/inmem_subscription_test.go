@@ -0,0 +1,220 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"blockchain-parser/internal/entity"
+	errorpkg "blockchain-parser/internal/error"
+)
+
+func TestInMemSubscription_Get(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	subscription := entity.Subscription{
+		TenantID:  "t1",
+		Address:   "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed",
+		CreatedAt: now,
+	}
+
+	r := NewInMemSubscription()
+	for _, s := range []entity.Subscription{subscription, {TenantID: "t1", Address: subscription.Address, CreatedAt: now.Add(time.Second)}} {
+		if err := r.Save(ctx, s); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+
+	type args struct {
+		tenantID string
+		address  entity.Address
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    entity.Subscription
+		wantErr error
+	}{
+		{
+			name:    "first subscription is kept",
+			args:    args{tenantID: "t1", address: subscription.Address},
+			want:    subscription,
+			wantErr: nil,
+		},
+		{
+			name:    "subscription by mixed case address",
+			args:    args{tenantID: "t1", address: "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"},
+			want:    subscription,
+			wantErr: nil,
+		},
+		{
+			name:    "address is subscribed by another tenant",
+			args:    args{tenantID: "t2", address: subscription.Address},
+			want:    entity.Subscription{},
+			wantErr: errorpkg.SubscriberNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.Get(ctx, tt.args.tenantID, tt.args.address)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Get() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Get() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInMemSubscription_CountByTenant(t *testing.T) {
+	ctx := context.Background()
+
+	r := NewInMemSubscription()
+	for _, s := range []entity.Subscription{
+		{TenantID: "t1", Address: "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"},
+		{TenantID: "t1", Address: "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"},
+		{TenantID: "t1", Address: "0xfb6916095ca1df60bb79ce92ce3ea74c37c5d359"},
+		{TenantID: "t2", Address: "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"},
+	} {
+		if err := r.Save(ctx, s); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		tenantID string
+		want     int
+	}{
+		{
+			name:     "same address is counted once",
+			tenantID: "t1",
+			want:     2,
+		},
+		{
+			name:     "tenant without subscriptions",
+			tenantID: "t3",
+			want:     0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.CountByTenant(ctx, tt.tenantID)
+			if err != nil {
+				t.Errorf("CountByTenant() error = %v, wantErr %v", err, nil)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("CountByTenant() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInMemSubscription_SaveWithinLimit(t *testing.T) {
+	subscribed := entity.Subscription{TenantID: "t1", Address: "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"}
+	newSubscription := entity.Subscription{TenantID: "t1", Address: "0xfb6916095ca1df60bb79ce92ce3ea74c37c5d359"}
+	otherSubscription := entity.Subscription{TenantID: "t1", Address: "0xdbf03b407c01e7cd3cbea99509d93f8dddc8c6fb"}
+
+	tests := []struct {
+		name          string
+		subscriptions []entity.Subscription
+		limit         int
+		want          []entity.Subscription
+		wantCount     int
+		wantErr       error
+	}{
+		{
+			name:          "subscriptions within the limit are saved",
+			subscriptions: []entity.Subscription{newSubscription, otherSubscription},
+			limit:         3,
+			want:          []entity.Subscription{newSubscription, otherSubscription},
+			wantCount:     3,
+			wantErr:       nil,
+		},
+		{
+			name:          "subscribed address isn't counted",
+			subscriptions: []entity.Subscription{{TenantID: "t1", Address: "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"}, newSubscription},
+			limit:         2,
+			want:          []entity.Subscription{newSubscription},
+			wantCount:     2,
+			wantErr:       nil,
+		},
+		{
+			name:          "nothing is saved over the limit",
+			subscriptions: []entity.Subscription{newSubscription, otherSubscription},
+			limit:         2,
+			want:          nil,
+			wantCount:     1,
+			wantErr:       errorpkg.SubscriptionLimit,
+		},
+		{
+			name:          "zero limit",
+			subscriptions: []entity.Subscription{newSubscription, otherSubscription},
+			limit:         0,
+			want:          []entity.Subscription{newSubscription, otherSubscription},
+			wantCount:     3,
+			wantErr:       nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			r := NewInMemSubscription()
+			if err := r.Save(ctx, subscribed); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+
+			got, err := r.SaveWithinLimit(ctx, tt.subscriptions, tt.limit)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("SaveWithinLimit() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SaveWithinLimit() got = %v, want %v", got, tt.want)
+			}
+
+			count, err := r.CountByTenant(ctx, "t1")
+			if err != nil {
+				t.Fatalf("CountByTenant() error = %v", err)
+			}
+			if count != tt.wantCount {
+				t.Errorf("CountByTenant() got = %v, want %v", count, tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestInMemSubscription_Delete(t *testing.T) {
+	ctx := context.Background()
+	address := entity.Address("0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed")
+
+	r := NewInMemSubscription()
+	for _, s := range []entity.Subscription{{TenantID: "t1", Address: address}, {TenantID: "t2", Address: address}} {
+		if err := r.Save(ctx, s); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+
+	if err := r.Delete(ctx, "t1", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"); err != nil {
+		t.Fatalf("Delete() error = %v, wantErr %v", err, nil)
+	}
+	if err := r.Delete(ctx, "t1", address); !errors.Is(err, errorpkg.SubscriberNotFound) {
+		t.Errorf("Delete() of deleted subscription error = %v, wantErr %v", err, errorpkg.SubscriberNotFound)
+	}
+	if _, err := r.Get(ctx, "t1", address); !errors.Is(err, errorpkg.SubscriberNotFound) {
+		t.Errorf("Get() of deleted subscription error = %v, wantErr %v", err, errorpkg.SubscriberNotFound)
+	}
+	if _, err := r.Get(ctx, "t2", address); err != nil {
+		t.Errorf("Get() of subscription of another tenant error = %v, wantErr %v", err, nil)
+	}
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"blockchain-parser/internal/entity"
+	errorpkg "blockchain-parser/internal/error"
+	"blockchain-parser/tools/tracing"
+)
+
+type InMemTenant struct {
+	data map[string]entity.Tenant
+	mu   sync.RWMutex
+}
+
+func NewInMemTenant() *InMemTenant {
+	return &InMemTenant{
+		data: map[string]entity.Tenant{},
+	}
+}
+
+func (r *InMemTenant) Save(ctx context.Context, tenant entity.Tenant) error {
+	_, span := tracing.Start(ctx, "InMemTenant.Save")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.data[tenant.ID] = tenant
+
+	return nil
+}
+
+func (r *InMemTenant) Get(ctx context.Context, id string) (entity.Tenant, error) {
+	_, span := tracing.Start(ctx, "InMemTenant.Get")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return entity.Tenant{}, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tenant, ok := r.data[id]
+	if !ok {
+		return entity.Tenant{}, errorpkg.TenantNotFound
+	}
+
+	return tenant, nil
+}
+
+// GetAll returns tenants ordered by creation time
+func (r *InMemTenant) GetAll(ctx context.Context) ([]entity.Tenant, error) {
+	_, span := tracing.Start(ctx, "InMemTenant.GetAll")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tenants := make([]entity.Tenant, 0, len(r.data))
+	for _, tenant := range r.data {
+		tenants = append(tenants, tenant)
+	}
+
+	sort.Slice(tenants, func(i, j int) bool {
+		if tenants[i].CreatedAt.Equal(tenants[j].CreatedAt) {
+			return tenants[i].ID < tenants[j].ID
+		}
+
+		return tenants[i].CreatedAt.Before(tenants[j].CreatedAt)
+	})
+
+	return tenants, nil
+}
@@ -3,44 +3,175 @@ package repository
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 
+	"blockchain-parser/internal/constant"
 	"blockchain-parser/internal/entity"
+	errorpkg "blockchain-parser/internal/error"
+	"blockchain-parser/tools/tracing"
 )
 
+// InMemTransaction keeps transactions of every address sorted by (block number, transaction index)
+// to serve range scans without sorting on read.
 type InMemTransaction struct {
-	data map[string]map[string]*entity.Transaction
-	mu   sync.RWMutex
+	data   map[entity.Address][]*entity.Transaction
+	blocks map[entity.BlockNumber]map[string]*entity.Transaction
+	hashes map[string]*entity.Transaction
+	mu     sync.RWMutex
 }
 
 func NewInMemTransaction() *InMemTransaction {
 	return &InMemTransaction{
-		data: map[string]map[string]*entity.Transaction{},
+		data:   map[entity.Address][]*entity.Transaction{},
+		blocks: map[entity.BlockNumber]map[string]*entity.Transaction{},
+		hashes: map[string]*entity.Transaction{},
 	}
 }
 
-func (r *InMemTransaction) Save(_ context.Context, transaction entity.Transaction) error {
+func (r *InMemTransaction) Save(ctx context.Context, transaction entity.Transaction) error {
+	_, span := tracing.Start(ctx, "InMemTransaction.Save")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if _, ok := r.data[transaction.To]; !ok {
-		r.data[transaction.To] = make(map[string]*entity.Transaction)
+	if _, ok := r.blocks[transaction.BlockNumber]; !ok {
+		r.blocks[transaction.BlockNumber] = make(map[string]*entity.Transaction)
 	}
-	if _, ok := r.data[transaction.From]; !ok {
-		r.data[transaction.From] = make(map[string]*entity.Transaction)
+
+	txnID := transactionID(transaction)
+	if prevTxn, ok := r.blocks[transaction.BlockNumber][txnID]; ok && prevTxn.Hash != "" {
+		delete(r.hashes, prevTxn.Hash)
 	}
 
-	txnID := fmt.Sprintf("%d_%d", transaction.BlockNumber, transaction.TransactionIndex)
-	r.data[transaction.To][txnID] = &transaction
-	r.data[transaction.From][txnID] = &transaction
+	transaction.From = transaction.From.Canonical()
+	transaction.To = transaction.To.Canonical()
+
+	// withdrawals have no sender and contract creations have no recipient
+	if transaction.To != "" {
+		r.insert(transaction.To, &transaction)
+	}
+	if transaction.From != "" && transaction.From != transaction.To {
+		r.insert(transaction.From, &transaction)
+	}
+	r.blocks[transaction.BlockNumber][txnID] = &transaction
+	// withdrawals have no hash
+	if transaction.Hash != "" {
+		r.hashes[transaction.Hash] = &transaction
+	}
 
 	return nil
 }
 
-func (r *InMemTransaction) GetTxnsByAddress(_ context.Context, address string) ([]entity.Transaction, error) {
+func (r *InMemTransaction) GetTxnByHash(ctx context.Context, hash string) (entity.Transaction, error) {
+	_, span := tracing.Start(ctx, "InMemTransaction.GetTxnByHash")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return entity.Transaction{}, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	txn, ok := r.hashes[hash]
+	if !ok {
+		return entity.Transaction{}, errorpkg.TransactionNotFound
+	}
+
+	return *txn, nil
+}
+
+func (r *InMemTransaction) GetTxnsByAddress(
+	ctx context.Context,
+	address entity.Address,
+	filter entity.TransactionFilter,
+) ([]entity.Transaction, error) {
+	_, span := tracing.Start(ctx, "InMemTransaction.GetTxnsByAddress")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	address = address.Canonical()
 	txns := r.data[address]
-	r.mu.RUnlock()
+	result := make([]entity.Transaction, 0)
+
+	appendMatched := func(txn *entity.Transaction) bool {
+		if matchTransactionFilter(*txn, address, filter) {
+			result = append(result, *txn)
+		}
+
+		return filter.Limit == 0 || len(result) < filter.Limit
+	}
+
+	if filter.Order == constant.OrderDesc {
+		end := len(txns)
+		if filter.After != nil {
+			end = sort.Search(len(txns), func(i int) bool {
+				return txns[i].Cursor().Compare(*filter.After) >= 0
+			})
+		}
+		if filter.ToBlock > 0 {
+			toBlockEnd := sort.Search(len(txns), func(i int) bool {
+				return txns[i].BlockNumber > filter.ToBlock
+			})
+			if toBlockEnd < end {
+				end = toBlockEnd
+			}
+		}
+
+		for i := end - 1; i >= 0 && txns[i].BlockNumber >= filter.FromBlock; i-- {
+			if !appendMatched(txns[i]) {
+				break
+			}
+		}
+
+		return result, nil
+	}
+
+	start := sort.Search(len(txns), func(i int) bool {
+		return txns[i].BlockNumber >= filter.FromBlock
+	})
+	if filter.After != nil {
+		afterStart := sort.Search(len(txns), func(i int) bool {
+			return txns[i].Cursor().Compare(*filter.After) > 0
+		})
+		if afterStart > start {
+			start = afterStart
+		}
+	}
+
+	for i := start; i < len(txns) && (filter.ToBlock == 0 || txns[i].BlockNumber <= filter.ToBlock); i++ {
+		if !appendMatched(txns[i]) {
+			break
+		}
+	}
+
+	return result, nil
+}
+
+func (r *InMemTransaction) GetTxnsByBlockNumber(ctx context.Context, blockNumber entity.BlockNumber) ([]entity.Transaction, error) {
+	_, span := tracing.Start(ctx, "InMemTransaction.GetTxnsByBlockNumber")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	txns := r.blocks[blockNumber]
 
 	txnscopy := make([]entity.Transaction, 0, len(txns))
 	for _, txn := range txns {
@@ -49,3 +180,109 @@ func (r *InMemTransaction) GetTxnsByAddress(_ context.Context, address string) (
 
 	return txnscopy, nil
 }
+
+func (r *InMemTransaction) DeleteByBlockNumber(ctx context.Context, blockNumber entity.BlockNumber) error {
+	_, span := tracing.Start(ctx, "InMemTransaction.DeleteByBlockNumber")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, txn := range r.blocks[blockNumber] {
+		r.remove(txn.To, txn.Cursor())
+		r.remove(txn.From, txn.Cursor())
+		delete(r.hashes, txn.Hash)
+	}
+	delete(r.blocks, blockNumber)
+
+	return nil
+}
+
+func (r *InMemTransaction) insert(address entity.Address, transaction *entity.Transaction) {
+	address = address.Canonical()
+	txns := r.data[address]
+	cursor := transaction.Cursor()
+
+	i := sort.Search(len(txns), func(i int) bool {
+		return txns[i].Cursor().Compare(cursor) >= 0
+	})
+	if i < len(txns) && txns[i].Cursor().Compare(cursor) == 0 {
+		txns[i] = transaction
+
+		return
+	}
+
+	txns = append(txns, nil)
+	copy(txns[i+1:], txns[i:])
+	txns[i] = transaction
+
+	r.data[address] = txns
+}
+
+func (r *InMemTransaction) remove(address entity.Address, cursor entity.TransactionCursor) {
+	address = address.Canonical()
+	txns := r.data[address]
+
+	i := sort.Search(len(txns), func(i int) bool {
+		return txns[i].Cursor().Compare(cursor) >= 0
+	})
+	if i == len(txns) || txns[i].Cursor().Compare(cursor) != 0 {
+		return
+	}
+
+	r.data[address] = append(txns[:i], txns[i+1:]...)
+}
+
+func matchTransactionFilter(txn entity.Transaction, address entity.Address, filter entity.TransactionFilter) bool {
+	if !filter.FromTime.IsZero() && txn.Timestamp.Before(filter.FromTime) {
+		return false
+	}
+	if !filter.ToTime.IsZero() && txn.Timestamp.After(filter.ToTime) {
+		return false
+	}
+
+	switch filter.Direction {
+	case constant.TransactionDirectionIn:
+		if txn.To != address {
+			return false
+		}
+	case constant.TransactionDirectionOut:
+		if txn.From != address {
+			return false
+		}
+	}
+
+	if filter.MinValue != nil && txn.Value.Cmp(*filter.MinValue) < 0 {
+		return false
+	}
+
+	return true
+}
+
+func transactionID(transaction entity.Transaction) string {
+	return fmt.Sprintf("%d_%d", transaction.BlockNumber, transaction.TransactionIndex)
+}
+
+// Count returns count of stored transactions and withdrawals
+func (r *InMemTransaction) Count(ctx context.Context) (int, error) {
+	_, span := tracing.Start(ctx, "InMemTransaction.Count")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	count := 0
+	for _, txns := range r.blocks {
+		count += len(txns)
+	}
+
+	return count, nil
+}
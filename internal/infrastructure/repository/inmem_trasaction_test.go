@@ -1,63 +1,187 @@
 package repository
 
 import (
-	"blockchain-parser/internal/entity"
 	"context"
 	"errors"
 	"reflect"
 	"testing"
+	"time"
+
+	"blockchain-parser/internal/constant"
+	"blockchain-parser/internal/entity"
+	errorpkg "blockchain-parser/internal/error"
 )
 
 func TestInMemTransaction_Save(t *testing.T) {
 	type fields struct {
-		data map[string]map[string]*entity.Transaction
+		data   map[entity.Address][]*entity.Transaction
+		blocks map[entity.BlockNumber]map[string]*entity.Transaction
 	}
 
 	ctx := context.Background()
 	txn := entity.Transaction{
 		From:             "0x42352",
 		To:               "0x245212",
-		Value:            "0x3453",
+		Value:            entity.NewWeiFromInt64(0x3453),
 		BlockNumber:      1,
 		TransactionIndex: 5,
 	}
+	prevTxn := entity.Transaction{
+		From:             "0x42352",
+		To:               "0x42352",
+		Value:            entity.NewWeiFromInt64(0x3453),
+		BlockNumber:      1,
+		TransactionIndex: 2,
+	}
+	withdrawal := entity.Transaction{
+		Kind:             constant.TransactionKindWithdrawal,
+		To:               "0x245212",
+		Value:            entity.NewWeiFromInt64(0x3453),
+		BlockNumber:      1,
+		TransactionIndex: 7,
+	}
 
 	type args struct {
 		ctx         context.Context
 		transaction entity.Transaction
 	}
 	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		want    map[string]map[string]*entity.Transaction
-		wantErr error
+		name       string
+		fields     fields
+		args       args
+		want       map[entity.Address][]*entity.Transaction
+		wantBlocks map[entity.BlockNumber]map[string]*entity.Transaction
+		wantErr    error
 	}{
 		{
 			name: "save transaction",
 			fields: fields{
-				data: map[string]map[string]*entity.Transaction{},
+				data:   map[entity.Address][]*entity.Transaction{},
+				blocks: map[entity.BlockNumber]map[string]*entity.Transaction{},
 			},
 			args: args{
 				ctx:         ctx,
 				transaction: txn,
 			},
-			want: map[string]map[string]*entity.Transaction{
+			want: map[entity.Address][]*entity.Transaction{
 				"0x42352": {
+					&txn,
+				},
+				"0x245212": {
+					&txn,
+				},
+			},
+			wantBlocks: map[entity.BlockNumber]map[string]*entity.Transaction{
+				1: {
 					"1_5": &txn,
 				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "save transaction in order",
+			fields: fields{
+				data: map[entity.Address][]*entity.Transaction{
+					"0x42352": {
+						&txn,
+					},
+					"0x245212": {
+						&txn,
+					},
+				},
+				blocks: map[entity.BlockNumber]map[string]*entity.Transaction{
+					1: {
+						"1_5": &txn,
+					},
+				},
+			},
+			args: args{
+				ctx:         ctx,
+				transaction: prevTxn,
+			},
+			want: map[entity.Address][]*entity.Transaction{
+				"0x42352": {
+					&prevTxn,
+					&txn,
+				},
 				"0x245212": {
+					&txn,
+				},
+			},
+			wantBlocks: map[entity.BlockNumber]map[string]*entity.Transaction{
+				1: {
+					"1_2": &prevTxn,
 					"1_5": &txn,
 				},
 			},
 			wantErr: nil,
 		},
+		{
+			name: "save the same transaction twice",
+			fields: fields{
+				data: map[entity.Address][]*entity.Transaction{
+					"0x42352": {
+						&txn,
+					},
+					"0x245212": {
+						&txn,
+					},
+				},
+				blocks: map[entity.BlockNumber]map[string]*entity.Transaction{
+					1: {
+						"1_5": &txn,
+					},
+				},
+			},
+			args: args{
+				ctx:         ctx,
+				transaction: txn,
+			},
+			want: map[entity.Address][]*entity.Transaction{
+				"0x42352": {
+					&txn,
+				},
+				"0x245212": {
+					&txn,
+				},
+			},
+			wantBlocks: map[entity.BlockNumber]map[string]*entity.Transaction{
+				1: {
+					"1_5": &txn,
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "save withdrawal without sender",
+			fields: fields{
+				data:   map[entity.Address][]*entity.Transaction{},
+				blocks: map[entity.BlockNumber]map[string]*entity.Transaction{},
+			},
+			args: args{
+				ctx:         ctx,
+				transaction: withdrawal,
+			},
+			want: map[entity.Address][]*entity.Transaction{
+				"0x245212": {
+					&withdrawal,
+				},
+			},
+			wantBlocks: map[entity.BlockNumber]map[string]*entity.Transaction{
+				1: {
+					"1_7": &withdrawal,
+				},
+			},
+			wantErr: nil,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := &InMemTransaction{
-				data: tt.fields.data,
+				data:   tt.fields.data,
+				blocks: tt.fields.blocks,
+				hashes: map[string]*entity.Transaction{},
 			}
 			if err := r.Save(tt.args.ctx, tt.args.transaction); !errors.Is(err, tt.wantErr) {
 				t.Errorf("Save() error = %v, wantErr %v", err, tt.wantErr)
@@ -65,27 +189,212 @@ func TestInMemTransaction_Save(t *testing.T) {
 			if !reflect.DeepEqual(r.data, tt.want) {
 				t.Errorf("data got = %v, want %v", r.data, tt.want)
 			}
+			if !reflect.DeepEqual(r.blocks, tt.wantBlocks) {
+				t.Errorf("blocks got = %v, want %v", r.blocks, tt.wantBlocks)
+			}
 		})
 	}
 }
 
 func TestInMemTransaction_GetTxnsByAddress(t *testing.T) {
+	ctx := context.Background()
+	address := entity.Address("0x42352")
+	blockTime := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	txn1 := entity.Transaction{
+		From:             address,
+		To:               "0x245212",
+		Value:            entity.NewWeiFromInt64(0x10),
+		BlockNumber:      1,
+		TransactionIndex: 5,
+		Timestamp:        blockTime,
+	}
+	txn2 := entity.Transaction{
+		From:             "0x245212",
+		To:               address,
+		Value:            entity.NewWeiFromInt64(0x3453),
+		BlockNumber:      2,
+		TransactionIndex: 0,
+		Timestamp:        blockTime.Add(time.Minute),
+	}
+	txn3 := entity.Transaction{
+		From:             address,
+		To:               "0x5531",
+		Value:            entity.NewWeiFromInt64(0x3453),
+		BlockNumber:      2,
+		TransactionIndex: 7,
+		Timestamp:        blockTime.Add(time.Minute),
+	}
+	txn4 := entity.Transaction{
+		From:             "0x5531",
+		To:               address,
+		Value:            entity.NewWeiFromInt64(0x1),
+		BlockNumber:      4,
+		TransactionIndex: 1,
+		Timestamp:        blockTime.Add(2 * time.Minute),
+	}
+	minValue := entity.NewWeiFromInt64(0x10)
+
+	r := NewInMemTransaction()
+	for _, txn := range []entity.Transaction{txn3, txn1, txn4, txn2} {
+		if err := r.Save(ctx, txn); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+
+	type args struct {
+		address entity.Address
+		filter  entity.TransactionFilter
+	}
+	tests := []struct {
+		name string
+		args args
+		want []entity.Transaction
+	}{
+		{
+			name: "no transactions",
+			args: args{
+				address: "0x1",
+			},
+			want: []entity.Transaction{},
+		},
+		{
+			name: "return transactions in order",
+			args: args{
+				address: address,
+			},
+			want: []entity.Transaction{txn1, txn2, txn3, txn4},
+		},
+		{
+			name: "return transactions in descending order",
+			args: args{
+				address: address,
+				filter: entity.TransactionFilter{
+					Order: constant.OrderDesc,
+				},
+			},
+			want: []entity.Transaction{txn4, txn3, txn2, txn1},
+		},
+		{
+			name: "limit and cursor",
+			args: args{
+				address: address,
+				filter: entity.TransactionFilter{
+					After: &entity.TransactionCursor{BlockNumber: 1, TransactionIndex: 5},
+					Limit: 2,
+				},
+			},
+			want: []entity.Transaction{txn2, txn3},
+		},
+		{
+			name: "limit and cursor in descending order",
+			args: args{
+				address: address,
+				filter: entity.TransactionFilter{
+					Order: constant.OrderDesc,
+					After: &entity.TransactionCursor{BlockNumber: 2, TransactionIndex: 7},
+					Limit: 1,
+				},
+			},
+			want: []entity.Transaction{txn2},
+		},
+		{
+			name: "block range",
+			args: args{
+				address: address,
+				filter: entity.TransactionFilter{
+					FromBlock: 2,
+					ToBlock:   3,
+				},
+			},
+			want: []entity.Transaction{txn2, txn3},
+		},
+		{
+			name: "block range in descending order",
+			args: args{
+				address: address,
+				filter: entity.TransactionFilter{
+					FromBlock: 2,
+					ToBlock:   3,
+					Order:     constant.OrderDesc,
+				},
+			},
+			want: []entity.Transaction{txn3, txn2},
+		},
+		{
+			name: "time range",
+			args: args{
+				address: address,
+				filter: entity.TransactionFilter{
+					FromTime: blockTime.Add(time.Minute),
+					ToTime:   blockTime.Add(time.Minute),
+				},
+			},
+			want: []entity.Transaction{txn2, txn3},
+		},
+		{
+			name: "incoming transactions",
+			args: args{
+				address: address,
+				filter: entity.TransactionFilter{
+					Direction: constant.TransactionDirectionIn,
+				},
+			},
+			want: []entity.Transaction{txn2, txn4},
+		},
+		{
+			name: "outgoing transactions",
+			args: args{
+				address: address,
+				filter: entity.TransactionFilter{
+					Direction: constant.TransactionDirectionOut,
+				},
+			},
+			want: []entity.Transaction{txn1, txn3},
+		},
+		{
+			name: "min value",
+			args: args{
+				address: address,
+				filter: entity.TransactionFilter{
+					MinValue: &minValue,
+				},
+			},
+			want: []entity.Transaction{txn1, txn2, txn3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.GetTxnsByAddress(ctx, tt.args.address, tt.args.filter)
+			if err != nil {
+				t.Errorf("GetTxnsByAddress() error = %v, wantErr %v", err, nil)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetTxnsByAddress() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInMemTransaction_GetTxnsByBlockNumber(t *testing.T) {
 	type fields struct {
-		data map[string]map[string]*entity.Transaction
+		blocks map[entity.BlockNumber]map[string]*entity.Transaction
 	}
 
 	ctx := context.Background()
 	txn := entity.Transaction{
 		From:             "0x42352",
 		To:               "0x245212",
-		Value:            "0x3453",
+		Value:            entity.NewWeiFromInt64(0x3453),
 		BlockNumber:      1,
 		TransactionIndex: 5,
 	}
 
 	type args struct {
-		ctx     context.Context
-		address string
+		ctx         context.Context
+		blockNumber entity.BlockNumber
 	}
 	tests := []struct {
 		name    string
@@ -97,11 +406,11 @@ func TestInMemTransaction_GetTxnsByAddress(t *testing.T) {
 		{
 			name: "no transactions",
 			fields: fields{
-				data: map[string]map[string]*entity.Transaction{},
+				blocks: map[entity.BlockNumber]map[string]*entity.Transaction{},
 			},
 			args: args{
-				ctx:     ctx,
-				address: "0x42352",
+				ctx:         ctx,
+				blockNumber: 1,
 			},
 			want:    []entity.Transaction{},
 			wantErr: false,
@@ -109,18 +418,15 @@ func TestInMemTransaction_GetTxnsByAddress(t *testing.T) {
 		{
 			name: "return transactions",
 			fields: fields{
-				data: map[string]map[string]*entity.Transaction{
-					"0x42352": {
-						"1_5": &txn,
-					},
-					"0x245212": {
+				blocks: map[entity.BlockNumber]map[string]*entity.Transaction{
+					1: {
 						"1_5": &txn,
 					},
 				},
 			},
 			args: args{
-				ctx:     ctx,
-				address: "0x245212",
+				ctx:         ctx,
+				blockNumber: 1,
 			},
 			want: []entity.Transaction{
 				txn,
@@ -132,16 +438,124 @@ func TestInMemTransaction_GetTxnsByAddress(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := &InMemTransaction{
-				data: tt.fields.data,
+				blocks: tt.fields.blocks,
 			}
-			got, err := r.GetTxnsByAddress(tt.args.ctx, tt.args.address)
+			got, err := r.GetTxnsByBlockNumber(tt.args.ctx, tt.args.blockNumber)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("GetTxnsByAddress() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("GetTxnsByBlockNumber() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("GetTxnsByAddress() got = %v, want %v", got, tt.want)
+				t.Errorf("GetTxnsByBlockNumber() got = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+func TestInMemTransaction_DeleteByBlockNumber(t *testing.T) {
+	ctx := context.Background()
+	txn1 := entity.Transaction{
+		From:             "0x42352",
+		To:               "0x245212",
+		Value:            entity.NewWeiFromInt64(0x3453),
+		BlockNumber:      1,
+		TransactionIndex: 5,
+	}
+	txn2 := entity.Transaction{
+		From:             "0x42352",
+		To:               "0x245212",
+		Value:            entity.NewWeiFromInt64(0x3453),
+		BlockNumber:      2,
+		TransactionIndex: 0,
+	}
+
+	r := NewInMemTransaction()
+	for _, txn := range []entity.Transaction{txn1, txn2} {
+		if err := r.Save(ctx, txn); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+
+	if err := r.DeleteByBlockNumber(ctx, 1); err != nil {
+		t.Errorf("DeleteByBlockNumber() error = %v, wantErr %v", err, nil)
+		return
+	}
+
+	want := map[entity.Address][]*entity.Transaction{
+		"0x42352": {
+			&txn2,
+		},
+		"0x245212": {
+			&txn2,
+		},
+	}
+	if !reflect.DeepEqual(r.data, want) {
+		t.Errorf("data got = %v, want %v", r.data, want)
+	}
+
+	wantBlocks := map[entity.BlockNumber]map[string]*entity.Transaction{
+		2: {
+			"2_0": &txn2,
+		},
+	}
+	if !reflect.DeepEqual(r.blocks, wantBlocks) {
+		t.Errorf("blocks got = %v, want %v", r.blocks, wantBlocks)
+	}
+
+	if count, err := r.Count(ctx); err != nil || count != 1 {
+		t.Errorf("Count() got = %v, %v, want %v", count, err, 1)
+	}
+}
+
+func TestInMemTransaction_GetTxnByHash(t *testing.T) {
+	ctx := context.Background()
+	txn := entity.Transaction{
+		Hash:             "0x5a",
+		From:             "0x42352",
+		To:               "0x245212",
+		Value:            entity.NewWeiFromInt64(0x3453),
+		BlockNumber:      1,
+		TransactionIndex: 5,
+	}
+
+	r := NewInMemTransaction()
+	if err := r.Save(ctx, txn); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	t.Run("transaction found", func(tt *testing.T) {
+		got, err := r.GetTxnByHash(ctx, txn.Hash)
+		if err != nil {
+			t.Errorf("GetTxnByHash() error = %v, wantErr %v", err, nil)
+			return
+		}
+		if !reflect.DeepEqual(got, txn) {
+			t.Errorf("GetTxnByHash() got = %v, want %v", got, txn)
+		}
+	})
+
+	t.Run("transaction replaced after reorg", func(tt *testing.T) {
+		reorgTxn := txn
+		reorgTxn.Hash = "0x5b"
+		if err := r.Save(ctx, reorgTxn); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+
+		if _, err := r.GetTxnByHash(ctx, txn.Hash); !errors.Is(err, errorpkg.TransactionNotFound) {
+			t.Errorf("GetTxnByHash() error = %v, wantErr %v", err, errorpkg.TransactionNotFound)
+		}
+		if _, err := r.GetTxnByHash(ctx, reorgTxn.Hash); err != nil {
+			t.Errorf("GetTxnByHash() error = %v, wantErr %v", err, nil)
+		}
+	})
+
+	t.Run("transaction deleted", func(tt *testing.T) {
+		if err := r.DeleteByBlockNumber(ctx, txn.BlockNumber); err != nil {
+			t.Fatalf("DeleteByBlockNumber() error = %v", err)
+		}
+
+		if _, err := r.GetTxnByHash(ctx, "0x5b"); !errors.Is(err, errorpkg.TransactionNotFound) {
+			t.Errorf("GetTxnByHash() error = %v, wantErr %v", err, errorpkg.TransactionNotFound)
+		}
+	})
+}
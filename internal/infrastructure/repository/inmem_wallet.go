@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"blockchain-parser/internal/entity"
+	errorpkg "blockchain-parser/internal/error"
+	"blockchain-parser/tools/tracing"
+)
+
+type InMemWallet struct {
+	data map[string]entity.Wallet
+	mu   sync.RWMutex
+}
+
+func NewInMemWallet() *InMemWallet {
+	return &InMemWallet{
+		data: map[string]entity.Wallet{},
+	}
+}
+
+func (r *InMemWallet) Save(ctx context.Context, wallet entity.Wallet) error {
+	_, span := tracing.Start(ctx, "InMemWallet.Save")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	wallet.Addresses = canonicalAddresses(wallet.Addresses)
+	r.data[wallet.ID] = wallet
+
+	return nil
+}
+
+func (r *InMemWallet) Get(ctx context.Context, id string) (entity.Wallet, error) {
+	_, span := tracing.Start(ctx, "InMemWallet.Get")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return entity.Wallet{}, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	wallet, ok := r.data[id]
+	if !ok {
+		return entity.Wallet{}, errorpkg.WalletNotFound
+	}
+
+	wallet.Addresses = append([]entity.Address(nil), wallet.Addresses...)
+
+	return wallet, nil
+}
+
+// GetByTenant returns wallets of the tenant ordered by creation time
+func (r *InMemWallet) GetByTenant(ctx context.Context, tenantID string) ([]entity.Wallet, error) {
+	_, span := tracing.Start(ctx, "InMemWallet.GetByTenant")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	wallets := make([]entity.Wallet, 0)
+	for _, wallet := range r.data {
+		if wallet.TenantID != tenantID {
+			continue
+		}
+
+		wallet.Addresses = append([]entity.Address(nil), wallet.Addresses...)
+		wallets = append(wallets, wallet)
+	}
+
+	sort.Slice(wallets, func(i, j int) bool {
+		if wallets[i].CreatedAt.Equal(wallets[j].CreatedAt) {
+			return wallets[i].ID < wallets[j].ID
+		}
+
+		return wallets[i].CreatedAt.Before(wallets[j].CreatedAt)
+	})
+
+	return wallets, nil
+}
+
+func (r *InMemWallet) Delete(ctx context.Context, id string) error {
+	_, span := tracing.Start(ctx, "InMemWallet.Delete")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.data[id]; !ok {
+		return errorpkg.WalletNotFound
+	}
+
+	delete(r.data, id)
+
+	return nil
+}
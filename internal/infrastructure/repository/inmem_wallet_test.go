@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"blockchain-parser/internal/entity"
+	errorpkg "blockchain-parser/internal/error"
+)
+
+func TestInMemWallet_Get(t *testing.T) {
+	type fields struct {
+		data map[string]entity.Wallet
+	}
+
+	ctx := context.Background()
+	wallet := entity.Wallet{
+		ID:        "a1",
+		Name:      "main",
+		Addresses: []entity.Address{"0x41da31", "0x41da32"},
+	}
+
+	type args struct {
+		ctx context.Context
+		id  string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    entity.Wallet
+		wantErr error
+	}{
+		{
+			name: "wallet not found",
+			fields: fields{
+				data: map[string]entity.Wallet{},
+			},
+			args: args{
+				ctx: ctx,
+				id:  "a1",
+			},
+			want:    entity.Wallet{},
+			wantErr: errorpkg.WalletNotFound,
+		},
+		{
+			name: "return wallet",
+			fields: fields{
+				data: map[string]entity.Wallet{
+					"a1": wallet,
+				},
+			},
+			args: args{
+				ctx: ctx,
+				id:  "a1",
+			},
+			want:    wallet,
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &InMemWallet{
+				data: tt.fields.data,
+			}
+			got, err := r.Get(tt.args.ctx, tt.args.id)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Get() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Get() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInMemWallet_GetByTenant(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	wallet1 := entity.Wallet{
+		ID:        "b2",
+		TenantID:  "t1",
+		Addresses: []entity.Address{"0x41da31"},
+		CreatedAt: now,
+	}
+	wallet2 := entity.Wallet{
+		ID:        "a1",
+		TenantID:  "t1",
+		Addresses: []entity.Address{"0x41da32"},
+		CreatedAt: now.Add(time.Second),
+	}
+	otherTenantWallet := entity.Wallet{
+		ID:        "c3",
+		TenantID:  "t2",
+		Addresses: []entity.Address{"0x41da31"},
+		CreatedAt: now,
+	}
+
+	r := NewInMemWallet()
+	for _, wallet := range []entity.Wallet{wallet2, otherTenantWallet, wallet1} {
+		if err := r.Save(ctx, wallet); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+
+	got, err := r.GetByTenant(ctx, "t1")
+	if err != nil {
+		t.Errorf("GetByTenant() error = %v, wantErr %v", err, nil)
+		return
+	}
+
+	want := []entity.Wallet{wallet1, wallet2}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetByTenant() got = %v, want %v", got, want)
+	}
+}
+
+func TestInMemWallet_Delete(t *testing.T) {
+	ctx := context.Background()
+
+	r := NewInMemWallet()
+	if err := r.Save(ctx, entity.Wallet{ID: "a1"}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	if err := r.Delete(ctx, "a1"); err != nil {
+		t.Errorf("Delete() error = %v, wantErr %v", err, nil)
+	}
+	if err := r.Delete(ctx, "a1"); !errors.Is(err, errorpkg.WalletNotFound) {
+		t.Errorf("Delete() error = %v, wantErr %v", err, errorpkg.WalletNotFound)
+	}
+}
@@ -0,0 +1,72 @@
+package metrics
+
+import (
+	metricspkg "blockchain-parser/tools/metrics"
+)
+
+// Registry keeps metrics of the service, they are served at GET /metrics
+var Registry = metricspkg.NewRegistry()
+
+var jobBuckets = []float64{.01, .05, .1, .5, 1, 5, 10, 30, 60, 300}
+
+var (
+	HeadBlock = Registry.NewGauge(
+		"blockchain_parser_head_block",
+		"Last block number reported by the node",
+	)
+	BlocksParsed = Registry.NewCounter(
+		"blockchain_parser_blocks_parsed_total",
+		"Count of parsed blocks",
+	)
+	BlocksFailed = Registry.NewCounter(
+		"blockchain_parser_blocks_failed_total",
+		"Count of blocks which failed parsing",
+	)
+	BlocksRetried = Registry.NewCounter(
+		"blockchain_parser_blocks_retried_total",
+		"Count of failed blocks taken for parsing again",
+	)
+	BlocksReparsed = Registry.NewCounter(
+		"blockchain_parser_blocks_reparsed_total",
+		"Count of blocks parsed again by reparse tasks",
+	)
+	ParserWorkers = Registry.NewGauge(
+		"blockchain_parser_parser_workers",
+		"Count of parser workers",
+	)
+	ParsingPaused = Registry.NewGauge(
+		"blockchain_parser_parsing_paused",
+		"1 when parsing is paused by admin",
+	)
+
+	RPCRequestDuration = Registry.NewHistogram(
+		"blockchain_parser_rpc_request_duration_seconds",
+		"Duration of JSON-RPC requests to the node by method",
+		metricspkg.DefBuckets,
+		"method",
+	)
+	RPCErrors = Registry.NewCounter(
+		"blockchain_parser_rpc_errors_total",
+		"Count of failed JSON-RPC requests to the node by method, null results aren't errors",
+		"method",
+	)
+
+	HTTPRequestDuration = Registry.NewHistogram(
+		"blockchain_parser_http_request_duration_seconds",
+		"Duration of HTTP requests by route and status, streams are observed when they are closed",
+		metricspkg.DefBuckets,
+		"method", "route", "status",
+	)
+
+	JobRunDuration = Registry.NewHistogram(
+		"blockchain_parser_job_run_duration_seconds",
+		"Duration of job runs",
+		jobBuckets,
+		"job",
+	)
+	JobRunFailures = Registry.NewCounter(
+		"blockchain_parser_job_run_failures_total",
+		"Count of job runs which returned error",
+		"job",
+	)
+)
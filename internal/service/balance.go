@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"blockchain-parser/internal/constant"
+	"blockchain-parser/internal/entity"
+	errorpkg "blockchain-parser/internal/error"
+	"blockchain-parser/tools/logger"
+	"blockchain-parser/tools/tracing"
+)
+
+// Balance serves running balances of the subscribed addresses. A running balance is the snapshot
+// taken at subscription plus balance changes recorded by the parser worker.
+type Balance struct {
+	balanceRepo      BalanceRepository
+	subscriptionRepo SubscriptionRepository
+	blockRepo        BlockRepository
+	blockChainClient BlockChainClient
+	log              logger.Logger
+}
+
+func NewBalance(
+	balanceRepo BalanceRepository,
+	subscriptionRepo SubscriptionRepository,
+	blockRepo BlockRepository,
+	blockChainClient BlockChainClient,
+	log logger.Logger,
+) *Balance {
+	return &Balance{
+		balanceRepo:      balanceRepo,
+		subscriptionRepo: subscriptionRepo,
+		blockRepo:        blockRepo,
+		blockChainClient: blockChainClient,
+		log:              log,
+	}
+}
+
+// GetBalance returns balance of the address subscribed by the tenant at the block, nil block number means
+// the last parsed block. Balance at blocks before the snapshot or the first block of the subscription is fetched
+// from the node, tracked balance there would tell that another tenant watched the address.
+func (s *Balance) GetBalance(ctx context.Context, tenantID string, address entity.Address, atBlockNumber *entity.BlockNumber) (entity.Balance, error) {
+	subscription, err := s.subscriptionRepo.Get(ctx, tenantID, address)
+	if err != nil {
+		return entity.Balance{}, fmt.Errorf("fail get subscription in GetBalance: %w", err)
+	}
+
+	var blockNumber entity.BlockNumber
+	if atBlockNumber != nil {
+		blockNumber = *atBlockNumber
+	} else {
+		block, err := s.blockRepo.GetLastParsedBlock(ctx)
+		if err != nil {
+			return entity.Balance{}, fmt.Errorf("fail get last parsed block in GetBalance: %w", err)
+		}
+
+		blockNumber = block.Number
+	}
+
+	snapshot, err := s.balanceRepo.GetSnapshot(ctx, address)
+	if err != nil {
+		return entity.Balance{}, fmt.Errorf("fail get balance snapshot of address (%s) in GetBalance: %w", address, err)
+	}
+
+	if blockNumber < snapshot.BlockNumber || blockNumber < subscription.FromBlock {
+		value, err := s.blockChainClient.GetBalance(ctx, address, blockNumber)
+		if err != nil {
+			return entity.Balance{}, fmt.Errorf("fail get balance of address (%s) from node in GetBalance: %w", address, err)
+		}
+
+		return entity.Balance{
+			Address:     address,
+			BlockNumber: blockNumber,
+			Value:       value,
+			Source:      constant.BalanceSourceNode,
+		}, nil
+	}
+
+	_, err = s.blockRepo.GetParsedBlock(ctx, blockNumber)
+	if errors.Is(err, errorpkg.BlockNotFound) {
+		return entity.Balance{}, fmt.Errorf("block (%d) in GetBalance: %w", blockNumber, errorpkg.BlockNotParsed)
+	}
+	if err != nil {
+		return entity.Balance{}, fmt.Errorf("fail get block (%d) in GetBalance: %w", blockNumber, err)
+	}
+
+	balance, err := s.getRunningBalance(ctx, snapshot, blockNumber)
+	if err != nil {
+		return entity.Balance{}, fmt.Errorf("fail get running balance of address (%s) in GetBalance: %w", address, err)
+	}
+
+	return balance, nil
+}
+
+// Reconcile compares running balances with the node. Each address is checked at the last block
+// up to which all blocks after the previous check are parsed. Drift is logged and stored
+// as a reconciliation change, so the running balance matches the node again.
+func (s *Balance) Reconcile(ctx context.Context) (err error) {
+	ctx, span := tracing.Start(ctx, "Balance.Reconcile")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	snapshots, err := s.balanceRepo.GetSnapshots(ctx)
+	if err != nil {
+		return fmt.Errorf("fail get balance snapshots in Reconcile: %w", err)
+	}
+
+	for _, snapshot := range snapshots {
+		if err := s.reconcile(ctx, snapshot); err != nil {
+			return fmt.Errorf("fail reconcile balance of address (%s) in Reconcile: %w", snapshot.Address, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Balance) reconcile(ctx context.Context, snapshot entity.BalanceSnapshot) error {
+	blockNumber := snapshot.ReconciledBlockNumber
+	for {
+		_, err := s.blockRepo.GetParsedBlock(ctx, blockNumber+1)
+		if errors.Is(err, errorpkg.BlockNotFound) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("fail get block (%d): %w", blockNumber+1, err)
+		}
+
+		blockNumber++
+	}
+
+	if blockNumber == snapshot.ReconciledBlockNumber {
+		return nil
+	}
+
+	balance, err := s.getRunningBalance(ctx, snapshot, blockNumber)
+	if err != nil {
+		return fmt.Errorf("fail get running balance: %w", err)
+	}
+
+	nodeValue, err := s.blockChainClient.GetBalance(ctx, snapshot.Address, blockNumber)
+	if err != nil {
+		return fmt.Errorf("fail get balance at block (%d) from node: %w", blockNumber, err)
+	}
+
+	drift := nodeValue.Sub(balance.Value)
+	if drift.Sign() != 0 {
+		logger.FromContext(ctx, s.log).Warn("balance drift",
+			logger.Stringer("address", snapshot.Address), logger.Uint64("block_number", uint64(blockNumber)), logger.Stringer("drift", drift))
+
+		change := entity.BalanceChange{
+			ID:          fmt.Sprintf("%s_%d", constant.BalanceChangeReasonReconciliation, blockNumber),
+			Address:     snapshot.Address,
+			BlockNumber: blockNumber,
+			Reason:      constant.BalanceChangeReasonReconciliation,
+			Delta:       drift,
+		}
+		if err := s.balanceRepo.SaveChange(ctx, change); err != nil {
+			return fmt.Errorf("fail save reconciliation change: %w", err)
+		}
+	}
+
+	snapshot.ReconciledBlockNumber = blockNumber
+	if err := s.balanceRepo.SaveSnapshot(ctx, snapshot); err != nil {
+		return fmt.Errorf("fail save balance snapshot: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Balance) getRunningBalance(ctx context.Context, snapshot entity.BalanceSnapshot, blockNumber entity.BlockNumber) (entity.Balance, error) {
+	changes, err := s.balanceRepo.GetChanges(ctx, snapshot.Address, snapshot.BlockNumber, blockNumber)
+	if err != nil {
+		return entity.Balance{}, fmt.Errorf("fail get balance changes: %w", err)
+	}
+
+	balance := entity.Balance{
+		Address:     snapshot.Address,
+		BlockNumber: blockNumber,
+		Value:       snapshot.Value,
+		Source:      constant.BalanceSourceTracked,
+	}
+
+	for _, change := range changes {
+		balance.Value = balance.Value.Add(change.Delta)
+
+		if change.Reason == constant.BalanceChangeReasonReconciliation {
+			drift := change.Delta
+			if balance.Drift != nil {
+				drift = balance.Drift.Add(change.Delta)
+			}
+			balance.Drift = &drift
+		}
+	}
+
+	return balance, nil
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"blockchain-parser/internal/constant"
+	"blockchain-parser/internal/entity"
+	errorpkg "blockchain-parser/internal/error"
+	"blockchain-parser/internal/service/mocks"
+	"blockchain-parser/tools/logger"
+)
+
+func TestBalance_GetBalance(t *testing.T) {
+	address := entity.Address("0xd7def8de6bff40e7fa3a19b6749aca84bd5ba0ae")
+	snapshot := entity.BalanceSnapshot{
+		Address:     address,
+		BlockNumber: 100,
+		Value:       entity.NewWeiFromInt64(1000),
+	}
+	changes := []entity.BalanceChange{
+		{ID: "transaction_in_0x5a", Address: address, BlockNumber: 101, Reason: constant.BalanceChangeReasonTransaction, Delta: entity.NewWeiFromInt64(200)},
+		{ID: "fee_out_0x5b", Address: address, BlockNumber: 102, Reason: constant.BalanceChangeReasonFee, Delta: entity.NewWeiFromInt64(-10)},
+		{ID: "reconciliation_102", Address: address, BlockNumber: 102, Reason: constant.BalanceChangeReasonReconciliation, Delta: entity.NewWeiFromInt64(-5)},
+	}
+	drift := entity.NewWeiFromInt64(-5)
+	blockNumber := func(number entity.BlockNumber) *entity.BlockNumber {
+		return &number
+	}
+
+	type mocksSetup func(balanceRepoMock *mocks.MockBalanceRepository, blockRepoMock *mocks.MockBlockRepository, blockChainClientMock *mocks.MockBlockChainClient)
+
+	tests := []struct {
+		name          string
+		blockNumber   *entity.BlockNumber
+		notSubscribed bool
+		fromBlock     entity.BlockNumber
+		setup         mocksSetup
+		want          entity.Balance
+		wantErr       error
+	}{
+		{
+			name:        "running balance at the last parsed block",
+			blockNumber: nil,
+			setup: func(balanceRepoMock *mocks.MockBalanceRepository, blockRepoMock *mocks.MockBlockRepository, _ *mocks.MockBlockChainClient) {
+				blockRepoMock.EXPECT().GetLastParsedBlock(gomock.Any()).Return(entity.Block{Number: 102}, nil).Times(1)
+				blockRepoMock.EXPECT().GetParsedBlock(gomock.Any(), entity.BlockNumber(102)).Return(entity.Block{Number: 102}, nil).Times(1)
+				balanceRepoMock.EXPECT().GetSnapshot(gomock.Any(), address).Return(snapshot, nil).Times(1)
+				balanceRepoMock.EXPECT().GetChanges(gomock.Any(), address, entity.BlockNumber(100), entity.BlockNumber(102)).Return(changes, nil).Times(1)
+			},
+			want: entity.Balance{
+				Address:     address,
+				BlockNumber: 102,
+				Value:       entity.NewWeiFromInt64(1185),
+				Source:      constant.BalanceSourceTracked,
+				Drift:       &drift,
+			},
+		},
+		{
+			name:        "balance before snapshot is fetched from node",
+			blockNumber: blockNumber(90),
+			setup: func(balanceRepoMock *mocks.MockBalanceRepository, _ *mocks.MockBlockRepository, blockChainClientMock *mocks.MockBlockChainClient) {
+				balanceRepoMock.EXPECT().GetSnapshot(gomock.Any(), address).Return(snapshot, nil).Times(1)
+				blockChainClientMock.EXPECT().GetBalance(gomock.Any(), address, entity.BlockNumber(90)).Return(entity.NewWeiFromInt64(700), nil).Times(1)
+			},
+			want: entity.Balance{
+				Address:     address,
+				BlockNumber: 90,
+				Value:       entity.NewWeiFromInt64(700),
+				Source:      constant.BalanceSourceNode,
+			},
+		},
+		{
+			name:        "balance before the subscription is fetched from node",
+			blockNumber: blockNumber(101),
+			fromBlock:   102,
+			setup: func(balanceRepoMock *mocks.MockBalanceRepository, _ *mocks.MockBlockRepository, blockChainClientMock *mocks.MockBlockChainClient) {
+				balanceRepoMock.EXPECT().GetSnapshot(gomock.Any(), address).Return(snapshot, nil).Times(1)
+				blockChainClientMock.EXPECT().GetBalance(gomock.Any(), address, entity.BlockNumber(101)).Return(entity.NewWeiFromInt64(1200), nil).Times(1)
+			},
+			want: entity.Balance{
+				Address:     address,
+				BlockNumber: 101,
+				Value:       entity.NewWeiFromInt64(1200),
+				Source:      constant.BalanceSourceNode,
+			},
+		},
+		{
+			name:        "block is not parsed",
+			blockNumber: blockNumber(110),
+			setup: func(balanceRepoMock *mocks.MockBalanceRepository, blockRepoMock *mocks.MockBlockRepository, _ *mocks.MockBlockChainClient) {
+				balanceRepoMock.EXPECT().GetSnapshot(gomock.Any(), address).Return(snapshot, nil).Times(1)
+				blockRepoMock.EXPECT().GetParsedBlock(gomock.Any(), entity.BlockNumber(110)).Return(entity.Block{}, errorpkg.BlockNotFound).Times(1)
+			},
+			wantErr: errorpkg.BlockNotParsed,
+		},
+		{
+			name:        "balance is not tracked",
+			blockNumber: blockNumber(110),
+			setup: func(balanceRepoMock *mocks.MockBalanceRepository, _ *mocks.MockBlockRepository, _ *mocks.MockBlockChainClient) {
+				balanceRepoMock.EXPECT().GetSnapshot(gomock.Any(), address).Return(entity.BalanceSnapshot{}, errorpkg.BalanceNotTracked).Times(1)
+			},
+			wantErr: errorpkg.BalanceNotTracked,
+		},
+		{
+			name:          "address is not subscribed by the tenant",
+			blockNumber:   nil,
+			notSubscribed: true,
+			setup:         func(_ *mocks.MockBalanceRepository, _ *mocks.MockBlockRepository, _ *mocks.MockBlockChainClient) {},
+			wantErr:       errorpkg.SubscriberNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			balanceRepoMock := mocks.NewMockBalanceRepository(ctrl)
+			blockRepoMock := mocks.NewMockBlockRepository(ctrl)
+			blockChainClientMock := mocks.NewMockBlockChainClient(ctrl)
+			subscriptionRepoMock := mocks.NewMockSubscriptionRepository(ctrl)
+			tt.setup(balanceRepoMock, blockRepoMock, blockChainClientMock)
+
+			var subscriptionErr error
+			if tt.notSubscribed {
+				subscriptionErr = errorpkg.SubscriberNotFound
+			}
+			subscriptionRepoMock.EXPECT().Get(gomock.Any(), "t1", address).Return(entity.Subscription{FromBlock: tt.fromBlock}, subscriptionErr).Times(1)
+
+			s := NewBalance(balanceRepoMock, subscriptionRepoMock, blockRepoMock, blockChainClientMock, logger.Nop())
+
+			got, err := s.GetBalance(context.Background(), "t1", address, tt.blockNumber)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetBalance() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetBalance() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBalance_Reconcile(t *testing.T) {
+	ctx := context.Background()
+	address := entity.Address("0xd7def8de6bff40e7fa3a19b6749aca84bd5ba0ae")
+	snapshot := entity.BalanceSnapshot{
+		Address:               address,
+		BlockNumber:           100,
+		Value:                 entity.NewWeiFromInt64(1000),
+		ReconciledBlockNumber: 100,
+	}
+
+	ctrl := gomock.NewController(t)
+	blockRepoMock := mocks.NewMockBlockRepository(ctrl)
+	blockRepoMock.EXPECT().GetParsedBlock(ctx, entity.BlockNumber(101)).Return(entity.Block{Number: 101}, nil).Times(1)
+	blockRepoMock.EXPECT().GetParsedBlock(ctx, entity.BlockNumber(102)).Return(entity.Block{Number: 102}, nil).Times(1)
+	blockRepoMock.EXPECT().GetParsedBlock(ctx, entity.BlockNumber(103)).Return(entity.Block{}, errorpkg.BlockNotFound).Times(1)
+
+	blockChainClientMock := mocks.NewMockBlockChainClient(ctrl)
+	blockChainClientMock.EXPECT().GetBalance(ctx, address, entity.BlockNumber(102)).Return(entity.NewWeiFromInt64(1300), nil).Times(1)
+
+	balanceRepoMock := mocks.NewMockBalanceRepository(ctrl)
+	balanceRepoMock.EXPECT().GetSnapshots(ctx).Return([]entity.BalanceSnapshot{snapshot}, nil).Times(1)
+	balanceRepoMock.EXPECT().GetChanges(ctx, address, entity.BlockNumber(100), entity.BlockNumber(102)).Return([]entity.BalanceChange{
+		{ID: "transaction_in_0x5a", Address: address, BlockNumber: 101, Reason: constant.BalanceChangeReasonTransaction, Delta: entity.NewWeiFromInt64(200)},
+	}, nil).Times(1)
+	balanceRepoMock.EXPECT().SaveChange(ctx, entity.BalanceChange{
+		ID:          "reconciliation_102",
+		Address:     address,
+		BlockNumber: 102,
+		Reason:      constant.BalanceChangeReasonReconciliation,
+		Delta:       entity.NewWeiFromInt64(100),
+	}).Return(nil).Times(1)
+
+	reconciledSnapshot := snapshot
+	reconciledSnapshot.ReconciledBlockNumber = 102
+	balanceRepoMock.EXPECT().SaveSnapshot(ctx, reconciledSnapshot).Return(nil).Times(1)
+
+	s := NewBalance(balanceRepoMock, nil, blockRepoMock, blockChainClientMock, logger.Nop())
+
+	if err := s.Reconcile(ctx); err != nil {
+		t.Errorf("Reconcile() error = %v, wantErr %v", err, nil)
+	}
+}
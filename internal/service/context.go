@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+	"time"
+)
+
+// withoutCancel keeps values of the parent context (logger, span) but isn't cancelled with it,
+// it's used to finish the state of the block after the run is cancelled on shutdown
+type withoutCancelCtx struct {
+	parent context.Context
+}
+
+func withoutCancel(parent context.Context) context.Context {
+	return withoutCancelCtx{parent: parent}
+}
+
+func (withoutCancelCtx) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (withoutCancelCtx) Done() <-chan struct{} {
+	return nil
+}
+
+func (withoutCancelCtx) Err() error {
+	return nil
+}
+
+func (c withoutCancelCtx) Value(key interface{}) interface{} {
+	return c.parent.Value(key)
+}
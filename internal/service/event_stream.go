@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"blockchain-parser/internal/entity"
+)
+
+const (
+	eventStreamBatchSize = 100
+)
+
+type EventStream struct {
+	eventRepo        EventRepository
+	subscriptionRepo SubscriptionRepository
+}
+
+func NewEventStream(eventRepo EventRepository, subscriptionRepo SubscriptionRepository) *EventStream {
+	return &EventStream{
+		eventRepo:        eventRepo,
+		subscriptionRepo: subscriptionRepo,
+	}
+}
+
+// CheckSubscriptions returns SubscriberNotFound if the tenant isn't subscribed to one of the addresses,
+// the stream is opened only for addresses of the tenant.
+func (s *EventStream) CheckSubscriptions(ctx context.Context, tenantID string, addresses []entity.Address) error {
+	if err := checkTenantSubscriptions(ctx, s.subscriptionRepo, tenantID, addresses); err != nil {
+		return fmt.Errorf("fail check subscriptions in CheckSubscriptions: %w", err)
+	}
+
+	return nil
+}
+
+func (s *EventStream) GetEvents(ctx context.Context, cursor uint64, addresses []entity.Address) ([]entity.Event, uint64, error) {
+	events, cursor, err := s.eventRepo.GetEventsAfter(ctx, cursor, addresses, eventStreamBatchSize)
+	if err != nil {
+		return nil, 0, fmt.Errorf("fail get events in GetEvents: %w", err)
+	}
+
+	return events, cursor, nil
+}
+
+func (s *EventStream) WaitEvents(ctx context.Context, cursor uint64) error {
+	return s.eventRepo.WaitEvents(ctx, cursor)
+}
@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"blockchain-parser/internal/constant"
+	"blockchain-parser/internal/entity"
+	errorpkg "blockchain-parser/internal/error"
+	"blockchain-parser/tools/logger"
+)
+
+// Health checks whether the instance is alive and ready to serve. The instance isn't ready when the node
+// is unreachable, parser workers don't run or parsing lags behind the node head.
+type Health struct {
+	blockRepo        BlockRepository
+	blockChainClient BlockChainClient
+	parserRuns       ParserRunTracker
+
+	maxParseAge time.Duration
+	maxBlockLag uint64
+
+	draining atomic.Bool
+
+	log logger.Logger
+}
+
+func NewHealth(
+	blockRepo BlockRepository,
+	blockChainClient BlockChainClient,
+	parserRuns ParserRunTracker,
+	maxParseAge time.Duration,
+	maxBlockLag uint64,
+	log logger.Logger,
+) *Health {
+	return &Health{
+		blockRepo:        blockRepo,
+		blockChainClient: blockChainClient,
+		parserRuns:       parserRuns,
+		maxParseAge:      maxParseAge,
+		maxBlockLag:      maxBlockLag,
+		log:              log,
+	}
+}
+
+// Liveness reports that the process serves requests, it doesn't depend on the node
+// so the orchestrator doesn't restart instances when the node is down.
+func (s *Health) Liveness() entity.HealthReport {
+	return newHealthReport([]entity.HealthCheck{
+		{
+			Name:   constant.HealthCheckProcess,
+			Status: constant.HealthStatusOK,
+		},
+	})
+}
+
+func (s *Health) Readiness(ctx context.Context) entity.HealthReport {
+	if s.draining.Load() {
+		return newHealthReport([]entity.HealthCheck{
+			{
+				Name:    constant.HealthCheckShutdown,
+				Status:  constant.HealthStatusFail,
+				Message: "instance is shutting down",
+			},
+		})
+	}
+
+	nodeCheck, headBlock, headOk := s.checkNode(ctx)
+
+	return newHealthReport([]entity.HealthCheck{
+		nodeCheck,
+		s.checkParsing(),
+		s.checkLag(ctx, headBlock, headOk),
+	})
+}
+
+// Drain makes the instance not ready, so the load balancer stops routing requests to it before shutdown
+func (s *Health) Drain() {
+	s.draining.Store(true)
+}
+
+func (s *Health) checkNode(ctx context.Context) (entity.HealthCheck, entity.BlockNumber, bool) {
+	check := entity.HealthCheck{
+		Name: constant.HealthCheckNode,
+	}
+
+	// the error isn't returned because probes are public and the node URL may contain credentials
+	headBlock, err := s.blockChainClient.GetBlockNumber(ctx)
+	if err != nil {
+		s.log.Warn("fail get block number in readiness check", logger.Err(err))
+
+		check.Status = constant.HealthStatusFail
+		check.Message = "node is unavailable or responded with error"
+		if errors.Is(err, errorpkg.TimeoutErr) {
+			check.Message = "node doesn't respond in time"
+		}
+
+		return check, 0, false
+	}
+
+	check.Status = constant.HealthStatusOK
+	check.Details = map[string]string{
+		"headBlock": headBlock.Hex(),
+	}
+
+	return check, headBlock, true
+}
+
+func (s *Health) checkParsing() entity.HealthCheck {
+	check := entity.HealthCheck{
+		Name:   constant.HealthCheckParsing,
+		Status: constant.HealthStatusOK,
+		Details: map[string]string{
+			"maxAge": s.maxParseAge.String(),
+		},
+	}
+
+	lastRunAt := s.parserRuns.LastRunAt()
+	if lastRunAt.IsZero() {
+		check.Status = constant.HealthStatusFail
+		check.Message = "parser workers haven't run yet"
+
+		return check
+	}
+
+	age := time.Since(lastRunAt)
+	check.Details["lastRunAt"] = lastRunAt.UTC().Format(time.RFC3339)
+	check.Details["age"] = age.Truncate(time.Millisecond).String()
+
+	if age > s.maxParseAge {
+		check.Status = constant.HealthStatusFail
+		check.Message = "last parse attempt is too old"
+	}
+
+	return check
+}
+
+func (s *Health) checkLag(ctx context.Context, headBlock entity.BlockNumber, headOk bool) entity.HealthCheck {
+	check := entity.HealthCheck{
+		Name:   constant.HealthCheckLag,
+		Status: constant.HealthStatusOK,
+		Details: map[string]string{
+			"maxLag": strconv.FormatUint(s.maxBlockLag, 10),
+		},
+	}
+
+	if !headOk {
+		check.Status = constant.HealthStatusFail
+		check.Message = "head block of the node is unknown"
+
+		return check
+	}
+
+	var lastParsedBlock entity.BlockNumber
+	block, err := s.blockRepo.GetLastParsedBlock(ctx)
+	switch {
+	case err == nil:
+		lastParsedBlock = block.Number
+	case errors.Is(err, errorpkg.BlockNotFound):
+		// nothing is parsed yet, the whole chain up to the head is the lag
+	default:
+		s.log.Error("fail get last parsed block in readiness check", logger.Err(err))
+
+		check.Status = constant.HealthStatusFail
+		check.Message = "fail get last parsed block"
+
+		return check
+	}
+
+	var lag uint64
+	if headBlock > lastParsedBlock {
+		lag = uint64(headBlock - lastParsedBlock)
+	}
+
+	check.Details["headBlock"] = headBlock.Hex()
+	check.Details["lastParsedBlock"] = lastParsedBlock.Hex()
+	check.Details["lag"] = strconv.FormatUint(lag, 10)
+
+	if lag > s.maxBlockLag {
+		check.Status = constant.HealthStatusFail
+		check.Message = "parsing lags behind the node head"
+	}
+
+	return check
+}
+
+func newHealthReport(checks []entity.HealthCheck) entity.HealthReport {
+	report := entity.HealthReport{
+		Status: constant.HealthStatusOK,
+		Checks: checks,
+	}
+
+	for _, check := range checks {
+		if check.Status != constant.HealthStatusOK {
+			report.Status = constant.HealthStatusFail
+
+			break
+		}
+	}
+
+	return report
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"blockchain-parser/internal/constant"
+	"blockchain-parser/internal/entity"
+	errorpkg "blockchain-parser/internal/error"
+	"blockchain-parser/internal/service/mocks"
+	"blockchain-parser/tools/logger"
+)
+
+func TestHealth_Readiness(t *testing.T) {
+	tests := []struct {
+		name            string
+		headBlock       entity.BlockNumber
+		nodeErr         error
+		lastRunAt       time.Time
+		lastParsedBlock entity.BlockNumber
+		draining        bool
+		want            map[string]string
+		wantStatus      string
+	}{
+		{
+			name:            "ready",
+			headBlock:       0x20,
+			lastRunAt:       time.Now(),
+			lastParsedBlock: 0x1f,
+			want: map[string]string{
+				constant.HealthCheckNode:    constant.HealthStatusOK,
+				constant.HealthCheckParsing: constant.HealthStatusOK,
+				constant.HealthCheckLag:     constant.HealthStatusOK,
+			},
+			wantStatus: constant.HealthStatusOK,
+		},
+		{
+			name:      "node is unreachable",
+			nodeErr:   errorpkg.TimeoutErr,
+			lastRunAt: time.Now(),
+			want: map[string]string{
+				constant.HealthCheckNode:    constant.HealthStatusFail,
+				constant.HealthCheckParsing: constant.HealthStatusOK,
+				constant.HealthCheckLag:     constant.HealthStatusFail,
+			},
+			wantStatus: constant.HealthStatusFail,
+		},
+		{
+			name:            "last parse attempt is too old",
+			headBlock:       0x20,
+			lastRunAt:       time.Now().Add(-time.Hour),
+			lastParsedBlock: 0x20,
+			want: map[string]string{
+				constant.HealthCheckNode:    constant.HealthStatusOK,
+				constant.HealthCheckParsing: constant.HealthStatusFail,
+				constant.HealthCheckLag:     constant.HealthStatusOK,
+			},
+			wantStatus: constant.HealthStatusFail,
+		},
+		{
+			name:            "parsing lags behind the head",
+			headBlock:       0x20,
+			lastRunAt:       time.Now(),
+			lastParsedBlock: 0x10,
+			want: map[string]string{
+				constant.HealthCheckNode:    constant.HealthStatusOK,
+				constant.HealthCheckParsing: constant.HealthStatusOK,
+				constant.HealthCheckLag:     constant.HealthStatusFail,
+			},
+			wantStatus: constant.HealthStatusFail,
+		},
+		{
+			name:            "parser workers haven't run yet",
+			headBlock:       0x20,
+			lastParsedBlock: 0x20,
+			want: map[string]string{
+				constant.HealthCheckNode:    constant.HealthStatusOK,
+				constant.HealthCheckParsing: constant.HealthStatusFail,
+				constant.HealthCheckLag:     constant.HealthStatusOK,
+			},
+			wantStatus: constant.HealthStatusFail,
+		},
+		{
+			name:            "instance is shutting down",
+			headBlock:       0x20,
+			lastRunAt:       time.Now(),
+			lastParsedBlock: 0x20,
+			draining:        true,
+			want: map[string]string{
+				constant.HealthCheckShutdown: constant.HealthStatusFail,
+			},
+			wantStatus: constant.HealthStatusFail,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// checks aren't run while the instance is draining
+			calls := 1
+			if tt.draining {
+				calls = 0
+			}
+
+			ctrl := gomock.NewController(t)
+			blockChainClientMock := mocks.NewMockBlockChainClient(ctrl)
+			blockChainClientMock.EXPECT().GetBlockNumber(gomock.Any()).Return(tt.headBlock, tt.nodeErr).Times(calls)
+
+			blockRepoMock := mocks.NewMockBlockRepository(ctrl)
+			blockRepoMock.EXPECT().GetLastParsedBlock(gomock.Any()).Return(entity.Block{Number: tt.lastParsedBlock}, nil).MaxTimes(1)
+
+			parserRunsMock := mocks.NewMockParserRunTracker(ctrl)
+			parserRunsMock.EXPECT().LastRunAt().Return(tt.lastRunAt).Times(calls)
+
+			s := NewHealth(blockRepoMock, blockChainClientMock, parserRunsMock, time.Minute, 5, logger.Nop())
+			if tt.draining {
+				s.Drain()
+			}
+
+			report := s.Readiness(context.Background())
+			if report.Status != tt.wantStatus {
+				t.Errorf("Readiness() status got = %v, want %v", report.Status, tt.wantStatus)
+			}
+
+			got := map[string]string{}
+			for _, check := range report.Checks {
+				got[check.Name] = check.Status
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Readiness() checks got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// generateID returns random hex ID of length bytes
+func generateID(length int) (string, error) {
+	buf := make([]byte, length)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(buf), nil
+}
@@ -6,8 +6,10 @@ package mocks
 
 import (
 	entity "blockchain-parser/internal/entity"
+	job "blockchain-parser/tools/job"
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -35,19 +37,63 @@ func (m *MockTransactionRepository) EXPECT() *MockTransactionRepositoryMockRecor
 	return m.recorder
 }
 
+// DeleteByBlockNumber mocks base method.
+func (m *MockTransactionRepository) DeleteByBlockNumber(ctx context.Context, blockNumber entity.BlockNumber) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByBlockNumber", ctx, blockNumber)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByBlockNumber indicates an expected call of DeleteByBlockNumber.
+func (mr *MockTransactionRepositoryMockRecorder) DeleteByBlockNumber(ctx, blockNumber interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByBlockNumber", reflect.TypeOf((*MockTransactionRepository)(nil).DeleteByBlockNumber), ctx, blockNumber)
+}
+
+// GetTxnByHash mocks base method.
+func (m *MockTransactionRepository) GetTxnByHash(ctx context.Context, hash string) (entity.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTxnByHash", ctx, hash)
+	ret0, _ := ret[0].(entity.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTxnByHash indicates an expected call of GetTxnByHash.
+func (mr *MockTransactionRepositoryMockRecorder) GetTxnByHash(ctx, hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTxnByHash", reflect.TypeOf((*MockTransactionRepository)(nil).GetTxnByHash), ctx, hash)
+}
+
 // GetTxnsByAddress mocks base method.
-func (m *MockTransactionRepository) GetTxnsByAddress(ctx context.Context, address string) ([]entity.Transaction, error) {
+func (m *MockTransactionRepository) GetTxnsByAddress(ctx context.Context, address entity.Address, filter entity.TransactionFilter) ([]entity.Transaction, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTxnsByAddress", ctx, address)
+	ret := m.ctrl.Call(m, "GetTxnsByAddress", ctx, address, filter)
 	ret0, _ := ret[0].([]entity.Transaction)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTxnsByAddress indicates an expected call of GetTxnsByAddress.
-func (mr *MockTransactionRepositoryMockRecorder) GetTxnsByAddress(ctx, address interface{}) *gomock.Call {
+func (mr *MockTransactionRepositoryMockRecorder) GetTxnsByAddress(ctx, address, filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTxnsByAddress", reflect.TypeOf((*MockTransactionRepository)(nil).GetTxnsByAddress), ctx, address)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTxnsByAddress", reflect.TypeOf((*MockTransactionRepository)(nil).GetTxnsByAddress), ctx, address, filter)
+}
+
+// GetTxnsByBlockNumber mocks base method.
+func (m *MockTransactionRepository) GetTxnsByBlockNumber(ctx context.Context, blockNumber entity.BlockNumber) ([]entity.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTxnsByBlockNumber", ctx, blockNumber)
+	ret0, _ := ret[0].([]entity.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTxnsByBlockNumber indicates an expected call of GetTxnsByBlockNumber.
+func (mr *MockTransactionRepositoryMockRecorder) GetTxnsByBlockNumber(ctx, blockNumber interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTxnsByBlockNumber", reflect.TypeOf((*MockTransactionRepository)(nil).GetTxnsByBlockNumber), ctx, blockNumber)
 }
 
 // Save mocks base method.
@@ -88,7 +134,7 @@ func (m *MockSubscriberRepository) EXPECT() *MockSubscriberRepositoryMockRecorde
 }
 
 // Get mocks base method.
-func (m *MockSubscriberRepository) Get(arg0 context.Context, address string) (entity.Subscriber, error) {
+func (m *MockSubscriberRepository) Get(arg0 context.Context, address entity.Address) (entity.Subscriber, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Get", arg0, address)
 	ret0, _ := ret[0].(entity.Subscriber)
@@ -116,6 +162,480 @@ func (mr *MockSubscriberRepositoryMockRecorder) Save(arg0, subscriber interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockSubscriberRepository)(nil).Save), arg0, subscriber)
 }
 
+// MockSubscriptionRepository is a mock of SubscriptionRepository interface.
+type MockSubscriptionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSubscriptionRepositoryMockRecorder
+}
+
+// MockSubscriptionRepositoryMockRecorder is the mock recorder for MockSubscriptionRepository.
+type MockSubscriptionRepositoryMockRecorder struct {
+	mock *MockSubscriptionRepository
+}
+
+// NewMockSubscriptionRepository creates a new mock instance.
+func NewMockSubscriptionRepository(ctrl *gomock.Controller) *MockSubscriptionRepository {
+	mock := &MockSubscriptionRepository{ctrl: ctrl}
+	mock.recorder = &MockSubscriptionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSubscriptionRepository) EXPECT() *MockSubscriptionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockSubscriptionRepository) Delete(ctx context.Context, tenantID string, address entity.Address) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, tenantID, address)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSubscriptionRepositoryMockRecorder) Delete(ctx, tenantID, address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSubscriptionRepository)(nil).Delete), ctx, tenantID, address)
+}
+
+// Get mocks base method.
+func (m *MockSubscriptionRepository) Get(ctx context.Context, tenantID string, address entity.Address) (entity.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, tenantID, address)
+	ret0, _ := ret[0].(entity.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockSubscriptionRepositoryMockRecorder) Get(ctx, tenantID, address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockSubscriptionRepository)(nil).Get), ctx, tenantID, address)
+}
+
+// SaveWithinLimit mocks base method.
+func (m *MockSubscriptionRepository) SaveWithinLimit(ctx context.Context, subscriptions []entity.Subscription, limit int) ([]entity.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveWithinLimit", ctx, subscriptions, limit)
+	ret0, _ := ret[0].([]entity.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveWithinLimit indicates an expected call of SaveWithinLimit.
+func (mr *MockSubscriptionRepositoryMockRecorder) SaveWithinLimit(ctx, subscriptions, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveWithinLimit", reflect.TypeOf((*MockSubscriptionRepository)(nil).SaveWithinLimit), ctx, subscriptions, limit)
+}
+
+// MockWalletRepository is a mock of WalletRepository interface.
+type MockWalletRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWalletRepositoryMockRecorder
+}
+
+// MockWalletRepositoryMockRecorder is the mock recorder for MockWalletRepository.
+type MockWalletRepositoryMockRecorder struct {
+	mock *MockWalletRepository
+}
+
+// NewMockWalletRepository creates a new mock instance.
+func NewMockWalletRepository(ctrl *gomock.Controller) *MockWalletRepository {
+	mock := &MockWalletRepository{ctrl: ctrl}
+	mock.recorder = &MockWalletRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWalletRepository) EXPECT() *MockWalletRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockWalletRepository) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockWalletRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWalletRepository)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockWalletRepository) Get(ctx context.Context, id string) (entity.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(entity.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockWalletRepositoryMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockWalletRepository)(nil).Get), ctx, id)
+}
+
+// GetByTenant mocks base method.
+func (m *MockWalletRepository) GetByTenant(ctx context.Context, tenantID string) ([]entity.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByTenant", ctx, tenantID)
+	ret0, _ := ret[0].([]entity.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByTenant indicates an expected call of GetByTenant.
+func (mr *MockWalletRepositoryMockRecorder) GetByTenant(ctx, tenantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTenant", reflect.TypeOf((*MockWalletRepository)(nil).GetByTenant), ctx, tenantID)
+}
+
+// Save mocks base method.
+func (m *MockWalletRepository) Save(ctx context.Context, wallet entity.Wallet) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, wallet)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockWalletRepositoryMockRecorder) Save(ctx, wallet interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockWalletRepository)(nil).Save), ctx, wallet)
+}
+
+// MockTenantRepository is a mock of TenantRepository interface.
+type MockTenantRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTenantRepositoryMockRecorder
+}
+
+// MockTenantRepositoryMockRecorder is the mock recorder for MockTenantRepository.
+type MockTenantRepositoryMockRecorder struct {
+	mock *MockTenantRepository
+}
+
+// NewMockTenantRepository creates a new mock instance.
+func NewMockTenantRepository(ctrl *gomock.Controller) *MockTenantRepository {
+	mock := &MockTenantRepository{ctrl: ctrl}
+	mock.recorder = &MockTenantRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTenantRepository) EXPECT() *MockTenantRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockTenantRepository) Get(ctx context.Context, id string) (entity.Tenant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(entity.Tenant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockTenantRepositoryMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockTenantRepository)(nil).Get), ctx, id)
+}
+
+// GetAll mocks base method.
+func (m *MockTenantRepository) GetAll(ctx context.Context) ([]entity.Tenant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret0, _ := ret[0].([]entity.Tenant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockTenantRepositoryMockRecorder) GetAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTenantRepository)(nil).GetAll), ctx)
+}
+
+// Save mocks base method.
+func (m *MockTenantRepository) Save(ctx context.Context, tenant entity.Tenant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, tenant)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockTenantRepositoryMockRecorder) Save(ctx, tenant interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockTenantRepository)(nil).Save), ctx, tenant)
+}
+
+// MockAPIKeyRepository is a mock of APIKeyRepository interface.
+type MockAPIKeyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyRepositoryMockRecorder
+}
+
+// MockAPIKeyRepositoryMockRecorder is the mock recorder for MockAPIKeyRepository.
+type MockAPIKeyRepositoryMockRecorder struct {
+	mock *MockAPIKeyRepository
+}
+
+// NewMockAPIKeyRepository creates a new mock instance.
+func NewMockAPIKeyRepository(ctrl *gomock.Controller) *MockAPIKeyRepository {
+	mock := &MockAPIKeyRepository{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeyRepository) EXPECT() *MockAPIKeyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockAPIKeyRepository) Get(ctx context.Context, id string) (entity.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(entity.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockAPIKeyRepositoryMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockAPIKeyRepository)(nil).Get), ctx, id)
+}
+
+// GetByHash mocks base method.
+func (m *MockAPIKeyRepository) GetByHash(ctx context.Context, hash string) (entity.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByHash", ctx, hash)
+	ret0, _ := ret[0].(entity.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByHash indicates an expected call of GetByHash.
+func (mr *MockAPIKeyRepositoryMockRecorder) GetByHash(ctx, hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByHash", reflect.TypeOf((*MockAPIKeyRepository)(nil).GetByHash), ctx, hash)
+}
+
+// GetByTenant mocks base method.
+func (m *MockAPIKeyRepository) GetByTenant(ctx context.Context, tenantID string) ([]entity.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByTenant", ctx, tenantID)
+	ret0, _ := ret[0].([]entity.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByTenant indicates an expected call of GetByTenant.
+func (mr *MockAPIKeyRepositoryMockRecorder) GetByTenant(ctx, tenantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTenant", reflect.TypeOf((*MockAPIKeyRepository)(nil).GetByTenant), ctx, tenantID)
+}
+
+// Save mocks base method.
+func (m *MockAPIKeyRepository) Save(ctx context.Context, key entity.APIKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockAPIKeyRepositoryMockRecorder) Save(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockAPIKeyRepository)(nil).Save), ctx, key)
+}
+
+// MockBalanceRepository is a mock of BalanceRepository interface.
+type MockBalanceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBalanceRepositoryMockRecorder
+}
+
+// MockBalanceRepositoryMockRecorder is the mock recorder for MockBalanceRepository.
+type MockBalanceRepositoryMockRecorder struct {
+	mock *MockBalanceRepository
+}
+
+// NewMockBalanceRepository creates a new mock instance.
+func NewMockBalanceRepository(ctrl *gomock.Controller) *MockBalanceRepository {
+	mock := &MockBalanceRepository{ctrl: ctrl}
+	mock.recorder = &MockBalanceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBalanceRepository) EXPECT() *MockBalanceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteByBlockNumber mocks base method.
+func (m *MockBalanceRepository) DeleteByBlockNumber(ctx context.Context, blockNumber entity.BlockNumber) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByBlockNumber", ctx, blockNumber)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByBlockNumber indicates an expected call of DeleteByBlockNumber.
+func (mr *MockBalanceRepositoryMockRecorder) DeleteByBlockNumber(ctx, blockNumber interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByBlockNumber", reflect.TypeOf((*MockBalanceRepository)(nil).DeleteByBlockNumber), ctx, blockNumber)
+}
+
+// GetChanges mocks base method.
+func (m *MockBalanceRepository) GetChanges(ctx context.Context, address entity.Address, fromBlock, toBlock entity.BlockNumber) ([]entity.BalanceChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChanges", ctx, address, fromBlock, toBlock)
+	ret0, _ := ret[0].([]entity.BalanceChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChanges indicates an expected call of GetChanges.
+func (mr *MockBalanceRepositoryMockRecorder) GetChanges(ctx, address, fromBlock, toBlock interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChanges", reflect.TypeOf((*MockBalanceRepository)(nil).GetChanges), ctx, address, fromBlock, toBlock)
+}
+
+// GetSnapshot mocks base method.
+func (m *MockBalanceRepository) GetSnapshot(ctx context.Context, address entity.Address) (entity.BalanceSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSnapshot", ctx, address)
+	ret0, _ := ret[0].(entity.BalanceSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSnapshot indicates an expected call of GetSnapshot.
+func (mr *MockBalanceRepositoryMockRecorder) GetSnapshot(ctx, address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSnapshot", reflect.TypeOf((*MockBalanceRepository)(nil).GetSnapshot), ctx, address)
+}
+
+// GetSnapshots mocks base method.
+func (m *MockBalanceRepository) GetSnapshots(ctx context.Context) ([]entity.BalanceSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSnapshots", ctx)
+	ret0, _ := ret[0].([]entity.BalanceSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSnapshots indicates an expected call of GetSnapshots.
+func (mr *MockBalanceRepositoryMockRecorder) GetSnapshots(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSnapshots", reflect.TypeOf((*MockBalanceRepository)(nil).GetSnapshots), ctx)
+}
+
+// SaveChange mocks base method.
+func (m *MockBalanceRepository) SaveChange(ctx context.Context, change entity.BalanceChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveChange", ctx, change)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveChange indicates an expected call of SaveChange.
+func (mr *MockBalanceRepositoryMockRecorder) SaveChange(ctx, change interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveChange", reflect.TypeOf((*MockBalanceRepository)(nil).SaveChange), ctx, change)
+}
+
+// SaveSnapshot mocks base method.
+func (m *MockBalanceRepository) SaveSnapshot(ctx context.Context, snapshot entity.BalanceSnapshot) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSnapshot", ctx, snapshot)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSnapshot indicates an expected call of SaveSnapshot.
+func (mr *MockBalanceRepositoryMockRecorder) SaveSnapshot(ctx, snapshot interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSnapshot", reflect.TypeOf((*MockBalanceRepository)(nil).SaveSnapshot), ctx, snapshot)
+}
+
+// MockReparseTaskRepository is a mock of ReparseTaskRepository interface.
+type MockReparseTaskRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReparseTaskRepositoryMockRecorder
+}
+
+// MockReparseTaskRepositoryMockRecorder is the mock recorder for MockReparseTaskRepository.
+type MockReparseTaskRepositoryMockRecorder struct {
+	mock *MockReparseTaskRepository
+}
+
+// NewMockReparseTaskRepository creates a new mock instance.
+func NewMockReparseTaskRepository(ctrl *gomock.Controller) *MockReparseTaskRepository {
+	mock := &MockReparseTaskRepository{ctrl: ctrl}
+	mock.recorder = &MockReparseTaskRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReparseTaskRepository) EXPECT() *MockReparseTaskRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockReparseTaskRepository) Get(ctx context.Context, id string) (entity.ReparseTask, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(entity.ReparseTask)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockReparseTaskRepositoryMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockReparseTaskRepository)(nil).Get), ctx, id)
+}
+
+// GetAll mocks base method.
+func (m *MockReparseTaskRepository) GetAll(ctx context.Context) ([]entity.ReparseTask, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret0, _ := ret[0].([]entity.ReparseTask)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockReparseTaskRepositoryMockRecorder) GetAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockReparseTaskRepository)(nil).GetAll), ctx)
+}
+
+// Save mocks base method.
+func (m *MockReparseTaskRepository) Save(ctx context.Context, task entity.ReparseTask) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, task)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockReparseTaskRepositoryMockRecorder) Save(ctx, task interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockReparseTaskRepository)(nil).Save), ctx, task)
+}
+
 // MockBlockRepository is a mock of BlockRepository interface.
 type MockBlockRepository struct {
 	ctrl     *gomock.Controller
@@ -184,6 +704,21 @@ func (mr *MockBlockRepositoryMockRecorder) GetLastParsedBlock(ctx interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastParsedBlock", reflect.TypeOf((*MockBlockRepository)(nil).GetLastParsedBlock), ctx)
 }
 
+// GetParsedBlock mocks base method.
+func (m *MockBlockRepository) GetParsedBlock(ctx context.Context, blockNumber entity.BlockNumber) (entity.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetParsedBlock", ctx, blockNumber)
+	ret0, _ := ret[0].(entity.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetParsedBlock indicates an expected call of GetParsedBlock.
+func (mr *MockBlockRepositoryMockRecorder) GetParsedBlock(ctx, blockNumber interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetParsedBlock", reflect.TypeOf((*MockBlockRepository)(nil).GetParsedBlock), ctx, blockNumber)
+}
+
 // Upsert mocks base method.
 func (m *MockBlockRepository) Upsert(ctx context.Context, block entity.Block) error {
 	m.ctrl.T.Helper()
@@ -221,11 +756,41 @@ func (m *MockBlockChainClient) EXPECT() *MockBlockChainClientMockRecorder {
 	return m.recorder
 }
 
+// GetBalance mocks base method.
+func (m *MockBlockChainClient) GetBalance(ctx context.Context, address entity.Address, blockNumber entity.BlockNumber) (entity.Wei, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalance", ctx, address, blockNumber)
+	ret0, _ := ret[0].(entity.Wei)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalance indicates an expected call of GetBalance.
+func (mr *MockBlockChainClientMockRecorder) GetBalance(ctx, address, blockNumber interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalance", reflect.TypeOf((*MockBlockChainClient)(nil).GetBalance), ctx, address, blockNumber)
+}
+
+// GetBlockByNumber mocks base method.
+func (m *MockBlockChainClient) GetBlockByNumber(ctx context.Context, blockNumber entity.BlockNumber) (entity.ChainBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockByNumber", ctx, blockNumber)
+	ret0, _ := ret[0].(entity.ChainBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockByNumber indicates an expected call of GetBlockByNumber.
+func (mr *MockBlockChainClientMockRecorder) GetBlockByNumber(ctx, blockNumber interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockByNumber", reflect.TypeOf((*MockBlockChainClient)(nil).GetBlockByNumber), ctx, blockNumber)
+}
+
 // GetBlockNumber mocks base method.
-func (m *MockBlockChainClient) GetBlockNumber(ctx context.Context) (int, error) {
+func (m *MockBlockChainClient) GetBlockNumber(ctx context.Context) (entity.BlockNumber, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetBlockNumber", ctx)
-	ret0, _ := ret[0].(int)
+	ret0, _ := ret[0].(entity.BlockNumber)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -236,19 +801,116 @@ func (mr *MockBlockChainClientMockRecorder) GetBlockNumber(ctx interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockNumber", reflect.TypeOf((*MockBlockChainClient)(nil).GetBlockNumber), ctx)
 }
 
-// GetTxnsByBlockByNumber mocks base method.
-func (m *MockBlockChainClient) GetTxnsByBlockByNumber(ctx context.Context, blockNumber int) ([]entity.Transaction, error) {
+// GetInternalTransfers mocks base method.
+func (m *MockBlockChainClient) GetInternalTransfers(ctx context.Context, blockNumber entity.BlockNumber) ([]entity.InternalTransfer, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTxnsByBlockByNumber", ctx, blockNumber)
-	ret0, _ := ret[0].([]entity.Transaction)
+	ret := m.ctrl.Call(m, "GetInternalTransfers", ctx, blockNumber)
+	ret0, _ := ret[0].([]entity.InternalTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInternalTransfers indicates an expected call of GetInternalTransfers.
+func (mr *MockBlockChainClientMockRecorder) GetInternalTransfers(ctx, blockNumber interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInternalTransfers", reflect.TypeOf((*MockBlockChainClient)(nil).GetInternalTransfers), ctx, blockNumber)
+}
+
+// GetTransactionByHash mocks base method.
+func (m *MockBlockChainClient) GetTransactionByHash(ctx context.Context, hash string) (entity.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransactionByHash", ctx, hash)
+	ret0, _ := ret[0].(entity.Transaction)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTxnsByBlockByNumber indicates an expected call of GetTxnsByBlockByNumber.
-func (mr *MockBlockChainClientMockRecorder) GetTxnsByBlockByNumber(ctx, blockNumber interface{}) *gomock.Call {
+// GetTransactionByHash indicates an expected call of GetTransactionByHash.
+func (mr *MockBlockChainClientMockRecorder) GetTransactionByHash(ctx, hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionByHash", reflect.TypeOf((*MockBlockChainClient)(nil).GetTransactionByHash), ctx, hash)
+}
+
+// GetTransactionReceipt mocks base method.
+func (m *MockBlockChainClient) GetTransactionReceipt(ctx context.Context, hash string) (entity.TransactionReceipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransactionReceipt", ctx, hash)
+	ret0, _ := ret[0].(entity.TransactionReceipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransactionReceipt indicates an expected call of GetTransactionReceipt.
+func (mr *MockBlockChainClientMockRecorder) GetTransactionReceipt(ctx, hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionReceipt", reflect.TypeOf((*MockBlockChainClient)(nil).GetTransactionReceipt), ctx, hash)
+}
+
+// MockEventRepository is a mock of EventRepository interface.
+type MockEventRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventRepositoryMockRecorder
+}
+
+// MockEventRepositoryMockRecorder is the mock recorder for MockEventRepository.
+type MockEventRepositoryMockRecorder struct {
+	mock *MockEventRepository
+}
+
+// NewMockEventRepository creates a new mock instance.
+func NewMockEventRepository(ctrl *gomock.Controller) *MockEventRepository {
+	mock := &MockEventRepository{ctrl: ctrl}
+	mock.recorder = &MockEventRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventRepository) EXPECT() *MockEventRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetEventsAfter mocks base method.
+func (m *MockEventRepository) GetEventsAfter(ctx context.Context, afterID uint64, addresses []entity.Address, limit int) ([]entity.Event, uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEventsAfter", ctx, afterID, addresses, limit)
+	ret0, _ := ret[0].([]entity.Event)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetEventsAfter indicates an expected call of GetEventsAfter.
+func (mr *MockEventRepositoryMockRecorder) GetEventsAfter(ctx, afterID, addresses, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEventsAfter", reflect.TypeOf((*MockEventRepository)(nil).GetEventsAfter), ctx, afterID, addresses, limit)
+}
+
+// Save mocks base method.
+func (m *MockEventRepository) Save(ctx context.Context, event entity.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockEventRepositoryMockRecorder) Save(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockEventRepository)(nil).Save), ctx, event)
+}
+
+// WaitEvents mocks base method.
+func (m *MockEventRepository) WaitEvents(ctx context.Context, afterID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitEvents", ctx, afterID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WaitEvents indicates an expected call of WaitEvents.
+func (mr *MockEventRepositoryMockRecorder) WaitEvents(ctx, afterID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTxnsByBlockByNumber", reflect.TypeOf((*MockBlockChainClient)(nil).GetTxnsByBlockByNumber), ctx, blockNumber)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitEvents", reflect.TypeOf((*MockEventRepository)(nil).WaitEvents), ctx, afterID)
 }
 
 // MockLocker is a mock of Locker interface.
@@ -297,3 +959,195 @@ func (mr *MockLockerMockRecorder) Unlock() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unlock", reflect.TypeOf((*MockLocker)(nil).Unlock))
 }
+
+// MockParserRunTracker is a mock of ParserRunTracker interface.
+type MockParserRunTracker struct {
+	ctrl     *gomock.Controller
+	recorder *MockParserRunTrackerMockRecorder
+}
+
+// MockParserRunTrackerMockRecorder is the mock recorder for MockParserRunTracker.
+type MockParserRunTrackerMockRecorder struct {
+	mock *MockParserRunTracker
+}
+
+// NewMockParserRunTracker creates a new mock instance.
+func NewMockParserRunTracker(ctrl *gomock.Controller) *MockParserRunTracker {
+	mock := &MockParserRunTracker{ctrl: ctrl}
+	mock.recorder = &MockParserRunTrackerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockParserRunTracker) EXPECT() *MockParserRunTrackerMockRecorder {
+	return m.recorder
+}
+
+// LastRunAt mocks base method.
+func (m *MockParserRunTracker) LastRunAt() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastRunAt")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// LastRunAt indicates an expected call of LastRunAt.
+func (mr *MockParserRunTrackerMockRecorder) LastRunAt() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastRunAt", reflect.TypeOf((*MockParserRunTracker)(nil).LastRunAt))
+}
+
+// MockParserRunner is a mock of ParserRunner interface.
+type MockParserRunner struct {
+	ctrl     *gomock.Controller
+	recorder *MockParserRunnerMockRecorder
+}
+
+// MockParserRunnerMockRecorder is the mock recorder for MockParserRunner.
+type MockParserRunnerMockRecorder struct {
+	mock *MockParserRunner
+}
+
+// NewMockParserRunner creates a new mock instance.
+func NewMockParserRunner(ctrl *gomock.Controller) *MockParserRunner {
+	mock := &MockParserRunner{ctrl: ctrl}
+	mock.recorder = &MockParserRunnerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockParserRunner) EXPECT() *MockParserRunnerMockRecorder {
+	return m.recorder
+}
+
+// Run mocks base method.
+func (m *MockParserRunner) Run(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Run", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockParserRunnerMockRecorder) Run(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockParserRunner)(nil).Run), ctx)
+}
+
+// SetPaused mocks base method.
+func (m *MockParserRunner) SetPaused(paused bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetPaused", paused)
+}
+
+// SetPaused indicates an expected call of SetPaused.
+func (mr *MockParserRunnerMockRecorder) SetPaused(paused interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPaused", reflect.TypeOf((*MockParserRunner)(nil).SetPaused), paused)
+}
+
+// MockJobScheduler is a mock of JobScheduler interface.
+type MockJobScheduler struct {
+	ctrl     *gomock.Controller
+	recorder *MockJobSchedulerMockRecorder
+}
+
+// MockJobSchedulerMockRecorder is the mock recorder for MockJobScheduler.
+type MockJobSchedulerMockRecorder struct {
+	mock *MockJobScheduler
+}
+
+// NewMockJobScheduler creates a new mock instance.
+func NewMockJobScheduler(ctrl *gomock.Controller) *MockJobScheduler {
+	mock := &MockJobScheduler{ctrl: ctrl}
+	mock.recorder = &MockJobSchedulerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJobScheduler) EXPECT() *MockJobSchedulerMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockJobScheduler) Add(job *job.Job) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Add", job)
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockJobSchedulerMockRecorder) Add(job interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockJobScheduler)(nil).Add), job)
+}
+
+// Pause mocks base method.
+func (m *MockJobScheduler) Pause(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pause", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Pause indicates an expected call of Pause.
+func (mr *MockJobSchedulerMockRecorder) Pause(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pause", reflect.TypeOf((*MockJobScheduler)(nil).Pause), id)
+}
+
+// Remove mocks base method.
+func (m *MockJobScheduler) Remove(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockJobSchedulerMockRecorder) Remove(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockJobScheduler)(nil).Remove), ctx, id)
+}
+
+// Reschedule mocks base method.
+func (m *MockJobScheduler) Reschedule(id string, schedule job.Schedule, policy job.Policy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reschedule", id, schedule, policy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reschedule indicates an expected call of Reschedule.
+func (mr *MockJobSchedulerMockRecorder) Reschedule(id, schedule, policy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reschedule", reflect.TypeOf((*MockJobScheduler)(nil).Reschedule), id, schedule, policy)
+}
+
+// Resume mocks base method.
+func (m *MockJobScheduler) Resume(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resume", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Resume indicates an expected call of Resume.
+func (mr *MockJobSchedulerMockRecorder) Resume(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resume", reflect.TypeOf((*MockJobScheduler)(nil).Resume), id)
+}
+
+// Status mocks base method.
+func (m *MockJobScheduler) Status(id string) (job.Status, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Status", id)
+	ret0, _ := ret[0].(job.Status)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Status indicates an expected call of Status.
+func (mr *MockJobSchedulerMockRecorder) Status(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockJobScheduler)(nil).Status), id)
+}
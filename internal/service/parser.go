@@ -2,61 +2,248 @@ package service
 
 import (
 	"context"
-	"log"
+	"errors"
+	"fmt"
 
+	"blockchain-parser/internal/constant"
 	"blockchain-parser/internal/entity"
+	errorpkg "blockchain-parser/internal/error"
 )
 
-// in my view methods shoud return error
 type Parser struct {
-	txnRepo        TransactionRepository
-	subscriberRepo SubscriberRepository
-	blockRepo      BlockRepository
+	txnRepo          TransactionRepository
+	subscriberRepo   SubscriberRepository
+	subscriptionRepo SubscriptionRepository
+	tenantRepo       TenantRepository
+	blockRepo        BlockRepository
+	balanceRepo      BalanceRepository
+	blockChainClient BlockChainClient
+
+	maxSubscriptions int
 }
 
 func NewParser(
 	txnRepo TransactionRepository,
 	subscriberRepo SubscriberRepository,
+	subscriptionRepo SubscriptionRepository,
+	tenantRepo TenantRepository,
 	blockRepo BlockRepository,
+	balanceRepo BalanceRepository,
+	blockChainClient BlockChainClient,
+	maxSubscriptions int,
 ) *Parser {
 
 	return &Parser{
-		txnRepo:        txnRepo,
-		subscriberRepo: subscriberRepo,
-		blockRepo:      blockRepo,
+		txnRepo:          txnRepo,
+		subscriberRepo:   subscriberRepo,
+		subscriptionRepo: subscriptionRepo,
+		tenantRepo:       tenantRepo,
+		blockRepo:        blockRepo,
+		balanceRepo:      balanceRepo,
+		blockChainClient: blockChainClient,
+		maxSubscriptions: maxSubscriptions,
+	}
+}
+
+func (p *Parser) GetCurrentBlock(ctx context.Context) (entity.BlockNumber, error) {
+	block, err := p.blockRepo.GetLastParsedBlock(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("fail get last parsed block in GetCurrentBlock: %w", err)
+	}
+
+	return block.Number, nil
+}
+
+// GetBlock returns the parsed block, blocks which aren't parsed yet are not found.
+func (p *Parser) GetBlock(ctx context.Context, blockNumber entity.BlockNumber) (entity.Block, error) {
+	block, err := p.blockRepo.GetParsedBlock(ctx, blockNumber)
+	if err != nil {
+		return entity.Block{}, fmt.Errorf("fail get block (%d) in GetBlock: %w", blockNumber, err)
+	}
+
+	return block, nil
+}
+
+// Subscribe subscribes the tenant to the address. Transactions of the address are parsed once
+// for all tenants subscribed to it. Subscriptions of the tenant are capped.
+func (p *Parser) Subscribe(ctx context.Context, tenantID string, address entity.Address) error {
+	err := subscribe(
+		ctx,
+		p.subscriberRepo,
+		p.subscriptionRepo,
+		p.tenantRepo,
+		p.blockRepo,
+		p.balanceRepo,
+		p.blockChainClient,
+		p.maxSubscriptions,
+		tenantID,
+		[]entity.Address{address},
+	)
+	if err != nil {
+		return fmt.Errorf("fail subscribe address (%s) in Subscribe: %w", address, err)
+	}
+
+	return nil
+}
+
+// Unsubscribe removes the subscription of the tenant to the address. The address is still parsed, it's shared
+// with other tenants and wallets, and transactions parsed so far are kept. Subscribing again shows transactions
+// of blocks parsed after the new subscription, the ones parsed meanwhile are indexed for other tenants.
+func (p *Parser) Unsubscribe(ctx context.Context, tenantID string, address entity.Address) error {
+	if err := p.subscriptionRepo.Delete(ctx, tenantID, address); err != nil {
+		return fmt.Errorf("fail delete subscription to address (%s) in Unsubscribe: %w", address, err)
+	}
+
+	return nil
+}
+
+// GetTransactions returns a page of transactions of the address subscribed by the tenant from the first block
+// of the subscription. One extra transaction is requested to find out whether the next page exists.
+func (p *Parser) GetTransactions(ctx context.Context, tenantID string, address entity.Address, filter entity.TransactionFilter) (entity.TransactionPage, error) {
+	subscription, err := p.subscriptionRepo.Get(ctx, tenantID, address)
+	if err != nil {
+		return entity.TransactionPage{}, fmt.Errorf("fail get subscription in GetTransactions: %w", err)
+	}
+
+	filter = subscriptionFilter(subscription, filter)
+
+	limit := filter.Limit
+	if limit > 0 {
+		filter.Limit = limit + 1
+	}
+
+	txns, err := p.txnRepo.GetTxnsByAddress(ctx, address, filter)
+	if err != nil {
+		return entity.TransactionPage{}, fmt.Errorf("fail get trasactions for address (%s) in GetTransactions: %w", address, err)
+	}
+
+	page := entity.TransactionPage{
+		Transactions: txns,
+	}
+
+	if limit > 0 && len(txns) > limit {
+		page.Transactions = txns[:limit]
+
+		next := txns[limit-1].Cursor()
+		page.Next = &next
 	}
+
+	return page, nil
 }
 
-func (p *Parser) GetCurrentBlock() int {
-	block, err := p.blockRepo.GetLastParsedBlock(context.Background())
+// GetTransaction looks for the transaction in the store. Unknown transaction is fetched from the node
+// and the reason why it wasn't stored is explained. Stored transaction of addresses the tenant isn't subscribed to,
+// or of blocks parsed before its subscription, is explained the same way, so tenants don't learn subscriptions of each other.
+func (p *Parser) GetTransaction(ctx context.Context, tenantID, hash string) (entity.TransactionLookup, error) {
+	txn, err := p.txnRepo.GetTxnByHash(ctx, hash)
+	switch {
+	case err == nil:
+		reason, err := p.getVisibility(ctx, tenantID, txn)
+		if err != nil {
+			return entity.TransactionLookup{}, fmt.Errorf("fail check subscriptions of transaction (%s) in GetTransaction: %w", hash, err)
+		}
+
+		if reason == constant.TransactionLookupReasonIndexed {
+			return entity.TransactionLookup{
+				Transaction: txn,
+				Reason:      reason,
+			}, nil
+		}
+
+		// stored fields, e.g. the timestamp, would tell that another tenant watches the address,
+		// so the transaction is answered from the node as if it wasn't stored
+		txn, err = p.blockChainClient.GetTransactionByHash(ctx, hash)
+		if err != nil {
+			return entity.TransactionLookup{}, fmt.Errorf("fail get transaction (%s) from node in GetTransaction: %w", hash, err)
+		}
+
+		return entity.TransactionLookup{
+			Transaction: txn,
+			Reason:      reason,
+		}, nil
+	case errors.Is(err, errorpkg.TransactionNotFound):
+		txn, err = p.blockChainClient.GetTransactionByHash(ctx, hash)
+		if err != nil {
+			return entity.TransactionLookup{}, fmt.Errorf("fail get transaction (%s) from node in GetTransaction: %w", hash, err)
+		}
+	default:
+		return entity.TransactionLookup{}, fmt.Errorf("fail get transaction (%s) in GetTransaction: %w", hash, err)
+	}
+
+	reason, err := p.explainMissingTransaction(ctx, tenantID, txn)
 	if err != nil {
-		log.Printf("fail get current block: %s\n", err)
+		return entity.TransactionLookup{}, fmt.Errorf("fail explain transaction (%s) in GetTransaction: %w", hash, err)
+	}
 
-		return 0
+	return entity.TransactionLookup{
+		Transaction: txn,
+		Reason:      reason,
+	}, nil
+}
+
+// getVisibility returns indexed reason if the transaction is shown to the tenant, i.e. the tenant is subscribed
+// to its sender or receiver from its block. Otherwise the reason why it's hidden is returned.
+func (p *Parser) getVisibility(ctx context.Context, tenantID string, txn entity.Transaction) (string, error) {
+	subscribed := false
+	for _, address := range []entity.Address{txn.From, txn.To} {
+		subscription, ok, err := getTenantSubscription(ctx, p.subscriptionRepo, tenantID, address)
+		if err != nil {
+			return "", err
+		}
+		if !ok {
+			continue
+		}
+
+		if txn.BlockNumber >= subscription.FromBlock {
+			return constant.TransactionLookupReasonIndexed, nil
+		}
+		subscribed = true
 	}
 
-	return block.Number
+	if subscribed {
+		return constant.TransactionLookupReasonSubscribedAfterParsing, nil
+	}
+
+	return constant.TransactionLookupReasonNotSubscribed, nil
 }
 
-func (p *Parser) Subscribe(address string) bool {
-	subscriber := entity.Subscriber{
-		Address: address,
+func (p *Parser) explainMissingTransaction(ctx context.Context, tenantID string, txn entity.Transaction) (string, error) {
+	if txn.Pending {
+		return constant.TransactionLookupReasonPending, nil
+	}
+
+	_, err := p.blockRepo.GetParsedBlock(ctx, txn.BlockNumber)
+	if errors.Is(err, errorpkg.BlockNotFound) {
+		return constant.TransactionLookupReasonBlockNotParsed, nil
 	}
-	err := p.subscriberRepo.Save(context.Background(), subscriber)
 	if err != nil {
-		log.Printf("fail subscribe address (%s): %s\n", address, err)
+		return "", err
+	}
+
+	reason, err := p.getVisibility(ctx, tenantID, txn)
+	if err != nil {
+		return "", err
+	}
 
-		return false
+	// the block is parsed without the transaction, the address was tracked after it
+	if reason == constant.TransactionLookupReasonIndexed {
+		return constant.TransactionLookupReasonSubscribedAfterParsing, nil
 	}
 
-	return true
+	return reason, nil
 }
 
-func (p *Parser) GetTransactions(address string) []entity.Transaction {
-	txns, err := p.txnRepo.GetTxnsByAddress(context.Background(), address)
+// QueryTransactions returns the combined transactions feed of the addresses subscribed by the tenant.
+func (p *Parser) QueryTransactions(ctx context.Context, tenantID string, addresses []entity.Address, filter entity.TransactionFilter) (entity.TransactionFeed, error) {
+	subscriptions, err := getTenantSubscriptions(ctx, p.subscriptionRepo, tenantID, addresses)
+	if err != nil {
+		return entity.TransactionFeed{}, fmt.Errorf("fail get subscriptions in QueryTransactions: %w", err)
+	}
+
+	feed, err := getTransactionFeed(ctx, p.txnRepo, subscriptions, filter)
 	if err != nil {
-		log.Printf("fail get trasactions for address (%s): %s\n", address, err)
+		return entity.TransactionFeed{}, fmt.Errorf("fail get transactions feed in QueryTransactions: %w", err)
 	}
 
-	return txns
+	return feed, nil
 }
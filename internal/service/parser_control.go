@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"blockchain-parser/internal/constant"
+	"blockchain-parser/internal/entity"
+	errorpkg "blockchain-parser/internal/error"
+	"blockchain-parser/internal/metrics"
+	"blockchain-parser/tools/job"
+	"blockchain-parser/tools/logger"
+)
+
+// ParserControl scales parser workers and pauses parsing at runtime. Every worker is a job of the scheduler,
+// new workers are added to the end and the last ones are removed first.
+type ParserControl struct {
+	scheduler  JobScheduler
+	parser     ParserRunner
+	schedule   job.Schedule
+	policy     job.Policy
+	maxWorkers int
+
+	mu      sync.Mutex
+	workers []string
+	paused  bool
+
+	log logger.Logger
+}
+
+func NewParserControl(
+	scheduler JobScheduler,
+	parser ParserRunner,
+	schedule job.Schedule,
+	policy job.Policy,
+	maxWorkers int,
+	log logger.Logger,
+) *ParserControl {
+	return &ParserControl{
+		scheduler:  scheduler,
+		parser:     parser,
+		schedule:   schedule,
+		policy:     policy,
+		maxWorkers: maxWorkers,
+		log:        log,
+	}
+}
+
+func (s *ParserControl) State(_ context.Context) (entity.ParserState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.stateLocked()
+}
+
+// Scale adds or removes workers up to the count. Removed workers are stopped, the block of the interrupted run
+// is released as failed and taken by other workers.
+func (s *ParserControl) Scale(ctx context.Context, count int) (entity.ParserState, error) {
+	if count < 0 || count > s.maxWorkers {
+		return entity.ParserState{}, errorpkg.NewInvalidArgument("count", fmt.Sprintf("count must be from 0 to %d", s.maxWorkers))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	log := logger.FromContext(ctx, s.log)
+	from := len(s.workers)
+
+	for len(s.workers) < count {
+		workerJob := job.NewJob(
+			s.parser.Run,
+			constant.ParserWorkerJobName,
+			s.schedule,
+			s.policy,
+			s.log.With(logger.Int("worker_id", len(s.workers))),
+		)
+		if s.paused {
+			workerJob.Pause()
+		}
+
+		s.scheduler.Add(workerJob)
+		s.workers = append(s.workers, workerJob.ID())
+	}
+
+	for len(s.workers) > count {
+		id := s.workers[len(s.workers)-1]
+		s.workers = s.workers[:len(s.workers)-1]
+
+		if err := s.scheduler.Remove(ctx, id); err != nil {
+			metrics.ParserWorkers.Set(float64(len(s.workers)))
+
+			return entity.ParserState{}, fmt.Errorf("fail remove parser worker %s in Scale: %w", id, err)
+		}
+	}
+
+	metrics.ParserWorkers.Set(float64(len(s.workers)))
+	if from != count {
+		log.Info("parser workers were scaled", logger.Int("from", from), logger.Int("to", count))
+	}
+
+	return s.stateLocked()
+}
+
+// Reschedule changes the schedule and the policy of current and new workers
+func (s *ParserControl) Reschedule(ctx context.Context, schedule job.Schedule, policy job.Policy) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.schedule = schedule
+	s.policy = policy
+
+	for _, id := range s.workers {
+		if err := s.scheduler.Reschedule(id, schedule, policy); err != nil {
+			return fmt.Errorf("fail reschedule parser worker %s in Reschedule: %w", id, err)
+		}
+	}
+
+	logger.FromContext(ctx, s.log).Info("parser workers were rescheduled", logger.String("schedule", schedule.String()))
+
+	return nil
+}
+
+// Pause stops workers from taking new blocks, runs in progress finish their current blocks and return
+func (s *ParserControl) Pause(ctx context.Context) (entity.ParserState, error) {
+	return s.setPaused(ctx, true)
+}
+
+func (s *ParserControl) Resume(ctx context.Context) (entity.ParserState, error) {
+	return s.setPaused(ctx, false)
+}
+
+func (s *ParserControl) setPaused(ctx context.Context, paused bool) (entity.ParserState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	change := s.scheduler.Resume
+	if paused {
+		change = s.scheduler.Pause
+	}
+	s.parser.SetPaused(paused)
+
+	for _, id := range s.workers {
+		if err := change(id); err != nil {
+			return entity.ParserState{}, fmt.Errorf("fail change pause of parser worker %s in ParserControl: %w", id, err)
+		}
+	}
+
+	if s.paused != paused {
+		s.paused = paused
+
+		msg := "parsing was resumed"
+		if paused {
+			msg = "parsing was paused"
+		}
+		logger.FromContext(ctx, s.log).Info(msg, logger.Int("workers", len(s.workers)))
+	}
+
+	pausedValue := 0.0
+	if paused {
+		pausedValue = 1
+	}
+	metrics.ParsingPaused.Set(pausedValue)
+
+	return s.stateLocked()
+}
+
+func (s *ParserControl) stateLocked() (entity.ParserState, error) {
+	state := entity.ParserState{
+		Workers: len(s.workers),
+		Paused:  s.paused,
+	}
+
+	for _, id := range s.workers {
+		status, err := s.scheduler.Status(id)
+		if err != nil {
+			return entity.ParserState{}, fmt.Errorf("fail get status of parser worker %s in ParserControl: %w", id, err)
+		}
+
+		state.Running += status.Running
+	}
+
+	return state, nil
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"blockchain-parser/internal/entity"
+	errorpkg "blockchain-parser/internal/error"
+	"blockchain-parser/internal/service/mocks"
+	"blockchain-parser/tools/job"
+	"blockchain-parser/tools/logger"
+)
+
+func TestParserControl_Scale(t *testing.T) {
+	tests := []struct {
+		name        string
+		workers     []string
+		paused      bool
+		count       int
+		wantAdded   int
+		wantRemoved []string
+		want        entity.ParserState
+		wantErr     error
+	}{
+		{
+			name:      "scale up",
+			workers:   []string{"parser_worker-0"},
+			count:     3,
+			wantAdded: 2,
+			want:      entity.ParserState{Workers: 3, Running: 3},
+		},
+		{
+			name:        "scale down removes last workers",
+			workers:     []string{"parser_worker-0", "parser_worker-1", "parser_worker-2"},
+			count:       1,
+			wantRemoved: []string{"parser_worker-2", "parser_worker-1"},
+			want:        entity.ParserState{Workers: 1, Running: 1},
+		},
+		{
+			name:      "new workers of paused parser are paused",
+			paused:    true,
+			count:     1,
+			wantAdded: 1,
+			want:      entity.ParserState{Workers: 1, Paused: true, Running: 1},
+		},
+		{
+			name:    "count above max",
+			count:   5,
+			wantErr: errorpkg.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			schedulerMock := mocks.NewMockJobScheduler(ctrl)
+
+			schedulerMock.EXPECT().Add(gomock.Any()).Do(func(workerJob *job.Job) {
+				if workerJob.Status().Paused != tt.paused {
+					t.Errorf("added worker paused = %v, want %v", workerJob.Status().Paused, tt.paused)
+				}
+			}).Times(tt.wantAdded)
+
+			var removed []string
+			schedulerMock.EXPECT().Remove(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, id string) error {
+				removed = append(removed, id)
+
+				return nil
+			}).Times(len(tt.wantRemoved))
+
+			schedulerMock.EXPECT().Status(gomock.Any()).Return(job.Status{Running: 1}, nil).Times(tt.want.Workers)
+
+			s := NewParserControl(schedulerMock, mocks.NewMockParserRunner(ctrl), job.Every(time.Second), job.Policy{}, 4, logger.Nop())
+			s.workers = append(s.workers, tt.workers...)
+			s.paused = tt.paused
+
+			got, err := s.Scale(context.Background(), tt.count)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Scale() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Scale() got = %+v, want %+v", got, tt.want)
+			}
+			if !reflect.DeepEqual(removed, tt.wantRemoved) {
+				t.Errorf("removed workers = %v, want %v", removed, tt.wantRemoved)
+			}
+		})
+	}
+}
+
+func TestParserControl_Reschedule(t *testing.T) {
+	schedule := job.Every(5 * time.Second)
+	policy := job.Policy{Jitter: time.Second}
+
+	ctrl := gomock.NewController(t)
+	schedulerMock := mocks.NewMockJobScheduler(ctrl)
+	schedulerMock.EXPECT().Reschedule("parser_worker-0", schedule, policy).Return(nil).Times(1)
+	schedulerMock.EXPECT().Reschedule("parser_worker-1", schedule, policy).Return(nil).Times(1)
+	schedulerMock.EXPECT().Add(gomock.Any()).Do(func(workerJob *job.Job) {
+		if got := workerJob.Status().Schedule; got != schedule.String() {
+			t.Errorf("added worker schedule = %s, want %s", got, schedule)
+		}
+	}).Times(1)
+	schedulerMock.EXPECT().Status(gomock.Any()).Return(job.Status{}, nil).AnyTimes()
+
+	s := NewParserControl(schedulerMock, mocks.NewMockParserRunner(ctrl), job.Every(time.Second), job.Policy{}, 4, logger.Nop())
+	s.workers = append(s.workers, "parser_worker-0", "parser_worker-1")
+
+	if err := s.Reschedule(context.Background(), schedule, policy); err != nil {
+		t.Fatalf("Reschedule() error = %v, wantErr %v", err, nil)
+	}
+
+	// workers added later get the new schedule
+	if _, err := s.Scale(context.Background(), 3); err != nil {
+		t.Fatalf("Scale() error = %v, wantErr %v", err, nil)
+	}
+}
+
+func TestParserControl_Pause(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	schedulerMock := mocks.NewMockJobScheduler(ctrl)
+	schedulerMock.EXPECT().Pause("parser_worker-0").Return(nil).Times(1)
+	schedulerMock.EXPECT().Resume("parser_worker-0").Return(nil).Times(1)
+	schedulerMock.EXPECT().Status("parser_worker-0").Return(job.Status{}, nil).Times(2)
+
+	// runs in progress are paused as well as scheduling of new runs
+	parserMock := mocks.NewMockParserRunner(ctrl)
+	gomock.InOrder(
+		parserMock.EXPECT().SetPaused(true).Times(1),
+		parserMock.EXPECT().SetPaused(false).Times(1),
+	)
+
+	s := NewParserControl(schedulerMock, parserMock, job.Every(time.Second), job.Policy{}, 4, logger.Nop())
+	s.workers = append(s.workers, "parser_worker-0")
+
+	got, err := s.Pause(context.Background())
+	if err != nil {
+		t.Fatalf("Pause() error = %v, wantErr %v", err, nil)
+	}
+	if want := (entity.ParserState{Workers: 1, Paused: true}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Pause() got = %+v, want %+v", got, want)
+	}
+
+	got, err = s.Resume(context.Background())
+	if err != nil {
+		t.Fatalf("Resume() error = %v, wantErr %v", err, nil)
+	}
+	if want := (entity.ParserState{Workers: 1}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Resume() got = %+v, want %+v", got, want)
+	}
+}
@@ -2,24 +2,71 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"blockchain-parser/internal/entity"
+	"blockchain-parser/tools/job"
 )
 
 //go:generate mockgen -source=./parser_dependency.go -destination=./mocks/mock.go -package=mocks
 
 type TransactionRepository interface {
-	GetTxnsByAddress(ctx context.Context, address string) ([]entity.Transaction, error)
+	GetTxnsByAddress(ctx context.Context, address entity.Address, filter entity.TransactionFilter) ([]entity.Transaction, error)
+	GetTxnsByBlockNumber(ctx context.Context, blockNumber entity.BlockNumber) ([]entity.Transaction, error)
+	GetTxnByHash(ctx context.Context, hash string) (entity.Transaction, error)
 	Save(_ context.Context, transaction entity.Transaction) error
+	DeleteByBlockNumber(ctx context.Context, blockNumber entity.BlockNumber) error
 }
 
 type SubscriberRepository interface {
 	Save(_ context.Context, subscriber entity.Subscriber) error
-	Get(_ context.Context, address string) (entity.Subscriber, error)
+	Get(_ context.Context, address entity.Address) (entity.Subscriber, error)
+}
+
+type SubscriptionRepository interface {
+	SaveWithinLimit(ctx context.Context, subscriptions []entity.Subscription, limit int) ([]entity.Subscription, error)
+	Get(ctx context.Context, tenantID string, address entity.Address) (entity.Subscription, error)
+	Delete(ctx context.Context, tenantID string, address entity.Address) error
+}
+
+type WalletRepository interface {
+	Save(ctx context.Context, wallet entity.Wallet) error
+	Get(ctx context.Context, id string) (entity.Wallet, error)
+	GetByTenant(ctx context.Context, tenantID string) ([]entity.Wallet, error)
+	Delete(ctx context.Context, id string) error
+}
+
+type TenantRepository interface {
+	Save(ctx context.Context, tenant entity.Tenant) error
+	Get(ctx context.Context, id string) (entity.Tenant, error)
+	GetAll(ctx context.Context) ([]entity.Tenant, error)
+}
+
+type APIKeyRepository interface {
+	Save(ctx context.Context, key entity.APIKey) error
+	Get(ctx context.Context, id string) (entity.APIKey, error)
+	GetByHash(ctx context.Context, hash string) (entity.APIKey, error)
+	GetByTenant(ctx context.Context, tenantID string) ([]entity.APIKey, error)
+}
+
+type BalanceRepository interface {
+	SaveSnapshot(ctx context.Context, snapshot entity.BalanceSnapshot) error
+	GetSnapshot(ctx context.Context, address entity.Address) (entity.BalanceSnapshot, error)
+	GetSnapshots(ctx context.Context) ([]entity.BalanceSnapshot, error)
+	SaveChange(ctx context.Context, change entity.BalanceChange) error
+	GetChanges(ctx context.Context, address entity.Address, fromBlock, toBlock entity.BlockNumber) ([]entity.BalanceChange, error)
+	DeleteByBlockNumber(ctx context.Context, blockNumber entity.BlockNumber) error
+}
+
+type ReparseTaskRepository interface {
+	Save(ctx context.Context, task entity.ReparseTask) error
+	Get(ctx context.Context, id string) (entity.ReparseTask, error)
+	GetAll(ctx context.Context) ([]entity.ReparseTask, error)
 }
 
 type BlockRepository interface {
 	GetLastParsedBlock(ctx context.Context) (entity.Block, error)
+	GetParsedBlock(ctx context.Context, blockNumber entity.BlockNumber) (entity.Block, error)
 	GetLastBlock(ctx context.Context) (entity.Block, error)
 	GetFailedBlock(ctx context.Context) (entity.Block, error)
 
@@ -27,11 +74,42 @@ type BlockRepository interface {
 }
 
 type BlockChainClient interface {
-	GetBlockNumber(ctx context.Context) (int, error)
-	GetTxnsByBlockByNumber(ctx context.Context, blockNumber int) ([]entity.Transaction, error)
+	GetBlockNumber(ctx context.Context) (entity.BlockNumber, error)
+	GetBlockByNumber(ctx context.Context, blockNumber entity.BlockNumber) (entity.ChainBlock, error)
+	GetTransactionByHash(ctx context.Context, hash string) (entity.Transaction, error)
+	GetTransactionReceipt(ctx context.Context, hash string) (entity.TransactionReceipt, error)
+	GetBalance(ctx context.Context, address entity.Address, blockNumber entity.BlockNumber) (entity.Wei, error)
+	GetInternalTransfers(ctx context.Context, blockNumber entity.BlockNumber) ([]entity.InternalTransfer, error)
+}
+
+type EventRepository interface {
+	Save(ctx context.Context, event entity.Event) error
+	GetEventsAfter(ctx context.Context, afterID uint64, addresses []entity.Address, limit int) ([]entity.Event, uint64, error)
+	WaitEvents(ctx context.Context, afterID uint64) error
 }
 
 type Locker interface {
 	Lock()
 	Unlock()
 }
+
+// ParserRunTracker reports when parser workers tried to parse blocks last time
+type ParserRunTracker interface {
+	LastRunAt() time.Time
+}
+
+// ParserRunner parses blocks in runs of parser workers, paused runs return after the block in progress
+type ParserRunner interface {
+	Run(ctx context.Context) error
+	SetPaused(paused bool)
+}
+
+// JobScheduler runs background jobs, jobs can be added and removed at runtime
+type JobScheduler interface {
+	Add(job *job.Job)
+	Remove(ctx context.Context, id string) error
+	Pause(id string) error
+	Resume(id string) error
+	Status(id string) (job.Status, error)
+	Reschedule(id string, schedule job.Schedule, policy job.Policy) error
+}
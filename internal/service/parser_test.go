@@ -0,0 +1,556 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"blockchain-parser/internal/constant"
+	"blockchain-parser/internal/entity"
+	errorpkg "blockchain-parser/internal/error"
+	"blockchain-parser/internal/service/mocks"
+)
+
+func TestParser_GetTransactions(t *testing.T) {
+	tenantID := "t1"
+	address := entity.Address("0xd7def8de6bff40e7fa3a19b6749aca84bd5ba0ae")
+	txn1 := entity.Transaction{
+		From:             address,
+		To:               "0x00000000006c3852cbef3e08e8df289169ede581",
+		Value:            entity.NewWeiFromInt64(0xb1a2bc2ec50000),
+		BlockNumber:      34534,
+		TransactionIndex: 0,
+	}
+	txn2 := entity.Transaction{
+		From:             "0x069acf904f610cbf8ef1540349092852e46b4e95",
+		To:               address,
+		Value:            entity.NewWeiFromInt64(0x5f3bcfe512dcc00),
+		BlockNumber:      34535,
+		TransactionIndex: 1,
+	}
+
+	t.Run("next page exists", func(tt *testing.T) {
+		ctx := context.Background()
+
+		ctrl := gomock.NewController(nil)
+		txnRepoMock := mocks.NewMockTransactionRepository(ctrl)
+		txnRepoMock.EXPECT().GetTxnsByAddress(ctx, address, entity.TransactionFilter{Limit: 2}).Return([]entity.Transaction{txn1, txn2}, nil).Times(1)
+		subscriptionRepoMock := mocks.NewMockSubscriptionRepository(ctrl)
+		subscriptionRepoMock.EXPECT().Get(ctx, tenantID, address).Return(entity.Subscription{TenantID: tenantID, Address: address}, nil).Times(1)
+
+		p := NewParser(txnRepoMock, nil, subscriptionRepoMock, nil, nil, nil, nil, 0)
+
+		want := entity.TransactionPage{
+			Transactions: []entity.Transaction{txn1},
+			Next:         &entity.TransactionCursor{BlockNumber: 34534, TransactionIndex: 0},
+		}
+		got, err := p.GetTransactions(context.Background(), tenantID, address, entity.TransactionFilter{Limit: 1})
+		if err != nil {
+			t.Errorf("GetTransactions() error = %v, wantErr %v", err, nil)
+			return
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("GetTransactions() got = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("last page", func(tt *testing.T) {
+		ctx := context.Background()
+
+		ctrl := gomock.NewController(nil)
+		txnRepoMock := mocks.NewMockTransactionRepository(ctrl)
+		txnRepoMock.EXPECT().GetTxnsByAddress(ctx, address, entity.TransactionFilter{Limit: 3}).Return([]entity.Transaction{txn1, txn2}, nil).Times(1)
+		subscriptionRepoMock := mocks.NewMockSubscriptionRepository(ctrl)
+		subscriptionRepoMock.EXPECT().Get(ctx, tenantID, address).Return(entity.Subscription{TenantID: tenantID, Address: address}, nil).Times(1)
+
+		p := NewParser(txnRepoMock, nil, subscriptionRepoMock, nil, nil, nil, nil, 0)
+
+		want := entity.TransactionPage{
+			Transactions: []entity.Transaction{txn1, txn2},
+		}
+		got, err := p.GetTransactions(context.Background(), tenantID, address, entity.TransactionFilter{Limit: 2})
+		if err != nil {
+			t.Errorf("GetTransactions() error = %v, wantErr %v", err, nil)
+			return
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("GetTransactions() got = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("blocks before the subscription are hidden", func(tt *testing.T) {
+		ctx := context.Background()
+
+		ctrl := gomock.NewController(tt)
+		txnRepoMock := mocks.NewMockTransactionRepository(ctrl)
+		txnRepoMock.EXPECT().GetTxnsByAddress(ctx, address, entity.TransactionFilter{FromBlock: 34535, Limit: 3}).Return([]entity.Transaction{txn2}, nil).Times(1)
+		subscriptionRepoMock := mocks.NewMockSubscriptionRepository(ctrl)
+		subscriptionRepoMock.EXPECT().Get(ctx, tenantID, address).Return(entity.Subscription{TenantID: tenantID, Address: address, FromBlock: 34535}, nil).Times(1)
+
+		p := NewParser(txnRepoMock, nil, subscriptionRepoMock, nil, nil, nil, nil, 0)
+
+		want := entity.TransactionPage{
+			Transactions: []entity.Transaction{txn2},
+		}
+		got, err := p.GetTransactions(context.Background(), tenantID, address, entity.TransactionFilter{FromBlock: 100, Limit: 2})
+		if err != nil {
+			tt.Errorf("GetTransactions() error = %v, wantErr %v", err, nil)
+			return
+		}
+		if !reflect.DeepEqual(got, want) {
+			tt.Errorf("GetTransactions() got = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("address is not subscribed by the tenant", func(tt *testing.T) {
+		ctx := context.Background()
+
+		ctrl := gomock.NewController(nil)
+		subscriptionRepoMock := mocks.NewMockSubscriptionRepository(ctrl)
+		subscriptionRepoMock.EXPECT().Get(ctx, tenantID, address).Return(entity.Subscription{}, errorpkg.SubscriberNotFound).Times(1)
+
+		p := NewParser(nil, nil, subscriptionRepoMock, nil, nil, nil, nil, 0)
+
+		_, err := p.GetTransactions(context.Background(), tenantID, address, entity.TransactionFilter{Limit: 2})
+		if !errors.Is(err, errorpkg.SubscriberNotFound) {
+			t.Errorf("GetTransactions() error = %v, wantErr %v", err, errorpkg.SubscriberNotFound)
+		}
+	})
+}
+
+func TestParser_GetTransaction(t *testing.T) {
+	tenantID := "t1"
+	hash := "0x3e1d2c9d1b4d5ad7bfa4bb9b1a2d0a8e0b0d4ebc6b6c1e14a2a1b08b8a5f0b1c"
+	txn := entity.Transaction{
+		Hash:             hash,
+		From:             "0xd7def8de6bff40e7fa3a19b6749aca84bd5ba0ae",
+		To:               "0x00000000006c3852cbef3e08e8df289169ede581",
+		Value:            entity.NewWeiFromInt64(0xb1a2bc2ec50000),
+		BlockNumber:      34534,
+		TransactionIndex: 0,
+	}
+	// storedTxn has fields of the store which the node doesn't return
+	storedTxn := txn
+	storedTxn.Timestamp = time.Unix(1700000000, 0).UTC()
+	pendingTxn := entity.Transaction{
+		Hash:             hash,
+		From:             txn.From,
+		To:               txn.To,
+		Value:            txn.Value,
+		Pending:          true,
+		TransactionIndex: -1,
+	}
+
+	type mocksSetup func(
+		ctx context.Context,
+		txnRepoMock *mocks.MockTransactionRepository,
+		subscriptionRepoMock *mocks.MockSubscriptionRepository,
+		blockRepoMock *mocks.MockBlockRepository,
+		blockChainClientMock *mocks.MockBlockChainClient,
+	)
+
+	tests := []struct {
+		name    string
+		setup   mocksSetup
+		want    entity.TransactionLookup
+		wantErr error
+	}{
+		{
+			name: "indexed transaction",
+			setup: func(ctx context.Context, txnRepoMock *mocks.MockTransactionRepository, subscriptionRepoMock *mocks.MockSubscriptionRepository, _ *mocks.MockBlockRepository, _ *mocks.MockBlockChainClient) {
+				txnRepoMock.EXPECT().GetTxnByHash(ctx, hash).Return(txn, nil).Times(1)
+				subscriptionRepoMock.EXPECT().Get(ctx, tenantID, txn.From).Return(entity.Subscription{}, errorpkg.SubscriberNotFound).Times(1)
+				subscriptionRepoMock.EXPECT().Get(ctx, tenantID, txn.To).Return(entity.Subscription{TenantID: tenantID, Address: txn.To}, nil).Times(1)
+			},
+			want: entity.TransactionLookup{
+				Transaction: txn,
+				Reason:      constant.TransactionLookupReasonIndexed,
+			},
+		},
+		{
+			name: "transaction indexed for another tenant",
+			setup: func(ctx context.Context, txnRepoMock *mocks.MockTransactionRepository, subscriptionRepoMock *mocks.MockSubscriptionRepository, _ *mocks.MockBlockRepository, blockChainClientMock *mocks.MockBlockChainClient) {
+				txnRepoMock.EXPECT().GetTxnByHash(ctx, hash).Return(storedTxn, nil).Times(1)
+				subscriptionRepoMock.EXPECT().Get(ctx, tenantID, txn.From).Return(entity.Subscription{}, errorpkg.SubscriberNotFound).Times(1)
+				subscriptionRepoMock.EXPECT().Get(ctx, tenantID, txn.To).Return(entity.Subscription{}, errorpkg.SubscriberNotFound).Times(1)
+				blockChainClientMock.EXPECT().GetTransactionByHash(ctx, hash).Return(txn, nil).Times(1)
+			},
+			want: entity.TransactionLookup{
+				Transaction: txn,
+				Reason:      constant.TransactionLookupReasonNotSubscribed,
+			},
+		},
+		{
+			name: "transaction indexed before the subscription",
+			setup: func(ctx context.Context, txnRepoMock *mocks.MockTransactionRepository, subscriptionRepoMock *mocks.MockSubscriptionRepository, _ *mocks.MockBlockRepository, blockChainClientMock *mocks.MockBlockChainClient) {
+				txnRepoMock.EXPECT().GetTxnByHash(ctx, hash).Return(storedTxn, nil).Times(1)
+				subscriptionRepoMock.EXPECT().Get(ctx, tenantID, txn.From).Return(entity.Subscription{}, errorpkg.SubscriberNotFound).Times(1)
+				subscriptionRepoMock.EXPECT().Get(ctx, tenantID, txn.To).Return(entity.Subscription{TenantID: tenantID, Address: txn.To, FromBlock: txn.BlockNumber + 1}, nil).Times(1)
+				blockChainClientMock.EXPECT().GetTransactionByHash(ctx, hash).Return(txn, nil).Times(1)
+			},
+			want: entity.TransactionLookup{
+				Transaction: txn,
+				Reason:      constant.TransactionLookupReasonSubscribedAfterParsing,
+			},
+		},
+		{
+			name: "unknown transaction",
+			setup: func(ctx context.Context, txnRepoMock *mocks.MockTransactionRepository, _ *mocks.MockSubscriptionRepository, _ *mocks.MockBlockRepository, blockChainClientMock *mocks.MockBlockChainClient) {
+				txnRepoMock.EXPECT().GetTxnByHash(ctx, hash).Return(entity.Transaction{}, errorpkg.TransactionNotFound).Times(1)
+				blockChainClientMock.EXPECT().GetTransactionByHash(ctx, hash).Return(entity.Transaction{}, errorpkg.TransactionNotFound).Times(1)
+			},
+			want:    entity.TransactionLookup{},
+			wantErr: errorpkg.TransactionNotFound,
+		},
+		{
+			name: "pending transaction",
+			setup: func(ctx context.Context, txnRepoMock *mocks.MockTransactionRepository, _ *mocks.MockSubscriptionRepository, _ *mocks.MockBlockRepository, blockChainClientMock *mocks.MockBlockChainClient) {
+				txnRepoMock.EXPECT().GetTxnByHash(ctx, hash).Return(entity.Transaction{}, errorpkg.TransactionNotFound).Times(1)
+				blockChainClientMock.EXPECT().GetTransactionByHash(ctx, hash).Return(pendingTxn, nil).Times(1)
+			},
+			want: entity.TransactionLookup{
+				Transaction: pendingTxn,
+				Reason:      constant.TransactionLookupReasonPending,
+			},
+		},
+		{
+			name: "block is not parsed",
+			setup: func(ctx context.Context, txnRepoMock *mocks.MockTransactionRepository, _ *mocks.MockSubscriptionRepository, blockRepoMock *mocks.MockBlockRepository, blockChainClientMock *mocks.MockBlockChainClient) {
+				txnRepoMock.EXPECT().GetTxnByHash(ctx, hash).Return(entity.Transaction{}, errorpkg.TransactionNotFound).Times(1)
+				blockChainClientMock.EXPECT().GetTransactionByHash(ctx, hash).Return(txn, nil).Times(1)
+				blockRepoMock.EXPECT().GetParsedBlock(ctx, txn.BlockNumber).Return(entity.Block{}, errorpkg.BlockNotFound).Times(1)
+			},
+			want: entity.TransactionLookup{
+				Transaction: txn,
+				Reason:      constant.TransactionLookupReasonBlockNotParsed,
+			},
+		},
+		{
+			name: "addresses are not subscribed",
+			setup: func(ctx context.Context, txnRepoMock *mocks.MockTransactionRepository, subscriptionRepoMock *mocks.MockSubscriptionRepository, blockRepoMock *mocks.MockBlockRepository, blockChainClientMock *mocks.MockBlockChainClient) {
+				txnRepoMock.EXPECT().GetTxnByHash(ctx, hash).Return(entity.Transaction{}, errorpkg.TransactionNotFound).Times(1)
+				blockChainClientMock.EXPECT().GetTransactionByHash(ctx, hash).Return(txn, nil).Times(1)
+				blockRepoMock.EXPECT().GetParsedBlock(ctx, txn.BlockNumber).Return(entity.Block{Number: txn.BlockNumber}, nil).Times(1)
+				subscriptionRepoMock.EXPECT().Get(ctx, tenantID, txn.From).Return(entity.Subscription{}, errorpkg.SubscriberNotFound).Times(1)
+				subscriptionRepoMock.EXPECT().Get(ctx, tenantID, txn.To).Return(entity.Subscription{}, errorpkg.SubscriberNotFound).Times(1)
+			},
+			want: entity.TransactionLookup{
+				Transaction: txn,
+				Reason:      constant.TransactionLookupReasonNotSubscribed,
+			},
+		},
+		{
+			name: "address is subscribed after parsing",
+			setup: func(ctx context.Context, txnRepoMock *mocks.MockTransactionRepository, subscriptionRepoMock *mocks.MockSubscriptionRepository, blockRepoMock *mocks.MockBlockRepository, blockChainClientMock *mocks.MockBlockChainClient) {
+				txnRepoMock.EXPECT().GetTxnByHash(ctx, hash).Return(entity.Transaction{}, errorpkg.TransactionNotFound).Times(1)
+				blockChainClientMock.EXPECT().GetTransactionByHash(ctx, hash).Return(txn, nil).Times(1)
+				blockRepoMock.EXPECT().GetParsedBlock(ctx, txn.BlockNumber).Return(entity.Block{Number: txn.BlockNumber}, nil).Times(1)
+				subscriptionRepoMock.EXPECT().Get(ctx, tenantID, txn.From).Return(entity.Subscription{TenantID: tenantID, Address: txn.From}, nil).Times(1)
+			},
+			want: entity.TransactionLookup{
+				Transaction: txn,
+				Reason:      constant.TransactionLookupReasonSubscribedAfterParsing,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			txnRepoMock := mocks.NewMockTransactionRepository(ctrl)
+			subscriptionRepoMock := mocks.NewMockSubscriptionRepository(ctrl)
+			blockRepoMock := mocks.NewMockBlockRepository(ctrl)
+			blockChainClientMock := mocks.NewMockBlockChainClient(ctrl)
+			tt.setup(ctx, txnRepoMock, subscriptionRepoMock, blockRepoMock, blockChainClientMock)
+
+			p := NewParser(txnRepoMock, nil, subscriptionRepoMock, nil, blockRepoMock, nil, blockChainClientMock, 0)
+
+			got, err := p.GetTransaction(context.Background(), tenantID, hash)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetTransaction() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetTransaction() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestParser_GetTransaction_notSubscribed checks that the transaction stored for another tenant is answered
+// the same way as the transaction which isn't stored
+func TestParser_GetTransaction_notSubscribed(t *testing.T) {
+	ctx := context.Background()
+	tenantID := "t1"
+	hash := "0x3e1d2c9d1b4d5ad7bfa4bb9b1a2d0a8e0b0d4ebc6b6c1e14a2a1b08b8a5f0b1c"
+	nodeTxn := entity.Transaction{
+		Hash:        hash,
+		Kind:        constant.TransactionKindTransaction,
+		From:        "0xd7def8de6bff40e7fa3a19b6749aca84bd5ba0ae",
+		To:          "0x00000000006c3852cbef3e08e8df289169ede581",
+		Value:       entity.NewWeiFromInt64(0xb1a2bc2ec50000),
+		BlockNumber: 34534,
+	}
+	storedTxn := nodeTxn
+	storedTxn.Timestamp = time.Unix(1700000000, 0).UTC()
+
+	lookup := func(stored bool) entity.TransactionLookup {
+		ctrl := gomock.NewController(t)
+		txnRepoMock := mocks.NewMockTransactionRepository(ctrl)
+		subscriptionRepoMock := mocks.NewMockSubscriptionRepository(ctrl)
+		blockRepoMock := mocks.NewMockBlockRepository(ctrl)
+		blockChainClientMock := mocks.NewMockBlockChainClient(ctrl)
+
+		if stored {
+			txnRepoMock.EXPECT().GetTxnByHash(ctx, hash).Return(storedTxn, nil).Times(1)
+		} else {
+			txnRepoMock.EXPECT().GetTxnByHash(ctx, hash).Return(entity.Transaction{}, errorpkg.TransactionNotFound).Times(1)
+			blockRepoMock.EXPECT().GetParsedBlock(ctx, nodeTxn.BlockNumber).Return(entity.Block{Number: nodeTxn.BlockNumber}, nil).Times(1)
+		}
+		blockChainClientMock.EXPECT().GetTransactionByHash(ctx, hash).Return(nodeTxn, nil).Times(1)
+		subscriptionRepoMock.EXPECT().Get(ctx, tenantID, gomock.Any()).Return(entity.Subscription{}, errorpkg.SubscriberNotFound).Times(2)
+
+		p := NewParser(txnRepoMock, nil, subscriptionRepoMock, nil, blockRepoMock, nil, blockChainClientMock, 0)
+
+		got, err := p.GetTransaction(ctx, tenantID, hash)
+		if err != nil {
+			t.Fatalf("GetTransaction() error = %v, wantErr %v", err, nil)
+		}
+
+		return got
+	}
+
+	stored, missing := lookup(true), lookup(false)
+	if !reflect.DeepEqual(stored, missing) {
+		t.Errorf("GetTransaction() of stored transaction got = %+v, want as of not stored %+v", stored, missing)
+	}
+}
+
+func TestParser_GetCurrentBlock(t *testing.T) {
+	tests := []struct {
+		name    string
+		block   entity.Block
+		repoErr error
+		want    entity.BlockNumber
+		wantErr error
+	}{
+		{
+			name:  "last parsed block",
+			block: entity.Block{Number: 34534},
+			want:  34534,
+		},
+		{
+			name:    "no parsed block",
+			repoErr: errorpkg.BlockNotFound,
+			wantErr: errorpkg.BlockNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			blockRepoMock := mocks.NewMockBlockRepository(ctrl)
+			blockRepoMock.EXPECT().GetLastParsedBlock(gomock.Any()).Return(tt.block, tt.repoErr).Times(1)
+
+			p := NewParser(nil, nil, nil, nil, blockRepoMock, nil, nil, 0)
+
+			got, err := p.GetCurrentBlock(context.Background())
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetCurrentBlock() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("GetCurrentBlock() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParser_GetBlock(t *testing.T) {
+	block := entity.Block{
+		Number: 34534,
+		Hash:   "0x3a5f",
+		Status: constant.BlockStatusParsed,
+	}
+
+	tests := []struct {
+		name    string
+		block   entity.Block
+		repoErr error
+		want    entity.Block
+		wantErr error
+	}{
+		{
+			name:  "parsed block",
+			block: block,
+			want:  block,
+		},
+		{
+			name:    "block isn't parsed",
+			repoErr: errorpkg.BlockNotFound,
+			want:    entity.Block{},
+			wantErr: errorpkg.BlockNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			blockRepoMock := mocks.NewMockBlockRepository(ctrl)
+			blockRepoMock.EXPECT().GetParsedBlock(gomock.Any(), entity.BlockNumber(34534)).Return(tt.block, tt.repoErr).Times(1)
+
+			p := NewParser(nil, nil, nil, nil, blockRepoMock, nil, nil, 0)
+
+			got, err := p.GetBlock(context.Background(), 34534)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetBlock() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetBlock() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParser_Subscribe(t *testing.T) {
+	address := entity.Address("0xd7def8de6bff40e7fa3a19b6749aca84bd5ba0ae")
+
+	tests := []struct {
+		name      string
+		tenant    entity.Tenant
+		tenantErr error
+		wantLimit int
+		tracked   bool
+		saveErr   error
+		nodeErr   error
+		wantErr   error
+	}{
+		{
+			name:      "default cap is exceeded",
+			tenantErr: errorpkg.TenantNotFound,
+			wantLimit: 2,
+			saveErr:   errorpkg.SubscriptionLimit,
+			wantErr:   errorpkg.SubscriptionLimit,
+		},
+		{
+			name:      "cap of the tenant overrides default cap",
+			tenant:    entity.Tenant{ID: "t1", MaxSubscriptions: 3},
+			wantLimit: 3,
+		},
+		{
+			name:      "tenant without cap has default cap",
+			tenant:    entity.Tenant{ID: "t1"},
+			wantLimit: 2,
+		},
+		{
+			name:      "blocks parsed before subscription to tracked address are hidden",
+			tenant:    entity.Tenant{ID: "t1"},
+			wantLimit: 2,
+			tracked:   true,
+		},
+		{
+			name:      "subscription is removed when the snapshot fails",
+			tenant:    entity.Tenant{ID: "t1"},
+			wantLimit: 2,
+			nodeErr:   errorpkg.TimeoutErr,
+			wantErr:   errorpkg.TimeoutErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			tenantRepoMock := mocks.NewMockTenantRepository(ctrl)
+			tenantRepoMock.EXPECT().Get(gomock.Any(), "t1").Return(tt.tenant, tt.tenantErr).Times(1)
+
+			blockRepoMock := mocks.NewMockBlockRepository(ctrl)
+			blockRepoMock.EXPECT().GetLastParsedBlock(gomock.Any()).Return(entity.Block{Number: 10}, nil).Times(1)
+
+			subscriberRepoMock := mocks.NewMockSubscriberRepository(ctrl)
+			wantFromBlock := entity.BlockNumber(0)
+			if tt.tracked {
+				subscriberRepoMock.EXPECT().Get(gomock.Any(), address).Return(entity.Subscriber{Address: address}, nil).Times(1)
+				wantFromBlock = 11
+			} else {
+				subscriberRepoMock.EXPECT().Get(gomock.Any(), address).Return(entity.Subscriber{}, errorpkg.SubscriberNotFound).Times(1)
+			}
+
+			subscriptionRepoMock := mocks.NewMockSubscriptionRepository(ctrl)
+			subscriptionRepoMock.EXPECT().SaveWithinLimit(gomock.Any(), gomock.Any(), tt.wantLimit).
+				DoAndReturn(func(_ context.Context, subscriptions []entity.Subscription, _ int) ([]entity.Subscription, error) {
+					if len(subscriptions) != 1 || subscriptions[0].TenantID != "t1" || subscriptions[0].Address != address {
+						t.Errorf("SaveWithinLimit() subscriptions = %v, want subscription of t1 to %v", subscriptions, address)
+					} else if subscriptions[0].FromBlock != wantFromBlock {
+						t.Errorf("SaveWithinLimit() first block got = %v, want %v", subscriptions[0].FromBlock, wantFromBlock)
+					}
+					if tt.saveErr != nil {
+						return nil, tt.saveErr
+					}
+
+					return subscriptions, nil
+				}).Times(1)
+
+			balanceRepoMock := mocks.NewMockBalanceRepository(ctrl)
+			blockChainClientMock := mocks.NewMockBlockChainClient(ctrl)
+			if tt.nodeErr != nil {
+				// the address isn't tracked without the snapshot
+				balanceRepoMock.EXPECT().GetSnapshot(gomock.Any(), address).Return(entity.BalanceSnapshot{}, errorpkg.BalanceNotTracked).Times(1)
+				blockChainClientMock.EXPECT().GetBlockNumber(gomock.Any()).Return(entity.BlockNumber(0), tt.nodeErr).Times(1)
+				subscriptionRepoMock.EXPECT().Delete(gomock.Any(), "t1", address).Return(nil).Times(1)
+			}
+			if tt.wantErr == nil {
+				subscriberRepoMock.EXPECT().Save(gomock.Any(), entity.Subscriber{Address: address}).Return(nil).Times(1)
+				balanceRepoMock.EXPECT().GetSnapshot(gomock.Any(), address).Return(entity.BalanceSnapshot{Address: address}, nil).Times(1)
+			}
+
+			p := NewParser(nil, subscriberRepoMock, subscriptionRepoMock, tenantRepoMock, blockRepoMock, balanceRepoMock, blockChainClientMock, 2)
+
+			if err := p.Subscribe(context.Background(), "t1", address); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Subscribe() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParser_Unsubscribe(t *testing.T) {
+	address := entity.Address("0xd7def8de6bff40e7fa3a19b6749aca84bd5ba0ae")
+
+	tests := []struct {
+		name      string
+		deleteErr error
+		wantErr   error
+	}{
+		{
+			name: "subscription is deleted",
+		},
+		{
+			name:      "tenant isn't subscribed",
+			deleteErr: errorpkg.SubscriberNotFound,
+			wantErr:   errorpkg.SubscriberNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			subscriptionRepoMock := mocks.NewMockSubscriptionRepository(ctrl)
+			subscriptionRepoMock.EXPECT().Delete(gomock.Any(), "t1", address).Return(tt.deleteErr).Times(1)
+
+			// the subscriber is kept, so the address is still parsed
+			subscriberRepoMock := mocks.NewMockSubscriberRepository(ctrl)
+
+			p := NewParser(nil, subscriberRepoMock, subscriptionRepoMock, nil, nil, nil, nil, 0)
+
+			if err := p.Unsubscribe(context.Background(), "t1", address); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Unsubscribe() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -4,50 +4,116 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"blockchain-parser/internal/constant"
 	"blockchain-parser/internal/entity"
 	errorpkg "blockchain-parser/internal/error"
+	"blockchain-parser/internal/metrics"
+	"blockchain-parser/tools/logger"
+	"blockchain-parser/tools/tracing"
+)
+
+const (
+	// parentWaitInterval and parentWaitTimeout bound the wait of the deferred block for its parent
+	parentWaitInterval = 100 * time.Millisecond
+	parentWaitTimeout  = 10 * time.Second
 )
 
 type ParserWorker struct {
 	txnRepo          TransactionRepository
 	subscriberRepo   SubscriberRepository
 	blockRepo        BlockRepository
+	eventRepo        EventRepository
+	balanceRepo      BalanceRepository
 	blockChainClient BlockChainClient
 	locker           Locker
+
+	confirmationDepth      int
+	traceInternalTransfers bool
+
+	// log is used when the run context doesn't carry a logger
+	log logger.Logger
+
+	// lastRunAt is unix time in nanoseconds of the last run of any worker, it's checked by readiness probe
+	lastRunAt atomic.Int64
+	// paused stops runs in progress after their current block
+	paused atomic.Bool
+
+	// published keeps transaction events of blocks which aren't parsed yet, so retries don't publish them again
+	publishedMu sync.Mutex
+	published   map[entity.BlockNumber]publishedBlock
+}
+
+// publishedBlock is the hash of the block with indexes of its published transactions, the block
+// with another hash after reorg publishes its transactions again
+type publishedBlock struct {
+	hash    string
+	indexes map[int]struct{}
 }
 
 func NewParserWorker(
 	txnRepo TransactionRepository,
 	subscriberRepo SubscriberRepository,
 	blockRepo BlockRepository,
+	eventRepo EventRepository,
+	balanceRepo BalanceRepository,
 	blockChainClient BlockChainClient,
 	locker Locker,
+	confirmationDepth int,
+	traceInternalTransfers bool,
+	log logger.Logger,
 ) *ParserWorker {
 	return &ParserWorker{
-		txnRepo:          txnRepo,
-		subscriberRepo:   subscriberRepo,
-		blockRepo:        blockRepo,
-		blockChainClient: blockChainClient,
-		locker:           locker,
+		txnRepo:                txnRepo,
+		subscriberRepo:         subscriberRepo,
+		blockRepo:              blockRepo,
+		eventRepo:              eventRepo,
+		balanceRepo:            balanceRepo,
+		blockChainClient:       blockChainClient,
+		locker:                 locker,
+		confirmationDepth:      confirmationDepth,
+		traceInternalTransfers: traceInternalTransfers,
+		log:                    log,
+		published:              map[entity.BlockNumber]publishedBlock{},
 	}
 }
 
-func (w *ParserWorker) Run(ctx context.Context) error {
+func (w *ParserWorker) Run(ctx context.Context) (err error) {
+	w.lastRunAt.Store(time.Now().UnixNano())
+
+	ctx, span := tracing.Start(ctx, "ParserWorker.Run")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
 	blockNumber, err := w.blockChainClient.GetBlockNumber(ctx)
 	if err != nil {
 		return fmt.Errorf("fail get block number in ParserWorker: %w", err)
 	}
+	metrics.HeadBlock.Set(float64(blockNumber))
+
+	log := logger.FromContext(ctx, w.log)
 
 	countParsedBlocks := 0
 	defer func() {
-		log.Printf("count parsed blocks: %d", countParsedBlocks)
+		log.Debug("parsed blocks", logger.Int("count", countParsedBlocks), logger.Uint64("head_block", uint64(blockNumber)))
+		span.SetAttributes(tracing.Int("parsed_blocks", countParsedBlocks), tracing.Uint64("head_block", uint64(blockNumber)))
 	}()
 
 	for {
+		// the run is cancelled on shutdown, the next block isn't taken
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		// the paused run returns after the block in progress, e.g. during catch-up in node maintenance
+		if w.paused.Load() {
+			return nil
+		}
+
 		block, err := w.getProcessingBlock(ctx, blockNumber)
 		if errors.Is(err, errorpkg.NoBlockForParsing) {
 			return nil
@@ -56,50 +122,167 @@ func (w *ParserWorker) Run(ctx context.Context) error {
 			return err
 		}
 
-		if err := w.processBlock(ctx, block); err != nil {
+		block.Hash, err = w.processBlock(ctx, block)
+		if errors.Is(err, errorpkg.ParentNotParsed) && w.waitParentBlock(ctx, block.Number) {
+			block.Hash, err = w.processBlock(ctx, block)
+		}
+		if errors.Is(err, errorpkg.ParentNotParsed) {
+			// the parent isn't parsed in time, the block is released and taken again after it
+			log.Debug("parent block is not parsed", logger.Uint64("block_number", uint64(block.Number)))
 			w.failBlockProcessing(ctx, block)
 
+			return nil
+		}
+		if err != nil {
+			log.Warn("fail parse block", logger.Uint64("block_number", uint64(block.Number)), logger.Err(err))
+			w.failBlockProcessing(ctx, block)
+			metrics.BlocksFailed.Inc()
+
 			return err
 		}
 
 		w.markBlockAsParsed(ctx, block)
+		metrics.BlocksParsed.Inc()
+		w.publishConfirmations(ctx, block)
 
 		countParsedBlocks++
 	}
 }
 
-func (w *ParserWorker) processBlock(ctx context.Context, block entity.Block) error {
-	txns, err := w.blockChainClient.GetTxnsByBlockByNumber(ctx, block.Number)
+// SetPaused pauses or resumes runs in progress, scheduling of runs is paused by the scheduler
+func (w *ParserWorker) SetPaused(paused bool) {
+	w.paused.Store(paused)
+}
+
+// LastRunAt returns start time of the last run, zero time if workers haven't run yet
+func (w *ParserWorker) LastRunAt() time.Time {
+	lastRunAt := w.lastRunAt.Load()
+	if lastRunAt == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(0, lastRunAt)
+}
+
+func (w *ParserWorker) processBlock(ctx context.Context, block entity.Block) (_ string, err error) {
+	ctx, span := tracing.Start(ctx, "ParserWorker.processBlock", tracing.Uint64("block_number", uint64(block.Number)))
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	chainBlock, err := w.blockChainClient.GetBlockByNumber(ctx, block.Number)
 	if err != nil {
-		return fmt.Errorf("fail get transactions in ParserWorker: %w", err)
+		return "", fmt.Errorf("fail get transactions in ParserWorker: %w", err)
+	}
+
+	if err := w.checkReorg(ctx, chainBlock); err != nil {
+		return "", err
+	}
+
+	txns, err := matchTransactions(ctx, w.subscriberRepo, chainBlock, nil)
+	if err != nil {
+		return "", fmt.Errorf("fail match transactions in ParserWorker: %w", err)
 	}
 
 	for _, txn := range txns {
-		toOk, err := w.checkSubscription(ctx, txn.To)
-		if err != nil {
-			return fmt.Errorf("fail get check subscription in ParserWorker: %w", err)
+		if err := w.txnRepo.Save(ctx, txn); err != nil {
+			return "", fmt.Errorf("fail save trasaction in ParserWorker: %w", err)
 		}
 
-		fromOk, err := w.checkSubscription(ctx, txn.From)
-		if err != nil {
-			return fmt.Errorf("fail get check subscription in ParserWorker: %w", err)
+		if w.markPublished(chainBlock, txn) {
+			w.publishEvent(ctx, constant.EventTypeTransaction, txn, 1)
 		}
+	}
 
-		if toOk || fromOk {
-			if err := w.txnRepo.Save(ctx, txn); err != nil {
-				return fmt.Errorf("fail save trasaction in ParserWorker: %w", err)
-			}
-		}
+	if err := w.trackBalances(ctx, chainBlock); err != nil {
+		return "", fmt.Errorf("fail track balances in ParserWorker: %w", err)
 	}
 
-	return nil
+	return chainBlock.Hash, nil
 }
 
-func (w *ParserWorker) getProcessingBlock(ctx context.Context, blockNumber int) (entity.Block, error) {
+// checkReorg compares parent hash of the block with the hash of the parsed previous block.
+// On mismatch transactions of the previous block are retracted and the block is sent to reparsing.
+// The block is deferred while the previous block isn't parsed, e.g. it's parsed by another worker.
+func (w *ParserWorker) checkReorg(ctx context.Context, chainBlock entity.ChainBlock) error {
+	if chainBlock.Number == 0 {
+		return nil
+	}
+
+	prevBlock, err := w.blockRepo.GetParsedBlock(ctx, chainBlock.Number-1)
+	if errors.Is(err, errorpkg.BlockNotFound) {
+		return fmt.Errorf("previous block of block (%d) in checkReorg: %w", chainBlock.Number, errorpkg.ParentNotParsed)
+	}
+	if err != nil {
+		return fmt.Errorf("fail get previous block in checkReorg: %w", err)
+	}
+
+	if prevBlock.Hash == "" || prevBlock.Hash == chainBlock.ParentHash {
+		return nil
+	}
+
+	txns, err := w.txnRepo.GetTxnsByBlockNumber(ctx, prevBlock.Number)
+	if err != nil {
+		return fmt.Errorf("fail get transactions of block (%d) in checkReorg: %w", prevBlock.Number, err)
+	}
+
+	if err := w.txnRepo.DeleteByBlockNumber(ctx, prevBlock.Number); err != nil {
+		return fmt.Errorf("fail delete transactions of block (%d) in checkReorg: %w", prevBlock.Number, err)
+	}
+
+	if err := w.balanceRepo.DeleteByBlockNumber(ctx, prevBlock.Number); err != nil {
+		return fmt.Errorf("fail delete balance changes of block (%d) in checkReorg: %w", prevBlock.Number, err)
+	}
+
+	for _, txn := range txns {
+		w.publishEvent(ctx, constant.EventTypeRetraction, txn, 0)
+	}
+
+	w.failBlockProcessing(ctx, prevBlock)
+
+	return fmt.Errorf("parent hash of block (%d) mismatch: %w", chainBlock.Number, errorpkg.ReorgDetected)
+}
+
+// waitParentBlock waits while the parent of the block is parsed by another worker,
+// the block stays in processing, so other workers take next blocks meanwhile.
+func (w *ParserWorker) waitParentBlock(ctx context.Context, blockNumber entity.BlockNumber) bool {
+	timeout := time.NewTimer(parentWaitTimeout)
+	defer timeout.Stop()
+
+	ticker := time.NewTicker(parentWaitInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-timeout.C:
+			return false
+		case <-ticker.C:
+		}
+
+		_, err := w.blockRepo.GetParsedBlock(ctx, blockNumber-1)
+		if err == nil {
+			return true
+		}
+		if !errors.Is(err, errorpkg.BlockNotFound) {
+			return false
+		}
+	}
+}
+
+func (w *ParserWorker) getProcessingBlock(ctx context.Context, blockNumber entity.BlockNumber) (entity.Block, error) {
+	// lock wait is traced separately, it grows with the count of workers
+	_, lockSpan := tracing.Start(ctx, "ParserWorker.lock")
 	w.locker.Lock()
+	lockSpan.End()
 	defer w.locker.Unlock()
 
 	block, err := w.blockRepo.GetFailedBlock(ctx)
+	if err == nil {
+		metrics.BlocksRetried.Inc()
+	}
 	if errors.Is(err, errorpkg.BlockNotFound) {
 		lastBlock, err := w.blockRepo.GetLastBlock(ctx)
 		if err != nil {
@@ -125,26 +308,164 @@ func (w *ParserWorker) getProcessingBlock(ctx context.Context, blockNumber int)
 	return block, nil
 }
 
+// failBlockProcessing releases the block for retry. It's done even when the run is cancelled,
+// otherwise the block stays in processing until its TTL expires.
 func (w *ParserWorker) failBlockProcessing(ctx context.Context, block entity.Block) {
 	block.Status = constant.BlockStatusFailed
 	block.UpdatedAt = time.Now()
 
-	if err := w.blockRepo.Upsert(ctx, block); err != nil {
-		log.Printf("fail save block (%d) in failBlockProcessing: %s\n", block.Number, err)
+	if err := w.blockRepo.Upsert(withoutCancel(ctx), block); err != nil {
+		logger.FromContext(ctx, w.log).Error("fail save block in failBlockProcessing",
+			logger.Uint64("block_number", uint64(block.Number)), logger.Err(err))
 	}
 }
 
+// markBlockAsParsed commits the processed block, it's done even when the run is cancelled after processing
 func (w *ParserWorker) markBlockAsParsed(ctx context.Context, block entity.Block) {
 	block.Status = constant.BlockStatusParsed
 	block.UpdatedAt = time.Now()
 
-	if err := w.blockRepo.Upsert(ctx, block); err != nil {
-		log.Printf("fail save block (%d) in markBlockAsParsed: %s\n", block.Number, err)
+	if err := w.blockRepo.Upsert(withoutCancel(ctx), block); err != nil {
+		logger.FromContext(ctx, w.log).Error("fail save block in markBlockAsParsed",
+			logger.Uint64("block_number", uint64(block.Number)), logger.Err(err))
+
+		return
+	}
+
+	w.publishedMu.Lock()
+	delete(w.published, block.Number)
+	w.publishedMu.Unlock()
+}
+
+// markPublished reports whether the transaction event of the block isn't published yet and marks it as published
+func (w *ParserWorker) markPublished(chainBlock entity.ChainBlock, txn entity.Transaction) bool {
+	w.publishedMu.Lock()
+	defer w.publishedMu.Unlock()
+
+	published, ok := w.published[chainBlock.Number]
+	if !ok || published.hash != chainBlock.Hash {
+		published = publishedBlock{
+			hash:    chainBlock.Hash,
+			indexes: map[int]struct{}{},
+		}
+		w.published[chainBlock.Number] = published
+	}
+
+	if _, ok := published.indexes[txn.TransactionIndex]; ok {
+		return false
+	}
+	published.indexes[txn.TransactionIndex] = struct{}{}
+
+	return true
+}
+
+// publishConfirmations notifies about transactions which got one more confirmation by the block.
+func (w *ParserWorker) publishConfirmations(ctx context.Context, block entity.Block) {
+	for depth := 1; depth < w.confirmationDepth && entity.BlockNumber(depth) <= block.Number; depth++ {
+		blockNumber := block.Number - entity.BlockNumber(depth)
+
+		txns, err := w.txnRepo.GetTxnsByBlockNumber(ctx, blockNumber)
+		if err != nil {
+			logger.FromContext(ctx, w.log).Error("fail get transactions of block in publishConfirmations",
+				logger.Uint64("block_number", uint64(blockNumber)), logger.Err(err))
+
+			return
+		}
+
+		for _, txn := range txns {
+			w.publishEvent(ctx, constant.EventTypeConfirmation, txn, depth+1)
+		}
+	}
+}
+
+func (w *ParserWorker) publishEvent(ctx context.Context, eventType string, txn entity.Transaction, confirmations int) {
+	event := entity.Event{
+		Type:          eventType,
+		Addresses:     []entity.Address{txn.From, txn.To},
+		Transaction:   txn,
+		Confirmations: confirmations,
+	}
+
+	if err := w.eventRepo.Save(ctx, event); err != nil {
+		logger.FromContext(ctx, w.log).Error("fail save event in publishEvent",
+			logger.String("event_type", eventType), logger.String("txn_hash", txn.Hash), logger.Err(err))
 	}
 }
 
-func (w *ParserWorker) checkSubscription(ctx context.Context, address string) (bool, error) {
-	_, err := w.subscriberRepo.Get(ctx, address)
+// mapWithdrawalToTransaction stores the withdrawal as a transfer to the address, withdrawals follow transactions of the block.
+func mapWithdrawalToTransaction(chainBlock entity.ChainBlock, position int) entity.Transaction {
+	withdrawal := chainBlock.Withdrawals[position]
+
+	return entity.Transaction{
+		Kind:             constant.TransactionKindWithdrawal,
+		To:               withdrawal.Address,
+		Value:            withdrawal.Amount,
+		BlockNumber:      chainBlock.Number,
+		TransactionIndex: len(chainBlock.Transactions) + position,
+		Timestamp:        chainBlock.Timestamp,
+		Withdrawal:       &withdrawal,
+	}
+}
+
+// matchTransactions returns transactions and withdrawals of the block which touch subscribed addresses.
+// Not empty addresses limit matching to these addresses, it's used by reparse.
+func matchTransactions(
+	ctx context.Context,
+	subscriberRepo SubscriberRepository,
+	chainBlock entity.ChainBlock,
+	addresses []entity.Address,
+) ([]entity.Transaction, error) {
+	match := func(address entity.Address) (bool, error) {
+		if len(addresses) > 0 && !containsAddress(addresses, address) {
+			return false, nil
+		}
+
+		return checkSubscription(ctx, subscriberRepo, address)
+	}
+
+	txns := make([]entity.Transaction, 0)
+	for _, txn := range chainBlock.Transactions {
+		toOk, err := match(txn.To)
+		if err != nil {
+			return nil, fmt.Errorf("fail get check subscription in matchTransactions: %w", err)
+		}
+
+		fromOk, err := match(txn.From)
+		if err != nil {
+			return nil, fmt.Errorf("fail get check subscription in matchTransactions: %w", err)
+		}
+
+		if toOk || fromOk {
+			txns = append(txns, txn)
+		}
+	}
+
+	for i, withdrawal := range chainBlock.Withdrawals {
+		ok, err := match(withdrawal.Address)
+		if err != nil {
+			return nil, fmt.Errorf("fail get check subscription in matchTransactions: %w", err)
+		}
+
+		if ok {
+			txns = append(txns, mapWithdrawalToTransaction(chainBlock, i))
+		}
+	}
+
+	return txns, nil
+}
+
+func containsAddress(addresses []entity.Address, address entity.Address) bool {
+	for _, a := range addresses {
+		if a == address {
+			return true
+		}
+	}
+
+	return false
+}
+
+func checkSubscription(ctx context.Context, subscriberRepo SubscriberRepository, address entity.Address) (bool, error) {
+	_, err := subscriberRepo.Get(ctx, address)
 	if err != nil {
 		if errors.Is(err, errorpkg.SubscriberNotFound) {
 			return false, nil
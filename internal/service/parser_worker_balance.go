@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"blockchain-parser/internal/constant"
+	"blockchain-parser/internal/entity"
+	errorpkg "blockchain-parser/internal/error"
+)
+
+const (
+	balanceChangeDirectionIn  = "in"
+	balanceChangeDirectionOut = "out"
+)
+
+// trackBalances records balance changes of the block for addresses with balance snapshot.
+// Value of a failed transaction isn't transferred, but the sender pays the fee anyway.
+func (w *ParserWorker) trackBalances(ctx context.Context, chainBlock entity.ChainBlock) error {
+	changes := []entity.BalanceChange{}
+
+	for _, txn := range chainBlock.Transactions {
+		fromOk, err := w.checkBalanceTracking(ctx, txn.From, chainBlock.Number)
+		if err != nil {
+			return err
+		}
+
+		toOk, err := w.checkBalanceTracking(ctx, txn.To, chainBlock.Number)
+		if err != nil {
+			return err
+		}
+
+		if !fromOk && !toOk {
+			continue
+		}
+
+		receipt, err := w.blockChainClient.GetTransactionReceipt(ctx, txn.Hash)
+		if err != nil {
+			return fmt.Errorf("fail get receipt of transaction (%s): %w", txn.Hash, err)
+		}
+
+		if receipt.Success && fromOk {
+			changes = append(changes, newBalanceChange(
+				txn.From, chainBlock.Number, constant.BalanceChangeReasonTransaction, balanceChangeDirectionOut, txn.Hash, txn.Hash, txn.Value.Neg(),
+			))
+		}
+
+		if receipt.Success && toOk {
+			changes = append(changes, newBalanceChange(
+				txn.To, chainBlock.Number, constant.BalanceChangeReasonTransaction, balanceChangeDirectionIn, txn.Hash, txn.Hash, txn.Value,
+			))
+		}
+
+		if fromOk {
+			changes = append(changes, newBalanceChange(
+				txn.From, chainBlock.Number, constant.BalanceChangeReasonFee, balanceChangeDirectionOut, txn.Hash, txn.Hash, receipt.Fee.Neg(),
+			))
+		}
+	}
+
+	for _, withdrawal := range chainBlock.Withdrawals {
+		ok, err := w.checkBalanceTracking(ctx, withdrawal.Address, chainBlock.Number)
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			changes = append(changes, newBalanceChange(
+				withdrawal.Address, chainBlock.Number, constant.BalanceChangeReasonWithdrawal, balanceChangeDirectionIn, fmt.Sprint(withdrawal.Index), "", withdrawal.Amount,
+			))
+		}
+	}
+
+	internalChanges, err := w.getInternalTransferChanges(ctx, chainBlock.Number)
+	if err != nil {
+		return err
+	}
+	changes = append(changes, internalChanges...)
+
+	for _, change := range changes {
+		if err := w.balanceRepo.SaveChange(ctx, change); err != nil {
+			return fmt.Errorf("fail save balance change (%s) of address (%s): %w", change.ID, change.Address, err)
+		}
+	}
+
+	return nil
+}
+
+// getInternalTransferChanges returns changes made by contract calls. Tracing is optional because not every node
+// supports it, without tracing such changes are corrected by reconciliation.
+func (w *ParserWorker) getInternalTransferChanges(ctx context.Context, blockNumber entity.BlockNumber) ([]entity.BalanceChange, error) {
+	if !w.traceInternalTransfers {
+		return nil, nil
+	}
+
+	transfers, err := w.blockChainClient.GetInternalTransfers(ctx, blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("fail get internal transfers: %w", err)
+	}
+
+	changes := []entity.BalanceChange{}
+	for _, transfer := range transfers {
+		sourceID := transfer.Hash + "_" + transfer.TraceAddress
+
+		fromOk, err := w.checkBalanceTracking(ctx, transfer.From, blockNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		if fromOk {
+			changes = append(changes, newBalanceChange(
+				transfer.From, blockNumber, constant.BalanceChangeReasonInternalTransfer, balanceChangeDirectionOut, sourceID, transfer.Hash, transfer.Value.Neg(),
+			))
+		}
+
+		toOk, err := w.checkBalanceTracking(ctx, transfer.To, blockNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		if toOk {
+			changes = append(changes, newBalanceChange(
+				transfer.To, blockNumber, constant.BalanceChangeReasonInternalTransfer, balanceChangeDirectionIn, sourceID, transfer.Hash, transfer.Value,
+			))
+		}
+	}
+
+	return changes, nil
+}
+
+// checkBalanceTracking reports whether changes of the address in the block are applied to its running balance.
+// Blocks up to the snapshot are already included in the snapshot value.
+func (w *ParserWorker) checkBalanceTracking(ctx context.Context, address entity.Address, blockNumber entity.BlockNumber) (bool, error) {
+	if address == "" {
+		return false, nil
+	}
+
+	snapshot, err := w.balanceRepo.GetSnapshot(ctx, address)
+	if errors.Is(err, errorpkg.BalanceNotTracked) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("fail get balance snapshot of address (%s): %w", address, err)
+	}
+
+	return blockNumber > snapshot.BlockNumber, nil
+}
+
+// newBalanceChange builds the change, sourceID identifies the transaction, the withdrawal or the call inside the block
+func newBalanceChange(address entity.Address, blockNumber entity.BlockNumber, reason, direction, sourceID, hash string, delta entity.Wei) entity.BalanceChange {
+	return entity.BalanceChange{
+		ID:          fmt.Sprintf("%s_%s_%s", reason, direction, sourceID),
+		Address:     address,
+		BlockNumber: blockNumber,
+		Reason:      reason,
+		Hash:        hash,
+		Delta:       delta,
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"blockchain-parser/internal/entity"
 	errorpkg "blockchain-parser/internal/error"
 	"blockchain-parser/internal/service/mocks"
+	"blockchain-parser/tools/logger"
 )
 
 func TestParserWorker_getProcessingBlock(t *testing.T) {
@@ -50,7 +51,12 @@ func TestParserWorker_getProcessingBlock(t *testing.T) {
 			nil,
 			blockRepoMock,
 			nil,
+			nil,
+			nil,
 			lockerMock,
+			0,
+			false,
+			logger.Nop(),
 		)
 
 		block, err := w.getProcessingBlock(ctx, 0)
@@ -96,7 +102,12 @@ func TestParserWorker_getProcessingBlock(t *testing.T) {
 			nil,
 			blockRepoMock,
 			nil,
+			nil,
+			nil,
 			lockerMock,
+			0,
+			false,
+			logger.Nop(),
 		)
 
 		block, err := w.getProcessingBlock(ctx, 10)
@@ -141,7 +152,12 @@ func TestParserWorker_getProcessingBlock(t *testing.T) {
 			nil,
 			blockRepoMock,
 			nil,
+			nil,
+			nil,
 			lockerMock,
+			0,
+			false,
+			logger.Nop(),
 		)
 
 		block, err := w.getProcessingBlock(ctx, 1)
@@ -155,6 +171,37 @@ func TestParserWorker_getProcessingBlock(t *testing.T) {
 	})
 }
 
+func TestParserWorker_Run(t *testing.T) {
+	t.Run("paused run takes no block", func(tt *testing.T) {
+		ctx := context.Background()
+
+		ctrl := gomock.NewController(tt)
+		blockChainClientMock := mocks.NewMockBlockChainClient(ctrl)
+		blockChainClientMock.EXPECT().GetBlockNumber(gomock.Any()).Return(entity.BlockNumber(10), nil).Times(1)
+
+		// the block repository isn't called, the next block isn't claimed
+		blockRepoMock := mocks.NewMockBlockRepository(ctrl)
+
+		w := NewParserWorker(
+			nil,
+			nil,
+			blockRepoMock,
+			nil,
+			nil,
+			blockChainClientMock,
+			nil,
+			0,
+			false,
+			logger.Nop(),
+		)
+		w.SetPaused(true)
+
+		if err := w.Run(ctx); err != nil {
+			tt.Errorf("Run() error = %v, wantErr %v", err, nil)
+		}
+	})
+}
+
 func TestParserWorker_processBlock(t *testing.T) {
 
 	t.Run("getting txns failed", func(tt *testing.T) {
@@ -167,17 +214,22 @@ func TestParserWorker_processBlock(t *testing.T) {
 
 		ctrl := gomock.NewController(nil)
 		blockChainClientMock := mocks.NewMockBlockChainClient(ctrl)
-		blockChainClientMock.EXPECT().GetTxnsByBlockByNumber(ctx, block.Number).Return(nil, gettingTxnsError).Times(1)
+		blockChainClientMock.EXPECT().GetBlockByNumber(ctx, block.Number).Return(entity.ChainBlock{}, gettingTxnsError).Times(1)
 
 		w := NewParserWorker(
+			nil,
+			nil,
 			nil,
 			nil,
 			nil,
 			blockChainClientMock,
 			nil,
+			0,
+			false,
+			logger.Nop(),
 		)
 
-		err := w.processBlock(ctx, block)
+		_, err := w.processBlock(ctx, block)
 		if !errors.Is(err, gettingTxnsError) {
 			t.Errorf("get process block error = %v, wantErr %v", err, nil)
 			return
@@ -194,21 +246,21 @@ func TestParserWorker_processBlock(t *testing.T) {
 			{
 				From:             "0xd7def8de6bff40e7fa3a19b6749aca84bd5ba0ae",
 				To:               "0x00000000006c3852cbef3e08e8df289169ede581",
-				Value:            "0xb1a2bc2ec50000",
+				Value:            entity.NewWeiFromInt64(0xb1a2bc2ec50000),
 				BlockNumber:      34534,
 				TransactionIndex: 0,
 			},
 			{
 				From:             "0x069acf904f610cbf8ef1540349092852e46b4e95",
 				To:               "0x8e9f0cd8f96e8e7b6531d01617e883d67f9dd150",
-				Value:            "0x5f3bcfe512dcc00",
+				Value:            entity.NewWeiFromInt64(0x5f3bcfe512dcc00),
 				BlockNumber:      34534,
 				TransactionIndex: 1,
 			},
 			{
 				From:             "0x4d7f1790644af787933c9ff0e2cff9a9b4299abb",
 				To:               "0x417651e5e427b77fb1c258a9fbdbf4632fe348f9",
-				Value:            "0x2386f26fc100000",
+				Value:            entity.NewWeiFromInt64(0x2386f26fc100000),
 				BlockNumber:      34534,
 				TransactionIndex: 2,
 			},
@@ -217,20 +269,28 @@ func TestParserWorker_processBlock(t *testing.T) {
 
 		ctrl := gomock.NewController(nil)
 		blockChainClientMock := mocks.NewMockBlockChainClient(ctrl)
-		blockChainClientMock.EXPECT().GetTxnsByBlockByNumber(ctx, block.Number).Return(txns, nil).Times(1)
+		blockChainClientMock.EXPECT().GetBlockByNumber(ctx, block.Number).Return(entity.ChainBlock{Number: block.Number, Hash: "0x1f", Transactions: txns}, nil).Times(1)
+
+		blockRepoMock := mocks.NewMockBlockRepository(ctrl)
+		blockRepoMock.EXPECT().GetParsedBlock(ctx, block.Number-1).Return(entity.Block{Number: block.Number - 1, Status: constant.BlockStatusParsed}, nil).Times(1)
 
 		subscriptionRepoMock := mocks.NewMockSubscriberRepository(ctrl)
-		subscriptionRepoMock.EXPECT().Get(ctx, "0x00000000006c3852cbef3e08e8df289169ede581").Return(entity.Subscriber{}, failCheckSubscriptionErr).Times(1)
+		subscriptionRepoMock.EXPECT().Get(ctx, entity.Address("0x00000000006c3852cbef3e08e8df289169ede581")).Return(entity.Subscriber{}, failCheckSubscriptionErr).Times(1)
 
 		w := NewParserWorker(
 			nil,
 			subscriptionRepoMock,
+			blockRepoMock,
+			nil,
 			nil,
 			blockChainClientMock,
 			nil,
+			0,
+			false,
+			logger.Nop(),
 		)
 
-		err := w.processBlock(ctx, block)
+		_, err := w.processBlock(ctx, block)
 		if !errors.Is(err, failCheckSubscriptionErr) {
 			t.Errorf("get process block error = %v, wantErr %v", err, nil)
 			return
@@ -247,21 +307,21 @@ func TestParserWorker_processBlock(t *testing.T) {
 			{
 				From:             "0xd7def8de6bff40e7fa3a19b6749aca84bd5ba0ae",
 				To:               "0x00000000006c3852cbef3e08e8df289169ede581",
-				Value:            "0xb1a2bc2ec50000",
+				Value:            entity.NewWeiFromInt64(0xb1a2bc2ec50000),
 				BlockNumber:      34534,
 				TransactionIndex: 0,
 			},
 			{
 				From:             "0x069acf904f610cbf8ef1540349092852e46b4e95",
 				To:               "0x8e9f0cd8f96e8e7b6531d01617e883d67f9dd150",
-				Value:            "0x5f3bcfe512dcc00",
+				Value:            entity.NewWeiFromInt64(0x5f3bcfe512dcc00),
 				BlockNumber:      34534,
 				TransactionIndex: 1,
 			},
 			{
 				From:             "0x4d7f1790644af787933c9ff0e2cff9a9b4299abb",
 				To:               "0x417651e5e427b77fb1c258a9fbdbf4632fe348f9",
-				Value:            "0x2386f26fc100000",
+				Value:            entity.NewWeiFromInt64(0x2386f26fc100000),
 				BlockNumber:      34534,
 				TransactionIndex: 2,
 			},
@@ -270,21 +330,29 @@ func TestParserWorker_processBlock(t *testing.T) {
 
 		ctrl := gomock.NewController(nil)
 		blockChainClientMock := mocks.NewMockBlockChainClient(ctrl)
-		blockChainClientMock.EXPECT().GetTxnsByBlockByNumber(ctx, block.Number).Return(txns, nil).Times(1)
+		blockChainClientMock.EXPECT().GetBlockByNumber(ctx, block.Number).Return(entity.ChainBlock{Number: block.Number, Hash: "0x1f", Transactions: txns}, nil).Times(1)
+
+		blockRepoMock := mocks.NewMockBlockRepository(ctrl)
+		blockRepoMock.EXPECT().GetParsedBlock(ctx, block.Number-1).Return(entity.Block{Number: block.Number - 1, Status: constant.BlockStatusParsed}, nil).Times(1)
 
 		subscriptionRepoMock := mocks.NewMockSubscriberRepository(ctrl)
-		subscriptionRepoMock.EXPECT().Get(ctx, "0x00000000006c3852cbef3e08e8df289169ede581").Return(entity.Subscriber{Address: "0x00000000006c3852cbef3e08e8df289169ede581"}, nil).Times(1)
-		subscriptionRepoMock.EXPECT().Get(ctx, "0xd7def8de6bff40e7fa3a19b6749aca84bd5ba0ae").Return(entity.Subscriber{}, failCheckSubscriptionErr).Times(1)
+		subscriptionRepoMock.EXPECT().Get(ctx, entity.Address("0x00000000006c3852cbef3e08e8df289169ede581")).Return(entity.Subscriber{Address: "0x00000000006c3852cbef3e08e8df289169ede581"}, nil).Times(1)
+		subscriptionRepoMock.EXPECT().Get(ctx, entity.Address("0xd7def8de6bff40e7fa3a19b6749aca84bd5ba0ae")).Return(entity.Subscriber{}, failCheckSubscriptionErr).Times(1)
 
 		w := NewParserWorker(
 			nil,
 			subscriptionRepoMock,
+			blockRepoMock,
+			nil,
 			nil,
 			blockChainClientMock,
 			nil,
+			0,
+			false,
+			logger.Nop(),
 		)
 
-		err := w.processBlock(ctx, block)
+		_, err := w.processBlock(ctx, block)
 		if !errors.Is(err, failCheckSubscriptionErr) {
 			t.Errorf("get process block error = %v, wantErr %v", err, nil)
 			return
@@ -301,21 +369,21 @@ func TestParserWorker_processBlock(t *testing.T) {
 		txn1 := entity.Transaction{
 			From:             "0xd7def8de6bff40e7fa3a19b6749aca84bd5ba0ae",
 			To:               "0x00000000006c3852cbef3e08e8df289169ede581",
-			Value:            "0xb1a2bc2ec50000",
+			Value:            entity.NewWeiFromInt64(0xb1a2bc2ec50000),
 			BlockNumber:      34534,
 			TransactionIndex: 0,
 		}
 		txn2 := entity.Transaction{
 			From:             "0x069acf904f610cbf8ef1540349092852e46b4e95",
 			To:               "0x8e9f0cd8f96e8e7b6531d01617e883d67f9dd150",
-			Value:            "0x5f3bcfe512dcc00",
+			Value:            entity.NewWeiFromInt64(0x5f3bcfe512dcc00),
 			BlockNumber:      34534,
 			TransactionIndex: 1,
 		}
 		txn3 := entity.Transaction{
 			From:             "0x4d7f1790644af787933c9ff0e2cff9a9b4299abb",
 			To:               "0x417651e5e427b77fb1c258a9fbdbf4632fe348f9",
-			Value:            "0x2386f26fc100000",
+			Value:            entity.NewWeiFromInt64(0x2386f26fc100000),
 			BlockNumber:      34534,
 			TransactionIndex: 2,
 		}
@@ -324,7 +392,10 @@ func TestParserWorker_processBlock(t *testing.T) {
 
 		ctrl := gomock.NewController(nil)
 		blockChainClientMock := mocks.NewMockBlockChainClient(ctrl)
-		blockChainClientMock.EXPECT().GetTxnsByBlockByNumber(ctx, block.Number).Return(txns, nil).Times(1)
+		blockChainClientMock.EXPECT().GetBlockByNumber(ctx, block.Number).Return(entity.ChainBlock{Number: block.Number, Hash: "0x1f", Transactions: txns}, nil).Times(1)
+
+		blockRepoMock := mocks.NewMockBlockRepository(ctrl)
+		blockRepoMock.EXPECT().GetParsedBlock(ctx, block.Number-1).Return(entity.Block{Number: block.Number - 1, Status: constant.BlockStatusParsed}, nil).Times(1)
 
 		subscriptionRepoMock := mocks.NewMockSubscriberRepository(ctrl)
 		subscriptionRepoMock.EXPECT().Get(ctx, txn1.To).Return(entity.Subscriber{Address: txn1.To}, nil).Times(1)
@@ -340,19 +411,132 @@ func TestParserWorker_processBlock(t *testing.T) {
 		txnRepoMock.EXPECT().Save(ctx, txn1).Return(nil).Times(1)
 		txnRepoMock.EXPECT().Save(ctx, txn2).Return(nil).Times(1)
 
+		eventRepoMock := mocks.NewMockEventRepository(ctrl)
+		eventRepoMock.EXPECT().Save(ctx, entity.Event{
+			Type:          constant.EventTypeTransaction,
+			Addresses:     []entity.Address{txn1.From, txn1.To},
+			Transaction:   txn1,
+			Confirmations: 1,
+		}).Return(nil).Times(1)
+		eventRepoMock.EXPECT().Save(ctx, entity.Event{
+			Type:          constant.EventTypeTransaction,
+			Addresses:     []entity.Address{txn2.From, txn2.To},
+			Transaction:   txn2,
+			Confirmations: 1,
+		}).Return(nil).Times(1)
+
+		balanceRepoMock := mocks.NewMockBalanceRepository(ctrl)
+		balanceRepoMock.EXPECT().GetSnapshot(ctx, gomock.Any()).Return(entity.BalanceSnapshot{}, errorpkg.BalanceNotTracked).AnyTimes()
+
 		w := NewParserWorker(
 			txnRepoMock,
 			subscriptionRepoMock,
-			nil,
+			blockRepoMock,
+			eventRepoMock,
+			balanceRepoMock,
 			blockChainClientMock,
 			nil,
+			0,
+			false,
+			logger.Nop(),
 		)
 
-		err := w.processBlock(ctx, block)
+		hash, err := w.processBlock(ctx, block)
 		if !errors.Is(err, nil) {
 			t.Errorf("get process block error = %v, wantErr %v", err, nil)
 			return
 		}
+		if hash != "0x1f" {
+			t.Errorf("get process block hash = %v, want %v", hash, "0x1f")
+		}
+	})
+
+	t.Run("withdrawal to subscribed address", func(tt *testing.T) {
+		ctx := context.Background()
+		block := entity.Block{
+			Number: 34534,
+			Status: constant.BlockStatusProcessing,
+		}
+
+		txn := entity.Transaction{
+			Hash:             "0x5a",
+			Kind:             constant.TransactionKindTransaction,
+			From:             "0x069acf904f610cbf8ef1540349092852e46b4e95",
+			To:               "0x8e9f0cd8f96e8e7b6531d01617e883d67f9dd150",
+			Value:            entity.NewWeiFromInt64(0x5f3bcfe512dcc00),
+			BlockNumber:      34534,
+			TransactionIndex: 0,
+		}
+		withdrawal1 := entity.Withdrawal{
+			Index:          41,
+			ValidatorIndex: 16,
+			Address:        "0x4d7f1790644af787933c9ff0e2cff9a9b4299abb",
+			Amount:         entity.NewWeiFromInt64(1_000_000_000),
+		}
+		withdrawal2 := entity.Withdrawal{
+			Index:          42,
+			ValidatorIndex: 17,
+			Address:        "0xd7def8de6bff40e7fa3a19b6749aca84bd5ba0ae",
+			Amount:         entity.NewWeiFromInt64(2_000_000_000),
+		}
+		chainBlock := entity.ChainBlock{
+			Number:       block.Number,
+			Hash:         "0x1f",
+			Transactions: []entity.Transaction{txn},
+			Withdrawals:  []entity.Withdrawal{withdrawal1, withdrawal2},
+		}
+		withdrawalTxn := entity.Transaction{
+			Kind:             constant.TransactionKindWithdrawal,
+			To:               withdrawal2.Address,
+			Value:            entity.NewWeiFromInt64(0x77359400),
+			BlockNumber:      34534,
+			TransactionIndex: 2,
+			Withdrawal:       &withdrawal2,
+		}
+
+		ctrl := gomock.NewController(t)
+		blockChainClientMock := mocks.NewMockBlockChainClient(ctrl)
+		blockChainClientMock.EXPECT().GetBlockByNumber(ctx, block.Number).Return(chainBlock, nil).Times(1)
+
+		blockRepoMock := mocks.NewMockBlockRepository(ctrl)
+		blockRepoMock.EXPECT().GetParsedBlock(ctx, block.Number-1).Return(entity.Block{Number: block.Number - 1, Status: constant.BlockStatusParsed}, nil).Times(1)
+
+		subscriptionRepoMock := mocks.NewMockSubscriberRepository(ctrl)
+		subscriptionRepoMock.EXPECT().Get(ctx, txn.To).Return(entity.Subscriber{}, errorpkg.SubscriberNotFound).Times(1)
+		subscriptionRepoMock.EXPECT().Get(ctx, txn.From).Return(entity.Subscriber{}, errorpkg.SubscriberNotFound).Times(1)
+		subscriptionRepoMock.EXPECT().Get(ctx, withdrawal1.Address).Return(entity.Subscriber{}, errorpkg.SubscriberNotFound).Times(1)
+		subscriptionRepoMock.EXPECT().Get(ctx, withdrawal2.Address).Return(entity.Subscriber{Address: withdrawal2.Address}, nil).Times(1)
+
+		txnRepoMock := mocks.NewMockTransactionRepository(ctrl)
+		txnRepoMock.EXPECT().Save(ctx, withdrawalTxn).Return(nil).Times(1)
+
+		eventRepoMock := mocks.NewMockEventRepository(ctrl)
+		eventRepoMock.EXPECT().Save(ctx, entity.Event{
+			Type:          constant.EventTypeTransaction,
+			Addresses:     []entity.Address{"", withdrawal2.Address},
+			Transaction:   withdrawalTxn,
+			Confirmations: 1,
+		}).Return(nil).Times(1)
+
+		balanceRepoMock := mocks.NewMockBalanceRepository(ctrl)
+		balanceRepoMock.EXPECT().GetSnapshot(ctx, gomock.Any()).Return(entity.BalanceSnapshot{}, errorpkg.BalanceNotTracked).AnyTimes()
+
+		w := NewParserWorker(
+			txnRepoMock,
+			subscriptionRepoMock,
+			blockRepoMock,
+			eventRepoMock,
+			balanceRepoMock,
+			blockChainClientMock,
+			nil,
+			0,
+			false,
+			logger.Nop(),
+		)
+
+		if _, err := w.processBlock(ctx, block); err != nil {
+			t.Errorf("get process block error = %v, wantErr %v", err, nil)
+		}
 	})
 
 	t.Run("saving transaction failed", func(tt *testing.T) {
@@ -365,7 +549,7 @@ func TestParserWorker_processBlock(t *testing.T) {
 		txn1 := entity.Transaction{
 			From:             "0xd7def8de6bff40e7fa3a19b6749aca84bd5ba0ae",
 			To:               "0x00000000006c3852cbef3e08e8df289169ede581",
-			Value:            "0xb1a2bc2ec50000",
+			Value:            entity.NewWeiFromInt64(0xb1a2bc2ec50000),
 			BlockNumber:      34534,
 			TransactionIndex: 0,
 		}
@@ -375,7 +559,10 @@ func TestParserWorker_processBlock(t *testing.T) {
 
 		ctrl := gomock.NewController(nil)
 		blockChainClientMock := mocks.NewMockBlockChainClient(ctrl)
-		blockChainClientMock.EXPECT().GetTxnsByBlockByNumber(ctx, block.Number).Return(txns, nil).Times(1)
+		blockChainClientMock.EXPECT().GetBlockByNumber(ctx, block.Number).Return(entity.ChainBlock{Number: block.Number, Hash: "0x1f", Transactions: txns}, nil).Times(1)
+
+		blockRepoMock := mocks.NewMockBlockRepository(ctrl)
+		blockRepoMock.EXPECT().GetParsedBlock(ctx, block.Number-1).Return(entity.Block{Number: block.Number - 1, Status: constant.BlockStatusParsed}, nil).Times(1)
 
 		subscriptionRepoMock := mocks.NewMockSubscriberRepository(ctrl)
 		subscriptionRepoMock.EXPECT().Get(ctx, txn1.To).Return(entity.Subscriber{Address: txn1.To}, nil).Times(1)
@@ -387,15 +574,375 @@ func TestParserWorker_processBlock(t *testing.T) {
 		w := NewParserWorker(
 			txnRepoMock,
 			subscriptionRepoMock,
+			blockRepoMock,
+			nil,
 			nil,
 			blockChainClientMock,
 			nil,
+			0,
+			false,
+			logger.Nop(),
 		)
 
-		err := w.processBlock(ctx, block)
+		_, err := w.processBlock(ctx, block)
 		if !errors.Is(err, savingTxnErr) {
 			t.Errorf("get process block error = %v, wantErr %v", err, nil)
 			return
 		}
 	})
+
+	t.Run("previous block is not parsed", func(tt *testing.T) {
+		ctx := context.Background()
+		block := entity.Block{
+			Number: 34534,
+			Status: constant.BlockStatusProcessing,
+		}
+
+		ctrl := gomock.NewController(tt)
+		blockChainClientMock := mocks.NewMockBlockChainClient(ctrl)
+		blockChainClientMock.EXPECT().GetBlockByNumber(ctx, block.Number).Return(entity.ChainBlock{Number: block.Number, Hash: "0x1f", ParentHash: "0x1e"}, nil).Times(1)
+
+		// the block is deferred before transactions are matched
+		blockRepoMock := mocks.NewMockBlockRepository(ctrl)
+		blockRepoMock.EXPECT().GetParsedBlock(ctx, block.Number-1).Return(entity.Block{}, errorpkg.BlockNotFound).Times(1)
+
+		w := NewParserWorker(
+			nil,
+			nil,
+			blockRepoMock,
+			nil,
+			nil,
+			blockChainClientMock,
+			nil,
+			0,
+			false,
+			logger.Nop(),
+		)
+
+		_, err := w.processBlock(ctx, block)
+		if !errors.Is(err, errorpkg.ParentNotParsed) {
+			tt.Errorf("get process block error = %v, wantErr %v", err, errorpkg.ParentNotParsed)
+		}
+	})
+
+	t.Run("retried block doesn't publish events again", func(tt *testing.T) {
+		ctx := context.Background()
+		block := entity.Block{
+			Number: 34534,
+			Status: constant.BlockStatusProcessing,
+		}
+
+		txn1 := entity.Transaction{
+			Hash:             "0x5a",
+			From:             "0xd7def8de6bff40e7fa3a19b6749aca84bd5ba0ae",
+			To:               "0x00000000006c3852cbef3e08e8df289169ede581",
+			Value:            entity.NewWeiFromInt64(0xb1a2bc2ec50000),
+			BlockNumber:      34534,
+			TransactionIndex: 0,
+		}
+		txn2 := entity.Transaction{
+			Hash:             "0x5b",
+			From:             txn1.From,
+			To:               txn1.To,
+			Value:            txn1.Value,
+			BlockNumber:      34534,
+			TransactionIndex: 1,
+		}
+		savingTxnErr := errors.New("error")
+
+		ctrl := gomock.NewController(tt)
+		blockChainClientMock := mocks.NewMockBlockChainClient(ctrl)
+		blockChainClientMock.EXPECT().GetBlockByNumber(ctx, block.Number).Return(entity.ChainBlock{Number: block.Number, Hash: "0x1f", Transactions: []entity.Transaction{txn1, txn2}}, nil).Times(2)
+
+		blockRepoMock := mocks.NewMockBlockRepository(ctrl)
+		blockRepoMock.EXPECT().GetParsedBlock(ctx, block.Number-1).Return(entity.Block{Number: block.Number - 1, Status: constant.BlockStatusParsed}, nil).Times(2)
+
+		subscriptionRepoMock := mocks.NewMockSubscriberRepository(ctrl)
+		subscriptionRepoMock.EXPECT().Get(ctx, txn1.To).Return(entity.Subscriber{Address: txn1.To}, nil).AnyTimes()
+		subscriptionRepoMock.EXPECT().Get(ctx, txn1.From).Return(entity.Subscriber{}, errorpkg.SubscriberNotFound).AnyTimes()
+
+		// the first attempt fails on the second transaction, the retry saves both
+		txnRepoMock := mocks.NewMockTransactionRepository(ctrl)
+		gomock.InOrder(
+			txnRepoMock.EXPECT().Save(ctx, txn1).Return(nil),
+			txnRepoMock.EXPECT().Save(ctx, txn2).Return(savingTxnErr),
+			txnRepoMock.EXPECT().Save(ctx, txn1).Return(nil),
+			txnRepoMock.EXPECT().Save(ctx, txn2).Return(nil),
+		)
+
+		eventRepoMock := mocks.NewMockEventRepository(ctrl)
+		for _, txn := range []entity.Transaction{txn1, txn2} {
+			eventRepoMock.EXPECT().Save(ctx, entity.Event{
+				Type:          constant.EventTypeTransaction,
+				Addresses:     []entity.Address{txn.From, txn.To},
+				Transaction:   txn,
+				Confirmations: 1,
+			}).Return(nil).Times(1)
+		}
+
+		balanceRepoMock := mocks.NewMockBalanceRepository(ctrl)
+		balanceRepoMock.EXPECT().GetSnapshot(ctx, gomock.Any()).Return(entity.BalanceSnapshot{}, errorpkg.BalanceNotTracked).AnyTimes()
+
+		w := NewParserWorker(
+			txnRepoMock,
+			subscriptionRepoMock,
+			blockRepoMock,
+			eventRepoMock,
+			balanceRepoMock,
+			blockChainClientMock,
+			nil,
+			0,
+			false,
+			logger.Nop(),
+		)
+
+		if _, err := w.processBlock(ctx, block); !errors.Is(err, savingTxnErr) {
+			tt.Fatalf("get process block error = %v, wantErr %v", err, savingTxnErr)
+		}
+		if _, err := w.processBlock(ctx, block); err != nil {
+			tt.Errorf("get process block of retry error = %v, wantErr %v", err, nil)
+		}
+	})
+
+	t.Run("reorg detected", func(tt *testing.T) {
+		ctx := context.Background()
+		now := time.Now()
+		block := entity.Block{
+			Number: 34534,
+			Status: constant.BlockStatusProcessing,
+		}
+		prevBlock := entity.Block{
+			Number: 34533,
+			Hash:   "0x1e",
+			Status: constant.BlockStatusParsed,
+		}
+		failedPrevBlock := entity.Block{
+			Number:    34533,
+			Hash:      "0x1e",
+			Status:    constant.BlockStatusFailed,
+			UpdatedAt: now,
+		}
+		chainBlock := entity.ChainBlock{
+			Number:     34534,
+			Hash:       "0x1f",
+			ParentHash: "0x2e",
+		}
+
+		txn := entity.Transaction{
+			Hash:             "0x5a",
+			From:             "0xd7def8de6bff40e7fa3a19b6749aca84bd5ba0ae",
+			To:               "0x00000000006c3852cbef3e08e8df289169ede581",
+			Value:            entity.NewWeiFromInt64(0xb1a2bc2ec50000),
+			BlockNumber:      34533,
+			TransactionIndex: 0,
+		}
+
+		ctrl := gomock.NewController(nil)
+		blockChainClientMock := mocks.NewMockBlockChainClient(ctrl)
+		blockChainClientMock.EXPECT().GetBlockByNumber(ctx, block.Number).Return(chainBlock, nil).Times(1)
+
+		blockRepoMock := mocks.NewMockBlockRepository(ctrl)
+		blockRepoMock.EXPECT().GetParsedBlock(ctx, prevBlock.Number).Return(prevBlock, nil).Times(1)
+		// the block is released with the context which isn't cancelled with the run
+		blockRepoMock.EXPECT().Upsert(gomock.Any(), failedPrevBlock).Return(nil).Times(1)
+
+		txnRepoMock := mocks.NewMockTransactionRepository(ctrl)
+		txnRepoMock.EXPECT().GetTxnsByBlockNumber(ctx, prevBlock.Number).Return([]entity.Transaction{txn}, nil).Times(1)
+		txnRepoMock.EXPECT().DeleteByBlockNumber(ctx, prevBlock.Number).Return(nil).Times(1)
+
+		balanceRepoMock := mocks.NewMockBalanceRepository(ctrl)
+		balanceRepoMock.EXPECT().DeleteByBlockNumber(ctx, prevBlock.Number).Return(nil).Times(1)
+
+		eventRepoMock := mocks.NewMockEventRepository(ctrl)
+		eventRepoMock.EXPECT().Save(ctx, entity.Event{
+			Type:        constant.EventTypeRetraction,
+			Addresses:   []entity.Address{txn.From, txn.To},
+			Transaction: txn,
+		}).Return(nil).Times(1)
+
+		monkey.Patch(time.Now, func() time.Time {
+			return now
+		})
+
+		w := NewParserWorker(
+			txnRepoMock,
+			nil,
+			blockRepoMock,
+			eventRepoMock,
+			balanceRepoMock,
+			blockChainClientMock,
+			nil,
+			0,
+			false,
+			logger.Nop(),
+		)
+
+		_, err := w.processBlock(ctx, block)
+		if !errors.Is(err, errorpkg.ReorgDetected) {
+			t.Errorf("get process block error = %v, wantErr %v", err, errorpkg.ReorgDetected)
+			return
+		}
+	})
+}
+
+func TestParserWorker_failBlockProcessing(t *testing.T) {
+
+	t.Run("release block of cancelled run", func(tt *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		now := time.Now()
+		block := entity.Block{
+			Number:    1,
+			Status:    constant.BlockStatusProcessing,
+			UpdatedAt: now.Add(-time.Second),
+		}
+		failedBlock := entity.Block{
+			Number:    1,
+			Status:    constant.BlockStatusFailed,
+			UpdatedAt: now,
+		}
+
+		ctrl := gomock.NewController(tt)
+		blockRepoMock := mocks.NewMockBlockRepository(ctrl)
+		blockRepoMock.EXPECT().Upsert(gomock.Any(), failedBlock).DoAndReturn(func(ctx context.Context, _ entity.Block) error {
+			if ctx.Err() != nil {
+				tt.Errorf("Upsert() ctx error = %v, want nil", ctx.Err())
+			}
+
+			return nil
+		}).Times(1)
+
+		monkey.Patch(time.Now, func() time.Time {
+			return now
+		})
+
+		w := NewParserWorker(
+			nil,
+			nil,
+			blockRepoMock,
+			nil,
+			nil,
+			nil,
+			nil,
+			0,
+			false,
+			logger.Nop(),
+		)
+
+		w.failBlockProcessing(ctx, block)
+	})
+}
+
+func TestParserWorker_waitParentBlock(t *testing.T) {
+	t.Run("parent is parsed by another worker", func(tt *testing.T) {
+		ctx := context.Background()
+
+		ctrl := gomock.NewController(tt)
+		blockRepoMock := mocks.NewMockBlockRepository(ctrl)
+		gomock.InOrder(
+			blockRepoMock.EXPECT().GetParsedBlock(gomock.Any(), entity.BlockNumber(1)).Return(entity.Block{}, errorpkg.BlockNotFound).Times(1),
+			blockRepoMock.EXPECT().GetParsedBlock(gomock.Any(), entity.BlockNumber(1)).Return(entity.Block{
+				Number: 1,
+				Status: constant.BlockStatusParsed,
+			}, nil).Times(1),
+		)
+
+		w := NewParserWorker(nil, nil, blockRepoMock, nil, nil, nil, nil, 0, false, logger.Nop())
+
+		if got := w.waitParentBlock(ctx, 2); !got {
+			tt.Errorf("waitParentBlock() = %v, want %v", got, true)
+		}
+	})
+
+	t.Run("cancelled run stops waiting", func(tt *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		ctrl := gomock.NewController(tt)
+		blockRepoMock := mocks.NewMockBlockRepository(ctrl)
+
+		w := NewParserWorker(nil, nil, blockRepoMock, nil, nil, nil, nil, 0, false, logger.Nop())
+
+		if got := w.waitParentBlock(ctx, 2); got {
+			tt.Errorf("waitParentBlock() = %v, want %v", got, false)
+		}
+	})
+}
+
+func TestParserWorker_trackBalances(t *testing.T) {
+	ctx := context.Background()
+	tracked := entity.Address("0xd7def8de6bff40e7fa3a19b6749aca84bd5ba0ae")
+	trackedLater := entity.Address("0x00000000006c3852cbef3e08e8df289169ede581")
+	untracked := entity.Address("0x069acf904f610cbf8ef1540349092852e46b4e95")
+
+	sentTxn := entity.Transaction{
+		Hash:  "0x5a",
+		From:  tracked,
+		To:    untracked,
+		Value: entity.NewWeiFromInt64(0x64),
+	}
+	failedTxn := entity.Transaction{
+		Hash:  "0x5b",
+		From:  untracked,
+		To:    tracked,
+		Value: entity.NewWeiFromInt64(0x32),
+	}
+	laterTxn := entity.Transaction{
+		Hash:  "0x5c",
+		From:  trackedLater,
+		To:    untracked,
+		Value: entity.NewWeiFromInt64(0x10),
+	}
+	untrackedTxn := entity.Transaction{
+		Hash:  "0x5d",
+		From:  untracked,
+		To:    "",
+		Value: entity.NewWeiFromInt64(0x0),
+	}
+	chainBlock := entity.ChainBlock{
+		Number:       101,
+		Transactions: []entity.Transaction{sentTxn, failedTxn, laterTxn, untrackedTxn},
+		Withdrawals: []entity.Withdrawal{
+			{Index: 7, ValidatorIndex: 16, Address: tracked, Amount: entity.NewWeiFromInt64(30)},
+			{Index: 8, ValidatorIndex: 17, Address: untracked, Amount: entity.NewWeiFromInt64(40)},
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	balanceRepoMock := mocks.NewMockBalanceRepository(ctrl)
+	balanceRepoMock.EXPECT().GetSnapshot(ctx, tracked).Return(entity.BalanceSnapshot{Address: tracked, BlockNumber: 100}, nil).AnyTimes()
+	balanceRepoMock.EXPECT().GetSnapshot(ctx, trackedLater).Return(entity.BalanceSnapshot{Address: trackedLater, BlockNumber: 101}, nil).AnyTimes()
+	balanceRepoMock.EXPECT().GetSnapshot(ctx, untracked).Return(entity.BalanceSnapshot{}, errorpkg.BalanceNotTracked).AnyTimes()
+
+	balanceRepoMock.EXPECT().SaveChange(ctx, entity.BalanceChange{
+		ID: "transaction_out_0x5a", Address: tracked, BlockNumber: 101, Reason: constant.BalanceChangeReasonTransaction, Hash: "0x5a", Delta: entity.NewWeiFromInt64(-100),
+	}).Return(nil).Times(1)
+	balanceRepoMock.EXPECT().SaveChange(ctx, entity.BalanceChange{
+		ID: "fee_out_0x5a", Address: tracked, BlockNumber: 101, Reason: constant.BalanceChangeReasonFee, Hash: "0x5a", Delta: entity.NewWeiFromInt64(-21),
+	}).Return(nil).Times(1)
+	balanceRepoMock.EXPECT().SaveChange(ctx, entity.BalanceChange{
+		ID: "withdrawal_in_7", Address: tracked, BlockNumber: 101, Reason: constant.BalanceChangeReasonWithdrawal, Delta: entity.NewWeiFromInt64(30),
+	}).Return(nil).Times(1)
+
+	blockChainClientMock := mocks.NewMockBlockChainClient(ctrl)
+	blockChainClientMock.EXPECT().GetTransactionReceipt(ctx, sentTxn.Hash).Return(entity.TransactionReceipt{Hash: sentTxn.Hash, Success: true, Fee: entity.NewWeiFromInt64(21)}, nil).Times(1)
+	blockChainClientMock.EXPECT().GetTransactionReceipt(ctx, failedTxn.Hash).Return(entity.TransactionReceipt{Hash: failedTxn.Hash, Success: false, Fee: entity.NewWeiFromInt64(21)}, nil).Times(1)
+
+	w := NewParserWorker(
+		nil,
+		nil,
+		nil,
+		nil,
+		balanceRepoMock,
+		blockChainClientMock,
+		nil,
+		0,
+		false,
+		logger.Nop(),
+	)
+
+	if err := w.trackBalances(ctx, chainBlock); err != nil {
+		t.Errorf("trackBalances() error = %v, wantErr %v", err, nil)
+	}
 }
@@ -0,0 +1,362 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"blockchain-parser/internal/constant"
+	"blockchain-parser/internal/entity"
+	errorpkg "blockchain-parser/internal/error"
+	"blockchain-parser/internal/metrics"
+	"blockchain-parser/tools/logger"
+	"blockchain-parser/tools/tracing"
+)
+
+const (
+	reparseTaskIDLength = 8
+
+	// a block is tried this many times before the task fails, failures are usually node timeouts
+	reparseBlockAttempts = 3
+	reparseRetryDelay    = time.Second
+)
+
+// Reparse parses ranges of blocks again, e.g. after a late subscription or a fix of matching. Tasks are run
+// one by one by the reparse job alongside parser workers, blocks are fetched at most at the configured rate.
+// Transactions are stored by block number and index, so parsing a block again doesn't duplicate them.
+// Reorgs, block statuses and stream events are left to parser workers.
+type Reparse struct {
+	taskRepo         ReparseTaskRepository
+	txnRepo          TransactionRepository
+	subscriberRepo   SubscriberRepository
+	blockRepo        BlockRepository
+	blockChainClient BlockChainClient
+
+	blockInterval time.Duration
+	maxBlocks     uint64
+
+	log logger.Logger
+
+	// mu serializes updates of tasks by the job and cancellation by admin
+	mu sync.Mutex
+}
+
+func NewReparse(
+	taskRepo ReparseTaskRepository,
+	txnRepo TransactionRepository,
+	subscriberRepo SubscriberRepository,
+	blockRepo BlockRepository,
+	blockChainClient BlockChainClient,
+	blocksPerSecond int,
+	maxBlocks uint64,
+	log logger.Logger,
+) *Reparse {
+	return &Reparse{
+		taskRepo:         taskRepo,
+		txnRepo:          txnRepo,
+		subscriberRepo:   subscriberRepo,
+		blockRepo:        blockRepo,
+		blockChainClient: blockChainClient,
+		blockInterval:    time.Second / time.Duration(blocksPerSecond),
+		maxBlocks:        maxBlocks,
+		log:              log,
+	}
+}
+
+// CreateTask queues reparse of blocks from..to. The range must be parsed already, blocks above the last parsed
+// block are parsed by workers. Addresses must be subscribed.
+func (s *Reparse) CreateTask(
+	ctx context.Context,
+	from, to entity.BlockNumber,
+	addresses []entity.Address,
+) (entity.ReparseTask, error) {
+	if from > to {
+		return entity.ReparseTask{}, errorpkg.NewInvalidArgument("to", "to must not be less than from")
+	}
+	if uint64(to-from) >= s.maxBlocks {
+		return entity.ReparseTask{}, errorpkg.NewInvalidArgument("to", fmt.Sprintf("range must not exceed %d blocks", s.maxBlocks))
+	}
+
+	lastBlock, err := s.blockRepo.GetLastParsedBlock(ctx)
+	if err != nil {
+		return entity.ReparseTask{}, fmt.Errorf("fail get last parsed block in CreateTask: %w", err)
+	}
+	if to > lastBlock.Number {
+		return entity.ReparseTask{}, errorpkg.NewInvalidArgument("to",
+			fmt.Sprintf("to must not exceed the last parsed block %d", lastBlock.Number))
+	}
+
+	addresses = uniqueAddresses(addresses)
+	for _, address := range addresses {
+		ok, err := checkSubscription(ctx, s.subscriberRepo, address)
+		if err != nil {
+			return entity.ReparseTask{}, fmt.Errorf("fail check subscription in CreateTask: %w", err)
+		}
+		if !ok {
+			return entity.ReparseTask{}, fmt.Errorf("address %s in CreateTask: %w", address, errorpkg.SubscriberNotFound)
+		}
+	}
+
+	id, err := generateID(reparseTaskIDLength)
+	if err != nil {
+		return entity.ReparseTask{}, fmt.Errorf("fail generate task id in CreateTask: %w", err)
+	}
+
+	task := entity.ReparseTask{
+		ID:        id,
+		From:      from,
+		To:        to,
+		Addresses: addresses,
+		Status:    constant.ReparseStatusQueued,
+		NextBlock: from,
+		CreatedAt: time.Now(),
+	}
+
+	if err := s.taskRepo.Save(ctx, task); err != nil {
+		return entity.ReparseTask{}, fmt.Errorf("fail save task in CreateTask: %w", err)
+	}
+
+	return task, nil
+}
+
+func (s *Reparse) GetTask(ctx context.Context, id string) (entity.ReparseTask, error) {
+	task, err := s.taskRepo.Get(ctx, id)
+	if err != nil {
+		return entity.ReparseTask{}, fmt.Errorf("fail get task in GetTask: %w", err)
+	}
+
+	return task, nil
+}
+
+func (s *Reparse) GetTasks(ctx context.Context) ([]entity.ReparseTask, error) {
+	tasks, err := s.taskRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fail get tasks in GetTasks: %w", err)
+	}
+
+	return tasks, nil
+}
+
+// CancelTask stops the queued or running task, blocks parsed before keep their transactions.
+// Finished tasks are returned as is.
+func (s *Reparse) CancelTask(ctx context.Context, id string) (entity.ReparseTask, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	task, err := s.taskRepo.Get(ctx, id)
+	if err != nil {
+		return entity.ReparseTask{}, fmt.Errorf("fail get task in CancelTask: %w", err)
+	}
+
+	if task.Status != constant.ReparseStatusQueued && task.Status != constant.ReparseStatusRunning {
+		return task, nil
+	}
+
+	task.Status = constant.ReparseStatusCanceled
+	task.FinishedAt = time.Now()
+
+	if err := s.taskRepo.Save(ctx, task); err != nil {
+		return entity.ReparseTask{}, fmt.Errorf("fail save task in CancelTask: %w", err)
+	}
+
+	return task, nil
+}
+
+// Run is the reparse job, it runs queued tasks in order of creation until none is left
+func (s *Reparse) Run(ctx context.Context) (err error) {
+	ctx, span := tracing.Start(ctx, "Reparse.Run")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		task, ok, err := s.startNextTask(ctx)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+
+		if err := s.runTask(ctx, task); err != nil {
+			return err
+		}
+	}
+}
+
+// startNextTask marks the oldest queued task as running
+func (s *Reparse) startNextTask(ctx context.Context) (entity.ReparseTask, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tasks, err := s.taskRepo.GetAll(ctx)
+	if err != nil {
+		return entity.ReparseTask{}, false, fmt.Errorf("fail get tasks in startNextTask: %w", err)
+	}
+
+	for _, task := range tasks {
+		if task.Status != constant.ReparseStatusQueued {
+			continue
+		}
+
+		task.Status = constant.ReparseStatusRunning
+		if task.StartedAt.IsZero() {
+			task.StartedAt = time.Now()
+		}
+
+		if err := s.taskRepo.Save(ctx, task); err != nil {
+			return entity.ReparseTask{}, false, fmt.Errorf("fail save task in startNextTask: %w", err)
+		}
+
+		return task, true, nil
+	}
+
+	return entity.ReparseTask{}, false, nil
+}
+
+// runTask parses blocks of the task and saves the progress after every block. The task interrupted by the run
+// cancellation is queued again and continues from the next block, a block which fails all attempts fails the task.
+func (s *Reparse) runTask(ctx context.Context, task entity.ReparseTask) error {
+	log := logger.FromContext(ctx, s.log).With(logger.String("task_id", task.ID))
+	log.Info("reparse started",
+		logger.Uint64("from", uint64(task.From)), logger.Uint64("to", uint64(task.To)),
+		logger.Uint64("next_block", uint64(task.NextBlock)))
+
+	ticker := time.NewTicker(s.blockInterval)
+	defer ticker.Stop()
+
+	for task.Status == constant.ReparseStatusRunning {
+		select {
+		case <-ctx.Done():
+			s.requeueTask(ctx, task.ID)
+
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		matched, parseErr := s.parseBlock(ctx, task.NextBlock, task.Addresses)
+		if parseErr != nil && ctx.Err() != nil {
+			s.requeueTask(ctx, task.ID)
+
+			return ctx.Err()
+		}
+		if parseErr != nil {
+			log.Warn("reparse failed", logger.Uint64("block_number", uint64(task.NextBlock)), logger.Err(parseErr))
+
+			_, err := s.updateTask(ctx, task.ID, func(task *entity.ReparseTask) {
+				task.Status = constant.ReparseStatusFailed
+				task.Error = parseErr.Error()
+				task.FinishedAt = time.Now()
+			})
+
+			return err
+		}
+		metrics.BlocksReparsed.Inc()
+
+		var err error
+		task, err = s.updateTask(ctx, task.ID, func(task *entity.ReparseTask) {
+			task.NextBlock++
+			task.MatchedTransactions += matched
+
+			if task.NextBlock > task.To {
+				task.Status = constant.ReparseStatusSucceeded
+				task.FinishedAt = time.Now()
+			}
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Info("reparse finished", logger.String("status", task.Status),
+		logger.Uint64("parsed_blocks", task.ParsedBlocks()), logger.Int("matched_transactions", task.MatchedTransactions))
+
+	return nil
+}
+
+// parseBlock saves matching transactions of the block and returns their count
+func (s *Reparse) parseBlock(ctx context.Context, blockNumber entity.BlockNumber, addresses []entity.Address) (int, error) {
+	var err error
+	for attempt := 1; ; attempt++ {
+		var matched int
+		matched, err = s.parseBlockOnce(ctx, blockNumber, addresses)
+		if err == nil {
+			return matched, nil
+		}
+		if attempt == reparseBlockAttempts {
+			return 0, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return 0, err
+		case <-time.After(reparseRetryDelay):
+		}
+	}
+}
+
+func (s *Reparse) parseBlockOnce(ctx context.Context, blockNumber entity.BlockNumber, addresses []entity.Address) (_ int, err error) {
+	ctx, span := tracing.Start(ctx, "Reparse.parseBlock", tracing.Uint64("block_number", uint64(blockNumber)))
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	chainBlock, err := s.blockChainClient.GetBlockByNumber(ctx, blockNumber)
+	if err != nil {
+		return 0, fmt.Errorf("fail get block (%d) in Reparse: %w", blockNumber, err)
+	}
+
+	txns, err := matchTransactions(ctx, s.subscriberRepo, chainBlock, addresses)
+	if err != nil {
+		return 0, fmt.Errorf("fail match transactions of block (%d) in Reparse: %w", blockNumber, err)
+	}
+
+	for _, txn := range txns {
+		if err := s.txnRepo.Save(ctx, txn); err != nil {
+			return 0, fmt.Errorf("fail save transaction of block (%d) in Reparse: %w", blockNumber, err)
+		}
+	}
+
+	return len(txns), nil
+}
+
+// updateTask applies the change to the running task and returns the saved task. The task canceled meanwhile
+// is returned as is.
+func (s *Reparse) updateTask(ctx context.Context, id string, change func(task *entity.ReparseTask)) (entity.ReparseTask, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	task, err := s.taskRepo.Get(ctx, id)
+	if err != nil {
+		return entity.ReparseTask{}, fmt.Errorf("fail get task in updateTask: %w", err)
+	}
+
+	if task.Status != constant.ReparseStatusRunning {
+		return task, nil
+	}
+
+	change(&task)
+
+	if err := s.taskRepo.Save(ctx, task); err != nil {
+		return entity.ReparseTask{}, fmt.Errorf("fail save task in updateTask: %w", err)
+	}
+
+	return task, nil
+}
+
+// requeueTask returns the task interrupted by shutdown to the queue, it's done even when the run is cancelled
+func (s *Reparse) requeueTask(ctx context.Context, id string) {
+	_, err := s.updateTask(withoutCancel(ctx), id, func(task *entity.ReparseTask) {
+		task.Status = constant.ReparseStatusQueued
+	})
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("fail requeue task in requeueTask", logger.String("task_id", id), logger.Err(err))
+	}
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"blockchain-parser/internal/constant"
+	"blockchain-parser/internal/entity"
+	errorpkg "blockchain-parser/internal/error"
+	"blockchain-parser/internal/service/mocks"
+	"blockchain-parser/tools/logger"
+)
+
+func TestReparse_CreateTask(t *testing.T) {
+	subscribed := entity.Address("0xd7def8de6bff40e7fa3a19b6749aca84bd5ba0ae")
+	unknown := entity.Address("0x00000000006c3852cbef3e08e8df289169ede581")
+
+	tests := []struct {
+		name      string
+		from      entity.BlockNumber
+		to        entity.BlockNumber
+		addresses []entity.Address
+		want      entity.ReparseTask
+		wantErr   error
+	}{
+		{
+			name:      "create task",
+			from:      100,
+			to:        150,
+			addresses: []entity.Address{subscribed, subscribed},
+			want: entity.ReparseTask{
+				From:      100,
+				To:        150,
+				Addresses: []entity.Address{subscribed},
+				Status:    constant.ReparseStatusQueued,
+				NextBlock: 100,
+			},
+		},
+		{
+			name:    "from above to",
+			from:    150,
+			to:      100,
+			wantErr: errorpkg.InvalidArgument,
+		},
+		{
+			name:    "range above max blocks",
+			from:    100,
+			to:      200,
+			wantErr: errorpkg.InvalidArgument,
+		},
+		{
+			name:    "to above last parsed block",
+			from:    150,
+			to:      201,
+			wantErr: errorpkg.InvalidArgument,
+		},
+		{
+			name:      "address isn't subscribed",
+			from:      100,
+			to:        150,
+			addresses: []entity.Address{subscribed, unknown},
+			wantErr:   errorpkg.SubscriberNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			blockRepoMock := mocks.NewMockBlockRepository(ctrl)
+			blockRepoMock.EXPECT().GetLastParsedBlock(gomock.Any()).Return(entity.Block{Number: 200}, nil).AnyTimes()
+
+			subscriberRepoMock := mocks.NewMockSubscriberRepository(ctrl)
+			subscriberRepoMock.EXPECT().Get(gomock.Any(), subscribed).Return(entity.Subscriber{Address: subscribed}, nil).AnyTimes()
+			subscriberRepoMock.EXPECT().Get(gomock.Any(), unknown).Return(entity.Subscriber{}, errorpkg.SubscriberNotFound).AnyTimes()
+
+			taskRepoMock := mocks.NewMockReparseTaskRepository(ctrl)
+			if tt.wantErr == nil {
+				taskRepoMock.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			}
+
+			s := NewReparse(taskRepoMock, nil, subscriberRepoMock, blockRepoMock, nil, 10, 100, logger.Nop())
+
+			got, err := s.CreateTask(context.Background(), tt.from, tt.to, tt.addresses)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreateTask() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if got.ID == "" || got.CreatedAt.IsZero() {
+				t.Errorf("CreateTask() ID and creation time must be set, got = %v", got)
+			}
+			got.ID = ""
+			got.CreatedAt = tt.want.CreatedAt
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CreateTask() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReparse_Run(t *testing.T) {
+	filtered := entity.Address("0xd7def8de6bff40e7fa3a19b6749aca84bd5ba0ae")
+	other := entity.Address("0x00000000006c3852cbef3e08e8df289169ede581")
+	unknown := entity.Address("0x069acf904f610cbf8ef1540349092852e46b4e95")
+
+	txn1 := entity.Transaction{From: unknown, To: filtered, BlockNumber: 10, TransactionIndex: 0}
+	txn2 := entity.Transaction{From: other, To: unknown, BlockNumber: 10, TransactionIndex: 1}
+	blocks := map[entity.BlockNumber]entity.ChainBlock{
+		10: {Number: 10, Transactions: []entity.Transaction{txn1, txn2}},
+		11: {Number: 11},
+		12: {
+			Number:      12,
+			Withdrawals: []entity.Withdrawal{{Address: filtered, Amount: entity.NewWeiFromInt64(1)}},
+		},
+	}
+
+	tasks := map[string]entity.ReparseTask{
+		"t1": {
+			ID:        "t1",
+			From:      10,
+			To:        12,
+			Addresses: []entity.Address{filtered},
+			Status:    constant.ReparseStatusQueued,
+			NextBlock: 10,
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	taskRepoMock := mocks.NewMockReparseTaskRepository(ctrl)
+	taskRepoMock.EXPECT().GetAll(gomock.Any()).DoAndReturn(func(_ context.Context) ([]entity.ReparseTask, error) {
+		all := make([]entity.ReparseTask, 0, len(tasks))
+		for _, task := range tasks {
+			all = append(all, task)
+		}
+
+		return all, nil
+	}).AnyTimes()
+	taskRepoMock.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, id string) (entity.ReparseTask, error) {
+		return tasks[id], nil
+	}).AnyTimes()
+	taskRepoMock.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, task entity.ReparseTask) error {
+		tasks[task.ID] = task
+
+		return nil
+	}).AnyTimes()
+
+	blockChainClientMock := mocks.NewMockBlockChainClient(ctrl)
+	blockChainClientMock.EXPECT().GetBlockByNumber(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, number entity.BlockNumber) (entity.ChainBlock, error) {
+		return blocks[number], nil
+	}).Times(3)
+
+	// other address is subscribed but isn't in the task addresses, so it's not checked
+	subscriberRepoMock := mocks.NewMockSubscriberRepository(ctrl)
+	subscriberRepoMock.EXPECT().Get(gomock.Any(), filtered).Return(entity.Subscriber{Address: filtered}, nil).Times(2)
+
+	txnRepoMock := mocks.NewMockTransactionRepository(ctrl)
+	txnRepoMock.EXPECT().Save(gomock.Any(), txn1).Return(nil).Times(1)
+	txnRepoMock.EXPECT().Save(gomock.Any(), mapWithdrawalToTransaction(blocks[12], 0)).Return(nil).Times(1)
+
+	s := NewReparse(taskRepoMock, txnRepoMock, subscriberRepoMock, nil, blockChainClientMock, 1000, 100, logger.Nop())
+
+	if err := s.Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v, wantErr %v", err, nil)
+	}
+
+	got := tasks["t1"]
+	if got.Status != constant.ReparseStatusSucceeded || got.StartedAt.IsZero() || got.FinishedAt.IsZero() {
+		t.Errorf("Run() task must succeed, got = %v", got)
+	}
+	if got.NextBlock != 13 || got.ParsedBlocks() != got.TotalBlocks() {
+		t.Errorf("Run() parsed blocks got = %d, want %d", got.ParsedBlocks(), got.TotalBlocks())
+	}
+	if got.MatchedTransactions != 2 {
+		t.Errorf("Run() matched transactions got = %d, want %d", got.MatchedTransactions, 2)
+	}
+}
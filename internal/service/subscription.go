@@ -0,0 +1,222 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"blockchain-parser/internal/entity"
+	errorpkg "blockchain-parser/internal/error"
+)
+
+// subscribe subscribes the tenant to the addresses, none of them is subscribed if the cap of the tenant is exceeded.
+// The addresses are saved as subscribers, so the parser tracks them once for all tenants, and the balance snapshot
+// is taken when an address is subscribed for the first time.
+func subscribe(
+	ctx context.Context,
+	subscriberRepo SubscriberRepository,
+	subscriptionRepo SubscriptionRepository,
+	tenantRepo TenantRepository,
+	blockRepo BlockRepository,
+	balanceRepo BalanceRepository,
+	blockChainClient BlockChainClient,
+	defaultMaxSubscriptions int,
+	tenantID string,
+	addresses []entity.Address,
+) error {
+	maxSubscriptions, err := getMaxSubscriptions(ctx, tenantRepo, defaultMaxSubscriptions, tenantID)
+	if err != nil {
+		return fmt.Errorf("fail get subscription cap: %w", err)
+	}
+
+	nextBlock, err := getNextBlockForParsing(ctx, blockRepo)
+	if err != nil {
+		return fmt.Errorf("fail get next block for parsing: %w", err)
+	}
+
+	now := time.Now()
+	subscriptions := make([]entity.Subscription, 0, len(addresses))
+	for _, address := range addresses {
+		subscription := entity.Subscription{
+			TenantID:  tenantID,
+			Address:   address,
+			CreatedAt: now,
+		}
+
+		// transactions of a tracked address parsed so far are indexed for other tenants, they aren't shown.
+		// Nothing is indexed for a new address, so blocks reparsed later are shown from the beginning.
+		_, err := subscriberRepo.Get(ctx, address)
+		switch {
+		case err == nil:
+			subscription.FromBlock = nextBlock
+		case !errors.Is(err, errorpkg.SubscriberNotFound):
+			return fmt.Errorf("fail get subscriber (%s): %w", address, err)
+		}
+
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	// the cap is checked by the repository while saving, so concurrent requests of the tenant don't exceed it
+	saved, err := subscriptionRepo.SaveWithinLimit(ctx, subscriptions, maxSubscriptions)
+	if err != nil {
+		return fmt.Errorf("fail save subscriptions: %w", err)
+	}
+
+	if err := trackAddresses(ctx, subscriberRepo, balanceRepo, blockChainClient, addresses); err != nil {
+		// subscriptions saved by the request are removed, so the tenant isn't subscribed to addresses without snapshots
+		for _, subscription := range saved {
+			if deleteErr := subscriptionRepo.Delete(withoutCancel(ctx), subscription.TenantID, subscription.Address); deleteErr != nil {
+				return fmt.Errorf("%w, fail delete subscription to address (%s): %v", err, subscription.Address, deleteErr)
+			}
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// trackAddresses saves the addresses as subscribers after their balance snapshots are taken,
+// so the parser doesn't track an address without the snapshot.
+func trackAddresses(
+	ctx context.Context,
+	subscriberRepo SubscriberRepository,
+	balanceRepo BalanceRepository,
+	blockChainClient BlockChainClient,
+	addresses []entity.Address,
+) error {
+	for _, address := range addresses {
+		if err := takeBalanceSnapshot(ctx, balanceRepo, blockChainClient, address); err != nil {
+			return fmt.Errorf("fail take balance snapshot of address (%s): %w", address, err)
+		}
+
+		subscriber := entity.Subscriber{
+			Address: address,
+		}
+		if err := subscriberRepo.Save(ctx, subscriber); err != nil {
+			return fmt.Errorf("fail save subscriber (%s): %w", address, err)
+		}
+	}
+
+	return nil
+}
+
+// getNextBlockForParsing returns the block after the last parsed block, blocks from it are parsed after now
+func getNextBlockForParsing(ctx context.Context, blockRepo BlockRepository) (entity.BlockNumber, error) {
+	block, err := blockRepo.GetLastParsedBlock(ctx)
+	if errors.Is(err, errorpkg.BlockNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return block.Number + 1, nil
+}
+
+// getMaxSubscriptions returns the cap of subscriptions of the tenant. Cap of the tenant overrides defaultMaxSubscriptions,
+// zero cap means no cap. Tenant which isn't stored (the default tenant) has the default cap.
+func getMaxSubscriptions(ctx context.Context, tenantRepo TenantRepository, defaultMaxSubscriptions int, tenantID string) (int, error) {
+	tenant, err := tenantRepo.Get(ctx, tenantID)
+	if err != nil && !errors.Is(err, errorpkg.TenantNotFound) {
+		return 0, fmt.Errorf("fail get tenant (%s): %w", tenantID, err)
+	}
+	if tenant.MaxSubscriptions > 0 {
+		return tenant.MaxSubscriptions, nil
+	}
+
+	return defaultMaxSubscriptions, nil
+}
+
+// takeBalanceSnapshot takes the balance snapshot of the address at the current node block, the parser
+// tracks balance changes of the next blocks. Existing snapshot is kept.
+func takeBalanceSnapshot(
+	ctx context.Context,
+	balanceRepo BalanceRepository,
+	blockChainClient BlockChainClient,
+	address entity.Address,
+) error {
+	_, err := balanceRepo.GetSnapshot(ctx, address)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, errorpkg.BalanceNotTracked) {
+		return fmt.Errorf("fail get balance snapshot: %w", err)
+	}
+
+	blockNumber, err := blockChainClient.GetBlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("fail get block number: %w", err)
+	}
+
+	value, err := blockChainClient.GetBalance(ctx, address, blockNumber)
+	if err != nil {
+		return fmt.Errorf("fail get balance at block (%d): %w", blockNumber, err)
+	}
+
+	snapshot := entity.BalanceSnapshot{
+		Address:               address,
+		BlockNumber:           blockNumber,
+		Value:                 value,
+		ReconciledBlockNumber: blockNumber,
+		CreatedAt:             time.Now(),
+	}
+	if err := balanceRepo.SaveSnapshot(ctx, snapshot); err != nil {
+		return fmt.Errorf("fail save balance snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// checkTenantSubscriptions returns SubscriberNotFound if the tenant isn't subscribed to one of the addresses.
+// Tenants see only transactions, balances and events of their own subscriptions.
+func checkTenantSubscriptions(
+	ctx context.Context,
+	subscriptionRepo SubscriptionRepository,
+	tenantID string,
+	addresses []entity.Address,
+) error {
+	_, err := getTenantSubscriptions(ctx, subscriptionRepo, tenantID, addresses)
+
+	return err
+}
+
+// getTenantSubscriptions returns subscriptions of the tenant to the addresses, SubscriberNotFound is returned
+// if the tenant isn't subscribed to one of them.
+func getTenantSubscriptions(
+	ctx context.Context,
+	subscriptionRepo SubscriptionRepository,
+	tenantID string,
+	addresses []entity.Address,
+) ([]entity.Subscription, error) {
+	subscriptions := make([]entity.Subscription, 0, len(addresses))
+	for _, address := range addresses {
+		subscription, err := subscriptionRepo.Get(ctx, tenantID, address)
+		if err != nil {
+			return nil, fmt.Errorf("fail get subscription to address (%s): %w", address, err)
+		}
+
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	return subscriptions, nil
+}
+
+// getTenantSubscription returns the subscription of the tenant to the address, ok is false if the tenant isn't subscribed
+func getTenantSubscription(
+	ctx context.Context,
+	subscriptionRepo SubscriptionRepository,
+	tenantID string,
+	address entity.Address,
+) (_ entity.Subscription, ok bool, _ error) {
+	subscription, err := subscriptionRepo.Get(ctx, tenantID, address)
+	if errors.Is(err, errorpkg.SubscriberNotFound) {
+		return entity.Subscription{}, false, nil
+	}
+	if err != nil {
+		return entity.Subscription{}, false, err
+	}
+
+	return subscription, true, nil
+}
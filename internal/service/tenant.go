@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"blockchain-parser/internal/entity"
+	errorpkg "blockchain-parser/internal/error"
+)
+
+const (
+	tenantIDLength     = 16
+	apiKeyIDLength     = 8
+	apiKeySecretLength = 32
+	apiKeyPrefix       = "bpk_"
+)
+
+// Tenant manages tenants and their API keys. A key is returned once when it's issued,
+// only its hash is stored, so a lost key can only be revoked and replaced.
+type Tenant struct {
+	tenantRepo TenantRepository
+	apiKeyRepo APIKeyRepository
+}
+
+func NewTenant(tenantRepo TenantRepository, apiKeyRepo APIKeyRepository) *Tenant {
+	return &Tenant{
+		tenantRepo: tenantRepo,
+		apiKeyRepo: apiKeyRepo,
+	}
+}
+
+// CreateTenant creates a tenant, zero maxSubscriptions means the server default cap
+func (s *Tenant) CreateTenant(ctx context.Context, name string, maxSubscriptions int) (entity.Tenant, error) {
+	id, err := generateID(tenantIDLength)
+	if err != nil {
+		return entity.Tenant{}, fmt.Errorf("fail generate tenant id in CreateTenant: %w", err)
+	}
+
+	tenant := entity.Tenant{
+		ID:               id,
+		Name:             name,
+		MaxSubscriptions: maxSubscriptions,
+		CreatedAt:        time.Now(),
+	}
+	if err := s.tenantRepo.Save(ctx, tenant); err != nil {
+		return entity.Tenant{}, fmt.Errorf("fail save tenant in CreateTenant: %w", err)
+	}
+
+	return tenant, nil
+}
+
+func (s *Tenant) GetTenants(ctx context.Context) ([]entity.Tenant, error) {
+	tenants, err := s.tenantRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fail get tenants in GetTenants: %w", err)
+	}
+
+	return tenants, nil
+}
+
+// IssueAPIKey creates a new key of the tenant, the key is returned along with its stored description.
+func (s *Tenant) IssueAPIKey(ctx context.Context, tenantID string) (entity.APIKey, string, error) {
+	if _, err := s.tenantRepo.Get(ctx, tenantID); err != nil {
+		return entity.APIKey{}, "", fmt.Errorf("fail get tenant (%s) in IssueAPIKey: %w", tenantID, err)
+	}
+
+	id, err := generateID(apiKeyIDLength)
+	if err != nil {
+		return entity.APIKey{}, "", fmt.Errorf("fail generate api key id in IssueAPIKey: %w", err)
+	}
+
+	secret, err := generateID(apiKeySecretLength)
+	if err != nil {
+		return entity.APIKey{}, "", fmt.Errorf("fail generate api key in IssueAPIKey: %w", err)
+	}
+	key := apiKeyPrefix + secret
+
+	apiKey := entity.APIKey{
+		ID:        id,
+		TenantID:  tenantID,
+		Hash:      hashAPIKey(key),
+		CreatedAt: time.Now(),
+	}
+	if err := s.apiKeyRepo.Save(ctx, apiKey); err != nil {
+		return entity.APIKey{}, "", fmt.Errorf("fail save api key in IssueAPIKey: %w", err)
+	}
+
+	return apiKey, key, nil
+}
+
+func (s *Tenant) GetAPIKeys(ctx context.Context, tenantID string) ([]entity.APIKey, error) {
+	if _, err := s.tenantRepo.Get(ctx, tenantID); err != nil {
+		return nil, fmt.Errorf("fail get tenant (%s) in GetAPIKeys: %w", tenantID, err)
+	}
+
+	keys, err := s.apiKeyRepo.GetByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("fail get api keys of tenant (%s) in GetAPIKeys: %w", tenantID, err)
+	}
+
+	return keys, nil
+}
+
+// RevokeAPIKey revokes the key of the tenant, revoking a revoked key keeps the first revocation time.
+func (s *Tenant) RevokeAPIKey(ctx context.Context, tenantID, keyID string) error {
+	key, err := s.apiKeyRepo.Get(ctx, keyID)
+	if err != nil {
+		return fmt.Errorf("fail get api key (%s) in RevokeAPIKey: %w", keyID, err)
+	}
+
+	if key.TenantID != tenantID {
+		return fmt.Errorf("api key (%s) of another tenant in RevokeAPIKey: %w", keyID, errorpkg.APIKeyNotFound)
+	}
+
+	if key.Revoked() {
+		return nil
+	}
+
+	now := time.Now()
+	key.RevokedAt = &now
+	if err := s.apiKeyRepo.Save(ctx, key); err != nil {
+		return fmt.Errorf("fail save api key (%s) in RevokeAPIKey: %w", keyID, err)
+	}
+
+	return nil
+}
+
+// Authenticate returns the tenant of the key. Unknown and revoked keys are unauthorized.
+func (s *Tenant) Authenticate(ctx context.Context, key string) (entity.Tenant, error) {
+	apiKey, err := s.apiKeyRepo.GetByHash(ctx, hashAPIKey(key))
+	if errors.Is(err, errorpkg.APIKeyNotFound) {
+		return entity.Tenant{}, fmt.Errorf("unknown api key in Authenticate: %w", errorpkg.Unauthorized)
+	}
+	if err != nil {
+		return entity.Tenant{}, fmt.Errorf("fail get api key in Authenticate: %w", err)
+	}
+
+	if apiKey.Revoked() {
+		return entity.Tenant{}, fmt.Errorf("api key (%s) is revoked in Authenticate: %w", apiKey.ID, errorpkg.Unauthorized)
+	}
+
+	tenant, err := s.tenantRepo.Get(ctx, apiKey.TenantID)
+	if err != nil {
+		return entity.Tenant{}, fmt.Errorf("fail get tenant (%s) in Authenticate: %w", apiKey.TenantID, err)
+	}
+
+	return tenant, nil
+}
+
+// hashAPIKey hashes the key with SHA-256. Keys are random, so salting and slow hashing aren't needed.
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+
+	return hex.EncodeToString(sum[:])
+}
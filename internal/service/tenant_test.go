@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"blockchain-parser/internal/entity"
+	errorpkg "blockchain-parser/internal/error"
+	"blockchain-parser/internal/service/mocks"
+)
+
+func TestTenant_IssueAPIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	tenantRepoMock := mocks.NewMockTenantRepository(ctrl)
+	tenantRepoMock.EXPECT().Get(gomock.Any(), "t1").Return(entity.Tenant{ID: "t1"}, nil).Times(1)
+
+	var saved entity.APIKey
+	apiKeyRepoMock := mocks.NewMockAPIKeyRepository(ctrl)
+	apiKeyRepoMock.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, key entity.APIKey) error {
+		saved = key
+
+		return nil
+	}).Times(1)
+
+	s := NewTenant(tenantRepoMock, apiKeyRepoMock)
+
+	apiKey, key, err := s.IssueAPIKey(context.Background(), "t1")
+	if err != nil {
+		t.Errorf("IssueAPIKey() error = %v, wantErr %v", err, nil)
+		return
+	}
+	if !strings.HasPrefix(key, apiKeyPrefix) {
+		t.Errorf("IssueAPIKey() key got = %v, want %s prefix", key, apiKeyPrefix)
+	}
+	if saved.Hash != hashAPIKey(key) || strings.Contains(saved.Hash, key) {
+		t.Errorf("IssueAPIKey() saved hash got = %v, want hash of the key", saved.Hash)
+	}
+	if !reflect.DeepEqual(apiKey, saved) {
+		t.Errorf("IssueAPIKey() got = %v, want %v", apiKey, saved)
+	}
+}
+
+func TestTenant_Authenticate(t *testing.T) {
+	key := "bpk_0123"
+	tenant := entity.Tenant{ID: "t1", Name: "main"}
+	revokedAt := time.Now()
+
+	tests := []struct {
+		name    string
+		apiKey  entity.APIKey
+		keyErr  error
+		want    entity.Tenant
+		wantErr error
+	}{
+		{
+			name:   "valid key",
+			apiKey: entity.APIKey{ID: "k1", TenantID: "t1", Hash: hashAPIKey(key)},
+			want:   tenant,
+		},
+		{
+			name:    "unknown key",
+			keyErr:  errorpkg.APIKeyNotFound,
+			want:    entity.Tenant{},
+			wantErr: errorpkg.Unauthorized,
+		},
+		{
+			name:    "revoked key",
+			apiKey:  entity.APIKey{ID: "k1", TenantID: "t1", Hash: hashAPIKey(key), RevokedAt: &revokedAt},
+			want:    entity.Tenant{},
+			wantErr: errorpkg.Unauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			apiKeyRepoMock := mocks.NewMockAPIKeyRepository(ctrl)
+			apiKeyRepoMock.EXPECT().GetByHash(gomock.Any(), hashAPIKey(key)).Return(tt.apiKey, tt.keyErr).Times(1)
+
+			tenantRepoMock := mocks.NewMockTenantRepository(ctrl)
+			tenantRepoMock.EXPECT().Get(gomock.Any(), "t1").Return(tenant, nil).MaxTimes(1)
+
+			s := NewTenant(tenantRepoMock, apiKeyRepoMock)
+
+			got, err := s.Authenticate(context.Background(), key)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Authenticate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Authenticate() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTenant_RevokeAPIKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		tenantID string
+		wantSave bool
+		wantErr  error
+	}{
+		{
+			name:     "revoke key",
+			tenantID: "t1",
+			wantSave: true,
+		},
+		{
+			name:     "key of another tenant",
+			tenantID: "t2",
+			wantErr:  errorpkg.APIKeyNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			apiKeyRepoMock := mocks.NewMockAPIKeyRepository(ctrl)
+			apiKeyRepoMock.EXPECT().Get(gomock.Any(), "k1").Return(entity.APIKey{ID: "k1", TenantID: "t1"}, nil).Times(1)
+			if tt.wantSave {
+				apiKeyRepoMock.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, key entity.APIKey) error {
+					if !key.Revoked() {
+						t.Errorf("RevokeAPIKey() saved key isn't revoked")
+					}
+
+					return nil
+				}).Times(1)
+			}
+
+			s := NewTenant(nil, apiKeyRepoMock)
+
+			if err := s.RevokeAPIKey(context.Background(), tt.tenantID, "k1"); !errors.Is(err, tt.wantErr) {
+				t.Errorf("RevokeAPIKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"blockchain-parser/internal/constant"
+	"blockchain-parser/internal/entity"
+)
+
+// getTransactionFeed merges ordered transactions of the subscribed addresses into one page, each address
+// from the first block of its subscription. Every address returns at most limit+1 transactions,
+// it's enough to build the page and find out whether the next one exists.
+func getTransactionFeed(
+	ctx context.Context,
+	txnRepo TransactionRepository,
+	subscriptions []entity.Subscription,
+	filter entity.TransactionFilter,
+) (entity.TransactionFeed, error) {
+	limit := filter.Limit
+	if limit > 0 {
+		filter.Limit = limit + 1
+	}
+
+	feedAddresses := make(map[entity.Address]struct{}, len(subscriptions))
+	txns := make([]entity.Transaction, 0)
+
+	for _, subscription := range subscriptions {
+		address := subscription.Address
+		if _, ok := feedAddresses[address]; ok {
+			continue
+		}
+		feedAddresses[address] = struct{}{}
+
+		addressTxns, err := txnRepo.GetTxnsByAddress(ctx, address, subscriptionFilter(subscription, filter))
+		if err != nil {
+			return entity.TransactionFeed{}, fmt.Errorf("fail get transactions for address (%s): %w", address, err)
+		}
+
+		txns = append(txns, addressTxns...)
+	}
+
+	sort.SliceStable(txns, func(i, j int) bool {
+		if filter.Order == constant.OrderDesc {
+			return txns[i].Cursor().Compare(txns[j].Cursor()) > 0
+		}
+
+		return txns[i].Cursor().Compare(txns[j].Cursor()) < 0
+	})
+
+	feed := entity.TransactionFeed{
+		Transactions: make([]entity.FeedTransaction, 0, len(txns)),
+	}
+
+	for i, txn := range txns {
+		if i > 0 && txns[i-1].Cursor().Compare(txn.Cursor()) == 0 {
+			continue
+		}
+
+		if limit > 0 && len(feed.Transactions) == limit {
+			next := feed.Transactions[limit-1].Cursor()
+			feed.Next = &next
+
+			break
+		}
+
+		_, fromOk := feedAddresses[txn.From]
+		_, toOk := feedAddresses[txn.To]
+
+		feed.Transactions = append(feed.Transactions, entity.FeedTransaction{
+			Transaction: txn,
+			Internal:    fromOk && toOk,
+		})
+	}
+
+	return feed, nil
+}
+
+// subscriptionFilter restricts the filter to blocks shown to the tenant of the subscription
+func subscriptionFilter(subscription entity.Subscription, filter entity.TransactionFilter) entity.TransactionFilter {
+	if filter.FromBlock < subscription.FromBlock {
+		filter.FromBlock = subscription.FromBlock
+	}
+
+	return filter
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"blockchain-parser/internal/constant"
+	"blockchain-parser/internal/entity"
+	"blockchain-parser/internal/service/mocks"
+)
+
+func TestGetTransactionFeed(t *testing.T) {
+	address1 := entity.Address("0xd7def8de6bff40e7fa3a19b6749aca84bd5ba0ae")
+	address2 := entity.Address("0x00000000006c3852cbef3e08e8df289169ede581")
+
+	internalTxn := entity.Transaction{
+		From:             address1,
+		To:               address2,
+		Value:            entity.NewWeiFromInt64(0xb1a2bc2ec50000),
+		BlockNumber:      34534,
+		TransactionIndex: 3,
+	}
+	txn1 := entity.Transaction{
+		From:             "0x069acf904f610cbf8ef1540349092852e46b4e95",
+		To:               address1,
+		Value:            entity.NewWeiFromInt64(0x5f3bcfe512dcc00),
+		BlockNumber:      34533,
+		TransactionIndex: 1,
+	}
+	txn2 := entity.Transaction{
+		From:             address2,
+		To:               "0x4d7f1790644af787933c9ff0e2cff9a9b4299abb",
+		Value:            entity.NewWeiFromInt64(0x2386f26fc100000),
+		BlockNumber:      34535,
+		TransactionIndex: 0,
+	}
+
+	t.Run("merge, deduplicate and mark internal transactions", func(tt *testing.T) {
+		ctx := context.Background()
+
+		ctrl := gomock.NewController(t)
+		txnRepoMock := mocks.NewMockTransactionRepository(ctrl)
+		txnRepoMock.EXPECT().GetTxnsByAddress(ctx, address1, entity.TransactionFilter{Limit: 4}).Return([]entity.Transaction{txn1, internalTxn}, nil).Times(1)
+		txnRepoMock.EXPECT().GetTxnsByAddress(ctx, address2, entity.TransactionFilter{Limit: 4}).Return([]entity.Transaction{internalTxn, txn2}, nil).Times(1)
+
+		got, err := getTransactionFeed(ctx, txnRepoMock, []entity.Subscription{{Address: address1}, {Address: address2}, {Address: address1}}, entity.TransactionFilter{Limit: 3})
+		if err != nil {
+			t.Errorf("getTransactionFeed() error = %v, wantErr %v", err, nil)
+			return
+		}
+
+		want := entity.TransactionFeed{
+			Transactions: []entity.FeedTransaction{
+				{Transaction: txn1},
+				{Transaction: internalTxn, Internal: true},
+				{Transaction: txn2},
+			},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("getTransactionFeed() got = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("blocks before the subscription are left out", func(tt *testing.T) {
+		ctx := context.Background()
+
+		ctrl := gomock.NewController(tt)
+		txnRepoMock := mocks.NewMockTransactionRepository(ctrl)
+		txnRepoMock.EXPECT().GetTxnsByAddress(ctx, address1, entity.TransactionFilter{FromBlock: 34534}).Return([]entity.Transaction{internalTxn}, nil).Times(1)
+		txnRepoMock.EXPECT().GetTxnsByAddress(ctx, address2, entity.TransactionFilter{FromBlock: 34534}).Return([]entity.Transaction{internalTxn, txn2}, nil).Times(1)
+
+		subscriptions := []entity.Subscription{{Address: address1, FromBlock: 34534}, {Address: address2, FromBlock: 100}}
+		got, err := getTransactionFeed(ctx, txnRepoMock, subscriptions, entity.TransactionFilter{FromBlock: 34534})
+		if err != nil {
+			tt.Errorf("getTransactionFeed() error = %v, wantErr %v", err, nil)
+			return
+		}
+
+		want := entity.TransactionFeed{
+			Transactions: []entity.FeedTransaction{
+				{Transaction: internalTxn, Internal: true},
+				{Transaction: txn2},
+			},
+		}
+		if !reflect.DeepEqual(got, want) {
+			tt.Errorf("getTransactionFeed() got = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("next page in descending order", func(tt *testing.T) {
+		ctx := context.Background()
+		filter := entity.TransactionFilter{Order: constant.OrderDesc, Limit: 1}
+		repoFilter := entity.TransactionFilter{Order: constant.OrderDesc, Limit: 2}
+
+		ctrl := gomock.NewController(t)
+		txnRepoMock := mocks.NewMockTransactionRepository(ctrl)
+		txnRepoMock.EXPECT().GetTxnsByAddress(ctx, address1, repoFilter).Return([]entity.Transaction{internalTxn, txn1}, nil).Times(1)
+		txnRepoMock.EXPECT().GetTxnsByAddress(ctx, address2, repoFilter).Return([]entity.Transaction{txn2, internalTxn}, nil).Times(1)
+
+		got, err := getTransactionFeed(ctx, txnRepoMock, []entity.Subscription{{Address: address1}, {Address: address2}}, filter)
+		if err != nil {
+			t.Errorf("getTransactionFeed() error = %v, wantErr %v", err, nil)
+			return
+		}
+
+		want := entity.TransactionFeed{
+			Transactions: []entity.FeedTransaction{
+				{Transaction: txn2},
+			},
+			Next: &entity.TransactionCursor{BlockNumber: 34535, TransactionIndex: 0},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("getTransactionFeed() got = %v, want %v", got, want)
+		}
+	})
+}
@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"blockchain-parser/internal/entity"
+	errorpkg "blockchain-parser/internal/error"
+)
+
+const (
+	walletIDLength = 16
+)
+
+// Wallet groups addresses of one owner. Addresses of a wallet are subscribed by the wallet tenant
+// because the wallet feed is built from the parsed transactions. Wallets of other tenants are not found.
+type Wallet struct {
+	walletRepo       WalletRepository
+	subscriberRepo   SubscriberRepository
+	subscriptionRepo SubscriptionRepository
+	tenantRepo       TenantRepository
+	txnRepo          TransactionRepository
+	blockRepo        BlockRepository
+	balanceRepo      BalanceRepository
+	blockChainClient BlockChainClient
+
+	maxSubscriptions int
+}
+
+func NewWallet(
+	walletRepo WalletRepository,
+	subscriberRepo SubscriberRepository,
+	subscriptionRepo SubscriptionRepository,
+	tenantRepo TenantRepository,
+	txnRepo TransactionRepository,
+	blockRepo BlockRepository,
+	balanceRepo BalanceRepository,
+	blockChainClient BlockChainClient,
+	maxSubscriptions int,
+) *Wallet {
+	return &Wallet{
+		walletRepo:       walletRepo,
+		subscriberRepo:   subscriberRepo,
+		subscriptionRepo: subscriptionRepo,
+		tenantRepo:       tenantRepo,
+		txnRepo:          txnRepo,
+		blockRepo:        blockRepo,
+		balanceRepo:      balanceRepo,
+		blockChainClient: blockChainClient,
+		maxSubscriptions: maxSubscriptions,
+	}
+}
+
+func (s *Wallet) CreateWallet(ctx context.Context, tenantID, name string, addresses []entity.Address) (entity.Wallet, error) {
+	id, err := generateID(walletIDLength)
+	if err != nil {
+		return entity.Wallet{}, fmt.Errorf("fail generate wallet id in CreateWallet: %w", err)
+	}
+
+	now := time.Now()
+	wallet := entity.Wallet{
+		ID:        id,
+		TenantID:  tenantID,
+		Name:      name,
+		Addresses: uniqueAddresses(addresses),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := s.subscribe(ctx, tenantID, wallet.Addresses); err != nil {
+		return entity.Wallet{}, fmt.Errorf("fail subscribe addresses in CreateWallet: %w", err)
+	}
+
+	if err := s.walletRepo.Save(ctx, wallet); err != nil {
+		return entity.Wallet{}, fmt.Errorf("fail save wallet in CreateWallet: %w", err)
+	}
+
+	return wallet, nil
+}
+
+func (s *Wallet) GetWallet(ctx context.Context, tenantID, id string) (entity.Wallet, error) {
+	wallet, err := s.getWallet(ctx, tenantID, id)
+	if err != nil {
+		return entity.Wallet{}, fmt.Errorf("fail get wallet (%s) in GetWallet: %w", id, err)
+	}
+
+	return wallet, nil
+}
+
+func (s *Wallet) GetWallets(ctx context.Context, tenantID string) ([]entity.Wallet, error) {
+	wallets, err := s.walletRepo.GetByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("fail get wallets in GetWallets: %w", err)
+	}
+
+	return wallets, nil
+}
+
+func (s *Wallet) UpdateWallet(ctx context.Context, tenantID, id, name string, addresses []entity.Address) (entity.Wallet, error) {
+	wallet, err := s.getWallet(ctx, tenantID, id)
+	if err != nil {
+		return entity.Wallet{}, fmt.Errorf("fail get wallet (%s) in UpdateWallet: %w", id, err)
+	}
+
+	wallet.Name = name
+	wallet.Addresses = uniqueAddresses(addresses)
+	wallet.UpdatedAt = time.Now()
+
+	if err := s.subscribe(ctx, tenantID, wallet.Addresses); err != nil {
+		return entity.Wallet{}, fmt.Errorf("fail subscribe addresses in UpdateWallet: %w", err)
+	}
+
+	if err := s.walletRepo.Save(ctx, wallet); err != nil {
+		return entity.Wallet{}, fmt.Errorf("fail save wallet (%s) in UpdateWallet: %w", id, err)
+	}
+
+	return wallet, nil
+}
+
+func (s *Wallet) DeleteWallet(ctx context.Context, tenantID, id string) error {
+	if _, err := s.getWallet(ctx, tenantID, id); err != nil {
+		return fmt.Errorf("fail get wallet (%s) in DeleteWallet: %w", id, err)
+	}
+
+	if err := s.walletRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("fail delete wallet (%s) in DeleteWallet: %w", id, err)
+	}
+
+	return nil
+}
+
+func (s *Wallet) GetWalletTransactions(ctx context.Context, tenantID, id string, filter entity.TransactionFilter) (entity.TransactionFeed, error) {
+	wallet, err := s.getWallet(ctx, tenantID, id)
+	if err != nil {
+		return entity.TransactionFeed{}, fmt.Errorf("fail get wallet (%s) in GetWalletTransactions: %w", id, err)
+	}
+
+	subscriptions, err := s.getSubscriptions(ctx, wallet)
+	if err != nil {
+		return entity.TransactionFeed{}, fmt.Errorf("fail get subscriptions of wallet (%s) in GetWalletTransactions: %w", id, err)
+	}
+
+	feed, err := getTransactionFeed(ctx, s.txnRepo, subscriptions, filter)
+	if err != nil {
+		return entity.TransactionFeed{}, fmt.Errorf("fail get transactions feed of wallet (%s) in GetWalletTransactions: %w", id, err)
+	}
+
+	return feed, nil
+}
+
+// getWallet returns the wallet of the tenant, wallet of another tenant isn't found
+func (s *Wallet) getWallet(ctx context.Context, tenantID, id string) (entity.Wallet, error) {
+	wallet, err := s.walletRepo.Get(ctx, id)
+	if err != nil {
+		return entity.Wallet{}, err
+	}
+
+	if wallet.TenantID != tenantID {
+		return entity.Wallet{}, errorpkg.WalletNotFound
+	}
+
+	return wallet, nil
+}
+
+// getSubscriptions returns subscriptions of the wallet tenant to the wallet addresses,
+// addresses unsubscribed by the tenant are left out of the wallet feed
+func (s *Wallet) getSubscriptions(ctx context.Context, wallet entity.Wallet) ([]entity.Subscription, error) {
+	subscriptions := make([]entity.Subscription, 0, len(wallet.Addresses))
+	for _, address := range wallet.Addresses {
+		subscription, ok, err := getTenantSubscription(ctx, s.subscriptionRepo, wallet.TenantID, address)
+		if err != nil {
+			return nil, fmt.Errorf("fail get subscription to address (%s): %w", address, err)
+		}
+
+		if ok {
+			subscriptions = append(subscriptions, subscription)
+		}
+	}
+
+	return subscriptions, nil
+}
+
+// subscribe subscribes the tenant to the wallet addresses, none of them is subscribed if the cap is exceeded
+func (s *Wallet) subscribe(ctx context.Context, tenantID string, addresses []entity.Address) error {
+	return subscribe(
+		ctx,
+		s.subscriberRepo,
+		s.subscriptionRepo,
+		s.tenantRepo,
+		s.blockRepo,
+		s.balanceRepo,
+		s.blockChainClient,
+		s.maxSubscriptions,
+		tenantID,
+		addresses,
+	)
+}
+
+func uniqueAddresses(addresses []entity.Address) []entity.Address {
+	seen := make(map[entity.Address]struct{}, len(addresses))
+	unique := make([]entity.Address, 0, len(addresses))
+
+	for _, address := range addresses {
+		if _, ok := seen[address]; ok {
+			continue
+		}
+		seen[address] = struct{}{}
+
+		unique = append(unique, address)
+	}
+
+	return unique
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"blockchain-parser/internal/entity"
+	errorpkg "blockchain-parser/internal/error"
+	"blockchain-parser/internal/service/mocks"
+)
+
+func TestWallet_CreateWallet(t *testing.T) {
+	addresses := []entity.Address{
+		"0xd7def8de6bff40e7fa3a19b6749aca84bd5ba0ae",
+		"0x00000000006c3852cbef3e08e8df289169ede581",
+		"0xd7def8de6bff40e7fa3a19b6749aca84bd5ba0ae",
+	}
+
+	ctrl := gomock.NewController(t)
+	subscriberRepoMock := mocks.NewMockSubscriberRepository(ctrl)
+	subscriberRepoMock.EXPECT().Get(gomock.Any(), addresses[0]).Return(entity.Subscriber{}, errorpkg.SubscriberNotFound).Times(1)
+	subscriberRepoMock.EXPECT().Get(gomock.Any(), addresses[1]).Return(entity.Subscriber{Address: addresses[1]}, nil).Times(1)
+	subscriberRepoMock.EXPECT().Save(gomock.Any(), entity.Subscriber{Address: addresses[0]}).Return(nil).Times(1)
+	subscriberRepoMock.EXPECT().Save(gomock.Any(), entity.Subscriber{Address: addresses[1]}).Return(nil).Times(1)
+
+	balanceRepoMock := mocks.NewMockBalanceRepository(ctrl)
+	balanceRepoMock.EXPECT().GetSnapshot(gomock.Any(), addresses[0]).Return(entity.BalanceSnapshot{Address: addresses[0]}, nil).Times(1)
+	balanceRepoMock.EXPECT().GetSnapshot(gomock.Any(), addresses[1]).Return(entity.BalanceSnapshot{Address: addresses[1]}, nil).Times(1)
+
+	subscriptionRepoMock := mocks.NewMockSubscriptionRepository(ctrl)
+	subscriptionRepoMock.EXPECT().SaveWithinLimit(gomock.Any(), gomock.Len(2), 0).
+		DoAndReturn(func(_ context.Context, subscriptions []entity.Subscription, _ int) ([]entity.Subscription, error) {
+			// blocks parsed before are hidden only for the address tracked already
+			if subscriptions[0].FromBlock != 0 || subscriptions[1].FromBlock != 11 {
+				t.Errorf("SaveWithinLimit() first blocks got = %v, %v, want %v, %v", subscriptions[0].FromBlock, subscriptions[1].FromBlock, 0, 11)
+			}
+
+			return subscriptions, nil
+		}).Times(1)
+
+	blockRepoMock := mocks.NewMockBlockRepository(ctrl)
+	blockRepoMock.EXPECT().GetLastParsedBlock(gomock.Any()).Return(entity.Block{Number: 10}, nil).Times(1)
+
+	tenantRepoMock := mocks.NewMockTenantRepository(ctrl)
+	tenantRepoMock.EXPECT().Get(gomock.Any(), "t1").Return(entity.Tenant{}, errorpkg.TenantNotFound).Times(1)
+
+	walletRepoMock := mocks.NewMockWalletRepository(ctrl)
+	walletRepoMock.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+	s := NewWallet(walletRepoMock, subscriberRepoMock, subscriptionRepoMock, tenantRepoMock, nil, blockRepoMock, balanceRepoMock, nil, 0)
+
+	wallet, err := s.CreateWallet(context.Background(), "t1", "main", addresses)
+	if err != nil {
+		t.Errorf("CreateWallet() error = %v, wantErr %v", err, nil)
+		return
+	}
+	if wallet.ID == "" {
+		t.Errorf("CreateWallet() wallet ID is empty")
+	}
+	if wallet.TenantID != "t1" {
+		t.Errorf("CreateWallet() tenant got = %v, want %v", wallet.TenantID, "t1")
+	}
+	if len(wallet.Addresses) != 2 {
+		t.Errorf("CreateWallet() addresses got = %v, want unique addresses", wallet.Addresses)
+	}
+}
+
+func TestWallet_GetWalletTransactions(t *testing.T) {
+	tests := []struct {
+		name    string
+		wallet  entity.Wallet
+		repoErr error
+	}{
+		{
+			name:    "wallet not found",
+			repoErr: errorpkg.WalletNotFound,
+		},
+		{
+			name:   "wallet of another tenant",
+			wallet: entity.Wallet{ID: "a1", TenantID: "t2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			walletRepoMock := mocks.NewMockWalletRepository(ctrl)
+			walletRepoMock.EXPECT().Get(gomock.Any(), "a1").Return(tt.wallet, tt.repoErr).Times(1)
+
+			s := NewWallet(walletRepoMock, nil, nil, nil, nil, nil, nil, nil, 0)
+
+			if _, err := s.GetWalletTransactions(context.Background(), "t1", "a1", entity.TransactionFilter{}); !errors.Is(err, errorpkg.WalletNotFound) {
+				t.Errorf("GetWalletTransactions() error = %v, wantErr %v", err, errorpkg.WalletNotFound)
+			}
+		})
+	}
+}
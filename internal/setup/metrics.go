@@ -0,0 +1,159 @@
+package setup
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"blockchain-parser/internal/infrastructure/repository"
+	"blockchain-parser/internal/metrics"
+	"blockchain-parser/tools/logger"
+	metricspkg "blockchain-parser/tools/metrics"
+	"blockchain-parser/tools/router"
+)
+
+const (
+	unmatchedRoute = "unmatched"
+)
+
+// httpMetricsMiddleware observes duration of requests by route pattern, so path parameters don't blow up label values
+func httpMetricsMiddleware(rt *router.Router, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(recorder, req)
+
+		route, ok := rt.Pattern(req.URL.Path)
+		if !ok {
+			route = unmatchedRoute
+		}
+
+		metrics.HTTPRequestDuration.Observe(time.Since(start).Seconds(), req.Method, route, strconv.Itoa(recorder.status))
+	})
+}
+
+// metricsHandler serves metrics of all registries for scraping, names of their metrics don't overlap
+func metricsHandler(registries ...*metricspkg.Registry) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", metricspkg.ContentType)
+		w.WriteHeader(http.StatusOK)
+
+		for _, registry := range registries {
+			if _, err := registry.WriteTo(w); err != nil {
+				return
+			}
+		}
+	})
+}
+
+// newRepositoryMetrics registers gauges which are read from repositories on every scrape.
+// The registry belongs to the server, so configuring another server doesn't register them twice.
+func newRepositoryMetrics(
+	blockRepo *repository.InMemBlock,
+	subscriberRepo *repository.InMemSubscriber,
+	txnRepo *repository.InMemTransaction,
+	log logger.Logger,
+) *metricspkg.Registry {
+	registry := metricspkg.NewRegistry()
+
+	lastParsedBlock := func() float64 {
+		block, err := blockRepo.GetLastParsedBlock(context.Background())
+		if err != nil {
+			log.Error("fail get last parsed block for metrics", logger.Err(err))
+
+			return 0
+		}
+
+		return float64(block.Number)
+	}
+
+	registry.NewGaugeFunc(
+		"blockchain_parser_last_parsed_block",
+		"Last parsed block number",
+		lastParsedBlock,
+	)
+	registry.NewGaugeFunc(
+		"blockchain_parser_block_lag",
+		"Count of blocks between the head block of the node and the last parsed block",
+		func() float64 {
+			lag := metrics.HeadBlock.Value() - lastParsedBlock()
+			if lag < 0 {
+				return 0
+			}
+
+			return lag
+		},
+	)
+	registry.NewGaugeFunc(
+		"blockchain_parser_subscribers",
+		"Count of subscribed addresses of all tenants",
+		func() float64 {
+			count, err := subscriberRepo.Count(context.Background())
+			if err != nil {
+				log.Error("fail count subscribers for metrics", logger.Err(err))
+			}
+
+			return float64(count)
+		},
+	)
+	registry.NewGaugeFunc(
+		"blockchain_parser_transactions",
+		"Count of stored transactions and withdrawals",
+		func() float64 {
+			count, err := txnRepo.Count(context.Background())
+			if err != nil {
+				log.Error("fail count transactions for metrics", logger.Err(err))
+			}
+
+			return float64(count)
+		},
+	)
+
+	return registry
+}
+
+func observeJobRun(name string, duration time.Duration, err error) {
+	metrics.JobRunDuration.Observe(duration.Seconds(), name)
+	if err != nil {
+		metrics.JobRunFailures.Inc(name)
+	}
+}
+
+// statusRecorder keeps the response status. Streams need Flush and WebSocket upgrade needs Hijack of the wrapped writer.
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (r *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := r.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer doesn't support hijacking")
+	}
+
+	// the connection is switched to WebSocket
+	r.status = http.StatusSwitchingProtocols
+
+	return hijacker.Hijack()
+}
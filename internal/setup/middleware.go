@@ -1,12 +1,27 @@
 package setup
 
 import (
-	"encoding/json"
-	"log"
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
 	"net/http"
 	"runtime/debug"
+	"strings"
 
+	"blockchain-parser/config"
+	"blockchain-parser/internal/constant"
+	errorpkg "blockchain-parser/internal/error"
 	"blockchain-parser/internal/infrastructure/handler"
+	"blockchain-parser/internal/service"
+	"blockchain-parser/tools/logger"
+	"blockchain-parser/tools/router"
+)
+
+const (
+	requestIDLength    = 16
+	maxRequestIDLength = 128
 )
 
 func contentTypeMiddleware(next http.Handler) http.Handler {
@@ -21,14 +36,10 @@ func panicRecoveryMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 		defer func() {
 			if r := recover(); r != nil {
-				log.Println("stacktrace from panic: \n" + string(debug.Stack()))
-
-				resp := handler.ErrorResponse{
-					Message: "internal server error",
-				}
+				logger.FromContext(req.Context(), logger.Nop()).Error("request panicked",
+					logger.Any("panic", r), logger.String("stacktrace", string(debug.Stack())))
 
-				w.WriteHeader(http.StatusInternalServerError)
-				_ = json.NewEncoder(w).Encode(resp)
+				handler.WriteError(w, req, fmt.Errorf("panic: %v", r))
 			}
 		}()
 
@@ -36,16 +47,114 @@ func panicRecoveryMiddleware(next http.Handler) http.Handler {
 	})
 }
 
-func methodCheckMiddleware(next http.Handler) http.Handler {
+// timeoutMiddleware sets the deadline of the request by its route, the context of the request is passed
+// down to repositories and node calls, so they stop when the deadline is exceeded or the client has gone.
+// Streams are long-lived, they get a deadline only when it's set for their route.
+func timeoutMiddleware(cfg config.Server, rt *router.Router, streamRoutes map[string]bool, next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
-		if _, ok := routes[req.URL.Path]; ok {
-			if _, ok := routes[req.URL.Path][req.Method]; !ok {
-				w.WriteHeader(http.StatusMethodNotAllowed)
+		pattern, _ := rt.Pattern(req.URL.Path)
+		route := req.Method + " " + pattern
+
+		timeout, ok := cfg.RouteTimeouts[route]
+		if !ok {
+			timeout = cfg.RequestTimeout
+			if streamRoutes[route] {
+				timeout = 0
+			}
+		}
+		if timeout <= 0 {
+			next.ServeHTTP(w, req)
+
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+
+		next.ServeHTTP(w, req.WithContext(ctx))
+	})
+}
+
+// authMiddleware puts the tenant of the API key to the request context. Admin paths require the admin key.
+// Without admin key authentication is disabled and all requests belong to the default tenant.
+func authMiddleware(tenant *service.Tenant, adminKey string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		key := handler.APIKeyFromRequest(req)
+
+		if strings.HasPrefix(req.URL.Path, v1AdminPathPrefix) {
+			if adminKey == "" || subtle.ConstantTimeCompare([]byte(key), []byte(adminKey)) != 1 {
+				handler.WriteError(w, req, errorpkg.Forbidden)
 
 				return
 			}
+
+			next.ServeHTTP(w, req)
+
+			return
+		}
+
+		if adminKey == "" {
+			next.ServeHTTP(w, req.WithContext(handler.ContextWithTenantID(req.Context(), constant.DefaultTenantID)))
+
+			return
+		}
+
+		if key == "" {
+			handler.WriteError(w, req, errorpkg.Unauthorized)
+
+			return
+		}
+
+		authenticated, err := tenant.Authenticate(req.Context(), key)
+		if err != nil {
+			handler.WriteError(w, req, err)
+
+			return
+		}
+
+		next.ServeHTTP(w, req.WithContext(handler.ContextWithTenantID(req.Context(), authenticated.ID)))
+	})
+}
+
+// serviceEndpointsMiddleware serves GET endpoints of monitoring and orchestration (metrics, probes)
+// before API middlewares, so they don't require API key and aren't rate limited
+func serviceEndpointsMiddleware(endpoints map[string]http.Handler, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if endpoint, ok := endpoints[req.URL.Path]; ok && req.Method == http.MethodGet {
+			endpoint.ServeHTTP(w, req)
+
+			return
 		}
 
 		next.ServeHTTP(w, req)
 	})
 }
+
+// requestIDMiddleware takes request ID from the header or generates a new one. The ID is returned
+// in the response header and in error responses, the request logger carries the ID as a field.
+func requestIDMiddleware(log logger.Logger, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requestID := req.Header.Get(handler.RequestIDHeader)
+		if requestID == "" || len(requestID) > maxRequestIDLength {
+			requestID = generateRequestID(log)
+		}
+
+		w.Header().Set(handler.RequestIDHeader, requestID)
+
+		ctx := handler.ContextWithRequestID(req.Context(), requestID)
+		ctx = logger.NewContext(ctx, log.With(logger.String("request_id", requestID)))
+
+		next.ServeHTTP(w, req.WithContext(ctx))
+	})
+}
+
+func generateRequestID(log logger.Logger) string {
+	buf := make([]byte, requestIDLength)
+	if _, err := rand.Read(buf); err != nil {
+		log.Error("fail generate request id", logger.Err(err))
+
+		return ""
+	}
+
+	return hex.EncodeToString(buf)
+}
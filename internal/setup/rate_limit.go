@@ -0,0 +1,120 @@
+package setup
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"blockchain-parser/config"
+	errorpkg "blockchain-parser/internal/error"
+	"blockchain-parser/internal/infrastructure/handler"
+	"blockchain-parser/tools/ratelimit"
+)
+
+const (
+	rateLimitLimitHeader     = "RateLimit-Limit"
+	rateLimitRemainingHeader = "RateLimit-Remaining"
+	rateLimitResetHeader     = "RateLimit-Reset"
+	retryAfterHeader         = "Retry-After"
+)
+
+// rateLimiter limits requests of every client by route with token buckets. A client is the API key
+// when authentication is enabled, otherwise the client IP. Limits can be changed at runtime, buckets keep
+// their tokens.
+type rateLimiter struct {
+	limiter     *ratelimit.Limiter
+	authEnabled bool
+
+	mu  sync.RWMutex
+	cfg config.RateLimit
+}
+
+func newRateLimiter(cfg config.RateLimit, authEnabled bool) *rateLimiter {
+	return &rateLimiter{
+		limiter:     ratelimit.New(),
+		cfg:         cfg,
+		authEnabled: authEnabled,
+	}
+}
+
+func (l *rateLimiter) configure(cfg config.RateLimit) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.cfg = cfg
+}
+
+// limit wraps the handler of the route, deprecated aliases pass the route of their /v1 path to share its budget
+func (l *rateLimiter) limit(method, pattern string, next http.HandlerFunc) http.HandlerFunc {
+	route := method + " " + pattern
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		limit, ok := l.routeLimit(route)
+		if !ok {
+			next(w, r)
+
+			return
+		}
+
+		result := l.limiter.Allow(route+" "+l.client(r), limit)
+
+		w.Header().Set(rateLimitLimitHeader, strconv.Itoa(result.Limit))
+		w.Header().Set(rateLimitRemainingHeader, strconv.Itoa(result.Remaining))
+		w.Header().Set(rateLimitResetHeader, seconds(result.Reset))
+
+		if !result.Allowed {
+			w.Header().Set(retryAfterHeader, seconds(result.RetryAfter))
+			handler.WriteError(w, r, errorpkg.RateLimited)
+
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+// routeLimit returns the limit of the route, false means the route isn't limited
+func (l *rateLimiter) routeLimit(route string) (ratelimit.Limit, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	routeLimit, ok := l.cfg.Routes[route]
+	if !ok {
+		routeLimit = l.cfg.Default
+	}
+	if routeLimit.Rate <= 0 || routeLimit.Burst <= 0 {
+		return ratelimit.Limit{}, false
+	}
+
+	return ratelimit.Limit{
+		Rate:  routeLimit.Rate,
+		Burst: routeLimit.Burst,
+	}, true
+}
+
+// client identifies the caller. The key is checked by the auth middleware already, its hash is used
+// to avoid keeping keys in memory.
+func (l *rateLimiter) client(r *http.Request) string {
+	if key := handler.APIKeyFromRequest(r); l.authEnabled && key != "" {
+		hash := sha256.Sum256([]byte(key))
+
+		return "key:" + hex.EncodeToString(hash[:])
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	return "ip:" + host
+}
+
+// seconds rounds the duration up to whole seconds as RateLimit-Reset and Retry-After headers require
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,118 @@
+package setup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"blockchain-parser/config"
+	errorpkg "blockchain-parser/internal/error"
+	"blockchain-parser/internal/infrastructure/httpclient"
+	"blockchain-parser/internal/service"
+	"blockchain-parser/tools/logger"
+)
+
+// reloadableSettings are applied without restart, the interval of workers is applied as their schedule and jitter
+var reloadableSettings = map[string]bool{
+	"BLOCKCHAIN_PARSER_ETH_HTTP_CLIENT_HOST":        true,
+	"BLOCKCHAIN_PARSER_ETH_HTTP_CLIENT_TIMEOUT":     true,
+	"BLOCKCHAIN_PARSER_PARSER_WORKER_SCHEDULE":      true,
+	"BLOCKCHAIN_PARSER_PARSER_WORKER_JITTER":        true,
+	"BLOCKCHAIN_PARSER_PARSER_WORKER_OVERLAP":       true,
+	"BLOCKCHAIN_PARSER_PARSER_WORKER_RUN_TIMEOUT":   true,
+	"BLOCKCHAIN_PARSER_PARSER_WORKER_COUNT_WORKERS": true,
+	"BLOCKCHAIN_PARSER_LOG_LEVEL":                   true,
+	"BLOCKCHAIN_PARSER_RATE_LIMIT_RATE":             true,
+	"BLOCKCHAIN_PARSER_RATE_LIMIT_BURST":            true,
+	"BLOCKCHAIN_PARSER_RATE_LIMIT_ROUTES":           true,
+}
+
+// configReloader loads the config again and applies settings changed since the last load. Runtime changes
+// made by admin endpoints, e.g. the worker count or the log level, are kept until the setting changes in the config.
+// The config with invalid settings or changes of settings which need restart is rejected as a whole.
+type configReloader struct {
+	configPath string
+
+	ethereumClient *httpclient.Ethereum
+	parserControl  *service.ParserControl
+	logLevel       *logger.LevelVar
+	rateLimiter    *rateLimiter
+
+	log logger.Logger
+
+	mu  sync.Mutex
+	cfg config.Config
+}
+
+func (r *configReloader) Reload(ctx context.Context) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	log := logger.FromContext(ctx, r.log)
+
+	changed, err := r.reload(ctx)
+	if err != nil {
+		log.Warn("config reload failed", logger.Err(err))
+
+		return nil, err
+	}
+
+	log.Info("config was reloaded", logger.Any("changed", changed))
+
+	return changed, nil
+}
+
+func (r *configReloader) reload(ctx context.Context) ([]string, error) {
+	cfg, err := config.Load(r.configPath)
+	if err != nil {
+		validationErr := &config.ValidationError{}
+		if errors.As(err, &validationErr) {
+			return nil, errorpkg.NewConfigRejected(validationErr.Errors)
+		}
+
+		return nil, errorpkg.NewConfigRejected([]string{err.Error()})
+	}
+
+	changed := r.cfg.Changes(cfg)
+
+	problems := make([]string, 0)
+	for _, name := range changed {
+		if !reloadableSettings[name] {
+			problems = append(problems, fmt.Sprintf("%s can't be changed without restart", name))
+		}
+	}
+	if len(problems) > 0 {
+		return nil, errorpkg.NewConfigRejected(problems)
+	}
+
+	// the config is kept only when every change is applied, so settings which failed are applied again on the next reload.
+	// Applying of other settings again doesn't change them.
+	prev := r.cfg
+
+	if prev.EthereumHttpClient != cfg.EthereumHttpClient {
+		r.ethereumClient.Configure(cfg.EthereumHttpClient)
+	}
+
+	r.rateLimiter.configure(cfg.RateLimit)
+
+	if prev.Log.Level != cfg.Log.Level {
+		r.logLevel.Set(cfg.Log.Level)
+	}
+
+	if prev.ParserWorker.Schedule.String() != cfg.ParserWorker.Schedule.String() || prev.ParserWorker.Policy != cfg.ParserWorker.Policy {
+		if err := r.parserControl.Reschedule(ctx, cfg.ParserWorker.Schedule, cfg.ParserWorker.Policy); err != nil {
+			return nil, fmt.Errorf("fail reschedule parser workers in Reload: %w", err)
+		}
+	}
+
+	if prev.ParserWorker.CountWorkers != cfg.ParserWorker.CountWorkers {
+		if _, err := r.parserControl.Scale(ctx, cfg.ParserWorker.CountWorkers); err != nil {
+			return nil, fmt.Errorf("fail scale parser workers in Reload: %w", err)
+		}
+	}
+
+	r.cfg = cfg
+
+	return changed, nil
+}
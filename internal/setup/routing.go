@@ -1,23 +1,71 @@
 package setup
 
-import "net/http"
+import (
+	"net/http"
+
+	errorpkg "blockchain-parser/internal/error"
+	"blockchain-parser/internal/infrastructure/handler"
+)
 
 const (
-	blockChainParserGetBlockNumberPath = "/block/number"
-	blockChainParserSubscribePath      = "/address/subscribe"
-	blockChainParserGetTransaction     = "/address/transaction"
+	v1BlockPath                   = "/v1/blocks/{number}"
+	v1AddressSubscriptionPath     = "/v1/addresses/{address}/subscription"
+	v1AddressTransactionsPath     = "/v1/addresses/{address}/transactions"
+	v1AddressBalancePath          = "/v1/addresses/{address}/balance"
+	v1AddressStreamPath           = "/v1/addresses/{address}/stream"
+	v1QueryTransactionsPath       = "/v1/transactions/query"
+	v1TransactionPath             = "/v1/transactions/{hash}"
+	v1WalletsPath                 = "/v1/wallets"
+	v1WalletPath                  = "/v1/wallets/{id}"
+	v1WalletTransactionsPath      = "/v1/wallets/{id}/transactions"
+	v1AdminPathPrefix             = "/v1/admin/"
+	v1AdminTenantsPath            = "/v1/admin/tenants"
+	v1AdminAPIKeysPath            = "/v1/admin/tenants/{tenantId}/keys"
+	v1AdminAPIKeyPath             = "/v1/admin/tenants/{tenantId}/keys/{keyId}"
+	v1AdminLogLevelPath           = "/v1/admin/log-level"
+	v1AdminJobsPath               = "/v1/admin/jobs"
+	v1AdminJobPath                = "/v1/admin/jobs/{jobId}"
+	v1AdminJobRunsPath            = "/v1/admin/jobs/{jobId}/runs"
+	v1AdminParserPath             = "/v1/admin/parser"
+	v1AdminParserWorkersPath      = "/v1/admin/parser/workers"
+	v1AdminParserPausePath        = "/v1/admin/parser/pause"
+	v1AdminParserResumePath       = "/v1/admin/parser/resume"
+	v1AdminReparsePath            = "/v1/admin/reparse"
+	v1AdminReparseTaskPath        = "/v1/admin/reparse/{taskId}"
+	v1AdminConfigReloadPath       = "/v1/admin/config/reload"
+	deprecatedGetBlockNumberPath  = "/block/number"
+	deprecatedSubscribePath       = "/address/subscribe"
+	deprecatedGetTransactionsPath = "/address/transaction"
+	deprecatedQueryTransactions   = "/address/transaction/query"
+	deprecatedBalancePath         = "/address/{address}/balance"
+	deprecatedStreamPath          = "/address/{address}/stream"
+	deprecatedTransactionPath     = "/transaction/{hash}"
+	deprecatedWalletsPath         = "/wallet"
+	deprecatedWalletPath          = "/wallet/{id}"
+	deprecatedWalletTxnsPath      = "/wallet/{id}/transaction"
+
+	metricsPath = "/metrics"
+	healthzPath = "/healthz"
+	readyzPath  = "/readyz"
+
+	deprecationHeader = "Deprecation"
 )
 
-var (
-	routes = map[string]map[string]struct{}{
-		blockChainParserGetBlockNumberPath: {
-			http.MethodGet: struct{}{},
-		},
-		blockChainParserGetTransaction: {
-			http.MethodGet: struct{}{},
-		},
-		blockChainParserSubscribePath: {
-			http.MethodPost: struct{}{},
-		},
+// deprecated marks responses of the paths kept for compatibility, clients should move to /v1
+func deprecated(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(deprecationHeader, "true")
+
+		next(w, r)
 	}
-)
+}
+
+// notFound answers requests of unknown paths in the same format as other errors
+func notFound(w http.ResponseWriter, r *http.Request) {
+	handler.WriteError(w, r, errorpkg.NotFound)
+}
+
+// methodNotAllowed answers requests of known paths with unsupported method, Allow header is set by the router
+func methodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	handler.WriteError(w, r, errorpkg.MethodNotAllowed)
+}
@@ -4,8 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
 	"net/http"
+	"os"
 	"time"
 
 	"blockchain-parser/config"
@@ -15,17 +15,42 @@ import (
 	"blockchain-parser/internal/infrastructure/httpclient"
 	lockerpkg "blockchain-parser/internal/infrastructure/locker"
 	"blockchain-parser/internal/infrastructure/repository"
+	"blockchain-parser/internal/metrics"
 	"blockchain-parser/internal/service"
 	"blockchain-parser/tools/job"
+	"blockchain-parser/tools/logger"
+	"blockchain-parser/tools/router"
+	"blockchain-parser/tools/tracing"
 )
 
 type Server struct {
 	starts []func(ctx context.Context)
-	stops  []func()
+	stops  []func(ctx context.Context) error
+
+	shutdownTimeout time.Duration
+
+	log      logger.Logger
+	logLevel *logger.LevelVar
+	tracer   *tracing.Tracer
+	reloader *configReloader
 }
 
-func (s *Server) Configure() {
-	cfg := config.Parse()
+// Configure wires the service by the config, the config is loaded from the path again on reload
+func (s *Server) Configure(cfg config.Config, configPath string) {
+	s.shutdownTimeout = cfg.Server.ShutdownTimeout
+
+	//-------------------
+	// logger
+	//-------------------
+
+	s.logLevel = logger.NewLevelVar(cfg.Log.Level)
+	s.log = logger.New(os.Stdout, cfg.Log.Format, s.logLevel)
+
+	//-------------------
+	// tracer
+	//-------------------
+
+	s.tracer = newTracer(cfg.Tracing, s.log)
 
 	//-------------------
 	// repositories
@@ -33,13 +58,20 @@ func (s *Server) Configure() {
 
 	txnRepo := repository.NewInMemTransaction()
 	subscriberRepo := repository.NewInMemSubscriber()
+	subscriptionRepo := repository.NewInMemSubscription()
 	blockRepo := repository.NewInMemBlock()
+	eventRepo := repository.NewInMemEvent(cfg.Stream.Retention)
+	walletRepo := repository.NewInMemWallet()
+	balanceRepo := repository.NewInMemBalance()
+	tenantRepo := repository.NewInMemTenant()
+	apiKeyRepo := repository.NewInMemAPIKey()
+	reparseTaskRepo := repository.NewInMemReparseTask()
 
 	//-------------------
 	// http clients
 	//-------------------
 
-	ethereumClient := httpclient.NewEthereum(cfg.EthereumHttpClient)
+	ethereumClient := httpclient.NewEthereum(cfg.EthereumHttpClient, s.log)
 
 	//-------------------
 	// locker
@@ -51,55 +83,250 @@ func (s *Server) Configure() {
 	// services
 	//-------------------
 
-	parser := service.NewParser(txnRepo, subscriberRepo, blockRepo)
-	parserWorker := service.NewParserWorker(txnRepo, subscriberRepo, blockRepo, ethereumClient, locker)
+	parser := service.NewParser(
+		txnRepo,
+		subscriberRepo,
+		subscriptionRepo,
+		tenantRepo,
+		blockRepo,
+		balanceRepo,
+		ethereumClient,
+		cfg.Quota.MaxSubscriptions,
+	)
+	eventStream := service.NewEventStream(eventRepo, subscriptionRepo)
+	wallet := service.NewWallet(
+		walletRepo,
+		subscriberRepo,
+		subscriptionRepo,
+		tenantRepo,
+		txnRepo,
+		blockRepo,
+		balanceRepo,
+		ethereumClient,
+		cfg.Quota.MaxSubscriptions,
+	)
+	balance := service.NewBalance(balanceRepo, subscriptionRepo, blockRepo, ethereumClient, s.log)
+	tenant := service.NewTenant(tenantRepo, apiKeyRepo)
+	parserWorker := service.NewParserWorker(
+		txnRepo,
+		subscriberRepo,
+		blockRepo,
+		eventRepo,
+		balanceRepo,
+		ethereumClient,
+		locker,
+		cfg.ParserWorker.ConfirmationDepth,
+		cfg.Balance.TraceInternalTransfers,
+		s.log,
+	)
+	reparse := service.NewReparse(
+		reparseTaskRepo,
+		txnRepo,
+		subscriberRepo,
+		blockRepo,
+		ethereumClient,
+		cfg.Reparse.BlocksPerSecond,
+		cfg.Reparse.MaxBlocks,
+		s.log,
+	)
+	health := service.NewHealth(
+		blockRepo,
+		ethereumClient,
+		parserWorker,
+		cfg.Health.MaxParseAge,
+		cfg.Health.MaxBlockLag,
+		s.log,
+	)
+
+	//-------------------
+	// jobs
+	//-------------------
+
+	jobs := &job.Jobs{}
+	parserControl := service.NewParserControl(
+		jobs,
+		parserWorker,
+		cfg.ParserWorker.Schedule,
+		cfg.ParserWorker.Policy,
+		cfg.ParserWorker.MaxWorkers,
+		s.log,
+	)
+	s.createJobs(cfg, jobs, parserControl, balance, reparse)
 
 	//-------------------
 	// handlers
 	//-------------------
 
 	BlockChainParserHandler := handler.NewBlockChainParser(parser)
+	EventStreamHandler := handler.NewEventStream(eventStream, cfg.Stream.HeartbeatInterval)
+	WalletHandler := handler.NewWallet(wallet)
+	BalanceHandler := handler.NewBalance(balance)
+	TenantHandler := handler.NewTenant(tenant)
+	HealthHandler := handler.NewHealth(health)
+	LogLevelHandler := handler.NewLogLevel(s.logLevel)
+	JobHandler := handler.NewJob(jobs)
+	ParserControlHandler := handler.NewParserControl(parserControl)
+	ReparseHandler := handler.NewReparse(reparse)
+
+	rl := newRateLimiter(cfg.RateLimit, cfg.Auth.AdminKey != "")
+
+	s.reloader = &configReloader{
+		configPath:     configPath,
+		ethereumClient: ethereumClient,
+		parserControl:  parserControl,
+		logLevel:       s.logLevel,
+		rateLimiter:    rl,
+		log:            s.log,
+		cfg:            cfg,
+	}
+	ConfigHandler := handler.NewConfig(s.reloader)
+
+	rt := router.New()
+	rt.NotFound = notFound
+	rt.MethodNotAllowed = methodNotAllowed
+
+	rt.Handle(http.MethodGet, v1BlockPath, rl.limit(http.MethodGet, v1BlockPath, BlockChainParserHandler.GetBlock))
+	rt.Handle(http.MethodPut, v1AddressSubscriptionPath, rl.limit(http.MethodPut, v1AddressSubscriptionPath, BlockChainParserHandler.Subscribe))
+	rt.Handle(http.MethodDelete, v1AddressSubscriptionPath, rl.limit(http.MethodDelete, v1AddressSubscriptionPath, BlockChainParserHandler.Unsubscribe))
+	rt.Handle(http.MethodGet, v1AddressTransactionsPath, rl.limit(http.MethodGet, v1AddressTransactionsPath, BlockChainParserHandler.GetTransactions))
+	rt.Handle(http.MethodGet, v1AddressBalancePath, rl.limit(http.MethodGet, v1AddressBalancePath, BalanceHandler.GetBalance))
+	rt.Handle(http.MethodGet, v1AddressStreamPath, rl.limit(http.MethodGet, v1AddressStreamPath, EventStreamHandler.Stream))
+	rt.Handle(http.MethodPost, v1QueryTransactionsPath, rl.limit(http.MethodPost, v1QueryTransactionsPath, BlockChainParserHandler.QueryTransactions))
+	rt.Handle(http.MethodGet, v1TransactionPath, rl.limit(http.MethodGet, v1TransactionPath, BlockChainParserHandler.GetTransaction))
+	rt.Handle(http.MethodGet, v1WalletsPath, rl.limit(http.MethodGet, v1WalletsPath, WalletHandler.GetWallets))
+	rt.Handle(http.MethodPost, v1WalletsPath, rl.limit(http.MethodPost, v1WalletsPath, WalletHandler.CreateWallet))
+	rt.Handle(http.MethodGet, v1WalletPath, rl.limit(http.MethodGet, v1WalletPath, WalletHandler.GetWallet))
+	rt.Handle(http.MethodPut, v1WalletPath, rl.limit(http.MethodPut, v1WalletPath, WalletHandler.UpdateWallet))
+	rt.Handle(http.MethodDelete, v1WalletPath, rl.limit(http.MethodDelete, v1WalletPath, WalletHandler.DeleteWallet))
+	rt.Handle(http.MethodGet, v1WalletTransactionsPath, rl.limit(http.MethodGet, v1WalletTransactionsPath, WalletHandler.GetWalletTransactions))
+	rt.Handle(http.MethodGet, v1AdminTenantsPath, TenantHandler.GetTenants)
+	rt.Handle(http.MethodPost, v1AdminTenantsPath, TenantHandler.CreateTenant)
+	rt.Handle(http.MethodGet, v1AdminAPIKeysPath, TenantHandler.GetAPIKeys)
+	rt.Handle(http.MethodPost, v1AdminAPIKeysPath, TenantHandler.IssueAPIKey)
+	rt.Handle(http.MethodDelete, v1AdminAPIKeyPath, TenantHandler.RevokeAPIKey)
+	rt.Handle(http.MethodGet, v1AdminLogLevelPath, LogLevelHandler.GetLogLevel)
+	rt.Handle(http.MethodPut, v1AdminLogLevelPath, LogLevelHandler.SetLogLevel)
+	rt.Handle(http.MethodGet, v1AdminJobsPath, JobHandler.GetJobs)
+	rt.Handle(http.MethodGet, v1AdminJobPath, JobHandler.GetJob)
+	rt.Handle(http.MethodPost, v1AdminJobRunsPath, JobHandler.TriggerJob)
+	rt.Handle(http.MethodGet, v1AdminParserPath, ParserControlHandler.GetParserState)
+	rt.Handle(http.MethodPut, v1AdminParserWorkersPath, ParserControlHandler.ScaleWorkers)
+	rt.Handle(http.MethodPost, v1AdminParserPausePath, ParserControlHandler.PauseParsing)
+	rt.Handle(http.MethodPost, v1AdminParserResumePath, ParserControlHandler.ResumeParsing)
+	rt.Handle(http.MethodGet, v1AdminReparsePath, ReparseHandler.GetReparseTasks)
+	rt.Handle(http.MethodPost, v1AdminReparsePath, ReparseHandler.CreateReparseTask)
+	rt.Handle(http.MethodGet, v1AdminReparseTaskPath, ReparseHandler.GetReparseTask)
+	rt.Handle(http.MethodDelete, v1AdminReparseTaskPath, ReparseHandler.CancelReparseTask)
+	rt.Handle(http.MethodPost, v1AdminConfigReloadPath, ConfigHandler.ReloadConfig)
 
-	mux := http.NewServeMux()
-	mux.HandleFunc(blockChainParserGetBlockNumberPath, BlockChainParserHandler.GetCurrentBlock)
-	mux.HandleFunc(blockChainParserSubscribePath, BlockChainParserHandler.Subscribe)
-	mux.HandleFunc(blockChainParserGetTransaction, BlockChainParserHandler.GetTransactions)
+	rt.Handle(http.MethodGet, deprecatedGetBlockNumberPath, deprecated(rl.limit(http.MethodGet, v1BlockPath, BlockChainParserHandler.GetCurrentBlock)))
+	rt.Handle(http.MethodPost, deprecatedSubscribePath, deprecated(rl.limit(http.MethodPut, v1AddressSubscriptionPath, BlockChainParserHandler.Subscribe)))
+	rt.Handle(http.MethodGet, deprecatedGetTransactionsPath, deprecated(rl.limit(http.MethodGet, v1AddressTransactionsPath, BlockChainParserHandler.GetTransactions)))
+	rt.Handle(http.MethodPost, deprecatedQueryTransactions, deprecated(rl.limit(http.MethodPost, v1QueryTransactionsPath, BlockChainParserHandler.QueryTransactions)))
+	rt.Handle(http.MethodGet, deprecatedBalancePath, deprecated(rl.limit(http.MethodGet, v1AddressBalancePath, BalanceHandler.GetBalance)))
+	rt.Handle(http.MethodGet, deprecatedStreamPath, deprecated(rl.limit(http.MethodGet, v1AddressStreamPath, EventStreamHandler.Stream)))
+	rt.Handle(http.MethodGet, deprecatedTransactionPath, deprecated(rl.limit(http.MethodGet, v1TransactionPath, BlockChainParserHandler.GetTransaction)))
+	rt.Handle(http.MethodGet, deprecatedWalletsPath, deprecated(rl.limit(http.MethodGet, v1WalletsPath, WalletHandler.GetWallets)))
+	rt.Handle(http.MethodPost, deprecatedWalletsPath, deprecated(rl.limit(http.MethodPost, v1WalletsPath, WalletHandler.CreateWallet)))
+	rt.Handle(http.MethodGet, deprecatedWalletPath, deprecated(rl.limit(http.MethodGet, v1WalletPath, WalletHandler.GetWallet)))
+	rt.Handle(http.MethodPut, deprecatedWalletPath, deprecated(rl.limit(http.MethodPut, v1WalletPath, WalletHandler.UpdateWallet)))
+	rt.Handle(http.MethodDelete, deprecatedWalletPath, deprecated(rl.limit(http.MethodDelete, v1WalletPath, WalletHandler.DeleteWallet)))
+	rt.Handle(http.MethodGet, deprecatedWalletTxnsPath, deprecated(rl.limit(http.MethodGet, v1WalletTransactionsPath, WalletHandler.GetWalletTransactions)))
 
 	//-------------------
 	// setup server
 	//-------------------
 
+	if cfg.Auth.AdminKey == "" {
+		s.log.Warn("BLOCKCHAIN_PARSER_AUTH_ADMIN_KEY is not set, authentication is disabled")
+	}
+
+	streamRoutes := map[string]bool{
+		http.MethodGet + " " + v1AddressStreamPath:  true,
+		http.MethodGet + " " + deprecatedStreamPath: true,
+	}
+
+	// middlewares are listed from the innermost one
+	var apiHandler http.Handler = rt
+	apiHandler = authMiddleware(tenant, cfg.Auth.AdminKey, apiHandler)
+	apiHandler = timeoutMiddleware(cfg.Server, rt, streamRoutes, apiHandler)
+	apiHandler = contentTypeMiddleware(apiHandler)
+	apiHandler = panicRecoveryMiddleware(apiHandler)
+	apiHandler = httpMetricsMiddleware(rt, apiHandler)
+	apiHandler = tracingMiddleware(s.tracer, rt, apiHandler)
+	apiHandler = requestIDMiddleware(s.log, apiHandler)
+
+	repositoryMetrics := newRepositoryMetrics(blockRepo, subscriberRepo, txnRepo, s.log)
+
 	srv := http.Server{
-		Addr:    fmt.Sprintf("%s:%s", cfg.Server.Host, cfg.Server.Port),
-		Handler: methodCheckMiddleware(panicRecoveryMiddleware(contentTypeMiddleware(mux))),
+		Addr: fmt.Sprintf("%s:%s", cfg.Server.Host, cfg.Server.Port),
+		Handler: serviceEndpointsMiddleware(
+			map[string]http.Handler{
+				metricsPath: metricsHandler(metrics.Registry, repositoryMetrics),
+				healthzPath: http.HandlerFunc(HealthHandler.Liveness),
+				readyzPath:  http.HandlerFunc(HealthHandler.Readiness),
+			},
+			apiHandler,
+		),
 	}
+	srv.RegisterOnShutdown(EventStreamHandler.Close)
 
 	startServer := func(_ context.Context) {
 		go func() {
-			log.Printf("server started at: %s\n", srv.Addr)
+			s.log.Info("server started", logger.String("addr", srv.Addr))
 
 			if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
-				log.Printf("fail stop server: %s\n", err)
+				s.log.Error("fail stop server", logger.Err(err))
 			}
 		}()
 	}
-	stopServer := func() {
-		if err := srv.Shutdown(context.Background()); err != nil {
-			log.Printf("fail shutdown: %s", err)
+	stopServer := func(ctx context.Context) error {
+		if err := srv.Shutdown(ctx); err != nil {
+			// requests which don't finish before the deadline are dropped
+			_ = srv.Close()
+
+			return fmt.Errorf("fail shutdown server: %w", err)
 		}
+		s.log.Info("server stopped")
+
+		return nil
 	}
 
 	s.starts = append(s.starts, startServer)
-	s.stops = append(s.stops, stopServer)
 
 	//-------------------
 	// setup initial state
 	//-------------------
 
-	setupStartBlockNumber(ethereumClient, blockRepo, cfg.ParserWorker)
-	subscribePredefinedAddress(subscriberRepo, cfg.ParserWorker)
+	s.setupStartBlockNumber(ethereumClient, blockRepo, cfg.ParserWorker)
+	s.subscribePredefinedAddress(parser, cfg.ParserWorker)
+
+	//-------------------
+	// shutdown order
+	//-------------------
+
+	// the instance becomes not ready first, so no new work is routed to it while jobs and requests finish
+	s.stops = append(s.stops, func(_ context.Context) error {
+		health.Drain()
 
-	s.createJobs(cfg, parserWorker)
+		return nil
+	})
+
+	s.starts = append(s.starts, withTracer(s.tracer, jobs.Start))
+	s.stops = append(s.stops, jobs.Stop, stopServer)
+
+	// stores are in memory and have nothing to flush, the tracer stops last to export spans of stopped
+	// jobs and server. It has own deadline, so spans are exported even when the shutdown timeout is exceeded.
+	s.stops = append(s.stops, func(_ context.Context) error {
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.Tracing.OTLPTimeout)
+		defer cancel()
+
+		if err := s.tracer.Shutdown(ctx); err != nil {
+			return fmt.Errorf("fail shutdown tracer: %w", err)
+		}
+
+		return nil
+	})
 }
 
 func (s *Server) Start(ctx context.Context) {
@@ -108,57 +335,100 @@ func (s *Server) Start(ctx context.Context) {
 	}
 }
 
+// Reload applies settings changed in the config without restart, see configReloader
+func (s *Server) Reload(ctx context.Context) ([]string, error) {
+	return s.reloader.Reload(ctx)
+}
+
+// Stop stops the server in order: readiness, jobs, HTTP server, tracer. In-flight blocks and requests
+// are waited for up to the shutdown timeout, the rest of steps are run even when the timeout is exceeded.
 func (s *Server) Stop() {
+	s.log.Info("shutdown started", logger.Duration("timeout", s.shutdownTimeout))
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
+	defer cancel()
+
 	for _, stop := range s.stops {
-		stop()
+		if err := stop(ctx); err != nil {
+			s.log.Error("fail shutdown", logger.Err(err))
+		}
 	}
+
+	s.log.Info("shutdown finished")
 }
 
-func (s *Server) createJobs(cfg config.Config, parserWorker *service.ParserWorker) {
-	jobs := job.Jobs{}
-	for i := 0; i < cfg.ParserWorker.CountWorkers; i++ {
-		jobs.Add(job.NewJob(
-			parserWorker.Run,
-			constant.ParserWorkerJobName,
-			cfg.ParserWorker.Interval,
-		))
+// createJobs creates jobs of parser workers, balance reconciliation and reparse, they're started after the initial state
+// is set up. Parser workers are scaled by the parser control at runtime.
+func (s *Server) createJobs(
+	cfg config.Config,
+	jobs *job.Jobs,
+	parserControl *service.ParserControl,
+	balance *service.Balance,
+	reparse *service.Reparse,
+) {
+	jobs.Observe(observeJobRun)
+
+	if _, err := parserControl.Scale(context.Background(), cfg.ParserWorker.CountWorkers); err != nil {
+		s.fatal("fail create parser workers", logger.Err(err))
 	}
 
-	s.starts = append(s.starts, jobs.Start)
-	s.stops = append(s.stops, jobs.Stop)
+	jobs.Add(job.NewJob(
+		balance.Reconcile,
+		constant.BalanceReconcilerJobName,
+		cfg.Balance.ReconciliationSchedule,
+		cfg.Balance.ReconciliationPolicy,
+		s.log,
+	))
+
+	jobs.Add(job.NewJob(
+		reparse.Run,
+		constant.ReparseJobName,
+		cfg.Reparse.Schedule,
+		cfg.Reparse.Policy,
+		s.log,
+	))
 }
 
-func setupStartBlockNumber(
+func (s *Server) setupStartBlockNumber(
 	ethereumClient *httpclient.Ethereum,
 	blockRepo *repository.InMemBlock,
 	cfg config.ParserWorker,
 ) {
 	block := entity.Block{
-		Number:    int(cfg.StartBlockNumber),
+		Number:    entity.BlockNumber(cfg.StartBlockNumber),
 		Status:    constant.BlockStatusParsed,
 		UpdatedAt: time.Now(),
 	}
 
-	if block.Number == -1 {
+	// negative start block number means the current block of the node
+	if cfg.StartBlockNumber < 0 {
 		var err error
 		block.Number, err = ethereumClient.GetBlockNumber(context.Background())
 		if err != nil {
-			log.Fatalf("fail get block number: %s", err)
+			s.fatal("fail get block number", logger.Err(err))
 		}
 	}
 
 	if err := blockRepo.Upsert(context.Background(), block); err != nil {
-		log.Fatalf("fail save block: %s", err)
+		s.fatal("fail save block", logger.Err(err))
 	}
 }
 
-func subscribePredefinedAddress(subscriberRepo *repository.InMemSubscriber, cfg config.ParserWorker) {
-	for _, address := range cfg.PredefinedAddresses {
-		subscriber := entity.Subscriber{
-			Address: address,
+func (s *Server) subscribePredefinedAddress(parser *service.Parser, cfg config.ParserWorker) {
+	for _, value := range cfg.PredefinedAddresses {
+		address, err := entity.ParseAddress(value)
+		if err != nil {
+			s.fatal("predefined address is invalid", logger.String("address", value), logger.Err(err))
 		}
-		if err := subscriberRepo.Save(context.Background(), subscriber); err != nil {
-			log.Fatalf("fail subscribe predefined address: %s", err)
+
+		if err := parser.Subscribe(context.Background(), constant.DefaultTenantID, address); err != nil {
+			s.fatal("fail subscribe predefined address", logger.String("address", value), logger.Err(err))
 		}
 	}
 }
+
+// fatal logs the message and exits, it's used when the initial state can't be set up
+func (s *Server) fatal(msg string, fields ...logger.Field) {
+	s.log.Error(msg, fields...)
+	os.Exit(1)
+}
@@ -0,0 +1,74 @@
+package setup
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"strconv"
+
+	"blockchain-parser/config"
+	"blockchain-parser/tools/logger"
+	"blockchain-parser/tools/router"
+	"blockchain-parser/tools/tracing"
+)
+
+func newTracer(cfg config.Tracing, log logger.Logger) *tracing.Tracer {
+	var exporter tracing.Exporter
+	switch cfg.Exporter {
+	case config.TracingExporterStdout:
+		exporter = tracing.NewStdoutExporter(os.Stdout)
+	case config.TracingExporterOTLP:
+		exporter = tracing.NewOTLPExporter(cfg.OTLPEndpoint, cfg.ServiceName, cfg.OTLPHeaders, cfg.OTLPTimeout)
+	}
+
+	return tracing.NewTracer(exporter, cfg.SampleRatio, func(err error) {
+		log.Warn("fail export spans", logger.String("exporter", cfg.Exporter), logger.Err(err))
+	})
+}
+
+// tracingMiddleware starts the server span of the request, the span continues the trace of traceparent header.
+// The trace ID is added to the request logger, so logs of the request can be found by the trace.
+func tracingMiddleware(tracer *tracing.Tracer, rt *router.Router, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ctx := tracing.NewContext(req.Context(), tracer)
+		if remote, ok := tracing.Extract(req.Header); ok {
+			ctx = tracing.ContextWithRemoteSpanContext(ctx, remote)
+		}
+
+		route, ok := rt.Pattern(req.URL.Path)
+		if !ok {
+			route = unmatchedRoute
+		}
+
+		ctx, span := tracing.StartKind(ctx, req.Method+" "+route, tracing.SpanKindServer,
+			tracing.String("http.method", req.Method),
+			tracing.String("http.route", route),
+			tracing.String("http.target", req.URL.Path),
+		)
+		defer span.End()
+
+		log := logger.FromContext(ctx, logger.Nop()).With(logger.String("trace_id", span.SpanContext().TraceID.String()))
+		ctx = logger.NewContext(ctx, log)
+
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, req.WithContext(ctx))
+
+		span.SetAttributes(tracing.Int("http.status_code", recorder.status))
+		if recorder.status >= http.StatusInternalServerError {
+			span.RecordError(errHTTPStatus(recorder.status))
+		}
+	})
+}
+
+type errHTTPStatus int
+
+func (e errHTTPStatus) Error() string {
+	return "response status " + strconv.Itoa(int(e))
+}
+
+// withTracer returns the start of background jobs which starts their spans by the tracer
+func withTracer(tracer *tracing.Tracer, start func(ctx context.Context)) func(ctx context.Context) {
+	return func(ctx context.Context) {
+		start(tracing.NewContext(ctx, tracer))
+	}
+}
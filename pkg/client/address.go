@@ -0,0 +1,149 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Transaction of the address. Block fields are empty for pending transaction, withdrawal has withdrawal
+// and validator indexes instead of transaction index. Value is in the unit of the request.
+// Internal is set in feeds of several addresses for value transfers made by contract calls.
+type Transaction struct {
+	Hash             string `json:"hash,omitempty"`
+	Kind             string `json:"kind"`
+	From             string `json:"from"`
+	To               string `json:"to"`
+	Value            string `json:"value"`
+	BlockNumber      string `json:"blockNumber,omitempty"`
+	TransactionIndex *int   `json:"transactionIndex,omitempty"`
+	Timestamp        string `json:"timestamp,omitempty"`
+	WithdrawalIndex  *int   `json:"withdrawalIndex,omitempty"`
+	ValidatorIndex   *int   `json:"validatorIndex,omitempty"`
+	Internal         bool   `json:"internal,omitempty"`
+}
+
+// TransactionPage is the page of transactions, NextCursor is empty on the last page
+type TransactionPage struct {
+	Transactions []Transaction `json:"transactions"`
+	NextCursor   string        `json:"nextCursor,omitempty"`
+}
+
+// TransactionFilter filters transactions, empty fields aren't sent. The limit is the page size.
+// Block numbers are hex or decimal, times are RFC 3339 or unix seconds, the unit is hex, wei or ether.
+type TransactionFilter struct {
+	Limit     int
+	Cursor    string
+	FromBlock string
+	ToBlock   string
+	FromTime  string
+	ToTime    string
+	Direction string
+	MinValue  string
+	Order     string
+	Unit      string
+}
+
+func (f TransactionFilter) query() url.Values {
+	query := url.Values{}
+	if f.Limit > 0 {
+		query.Set("limit", strconv.Itoa(f.Limit))
+	}
+
+	for name, value := range map[string]string{
+		"cursor":    f.Cursor,
+		"fromBlock": f.FromBlock,
+		"toBlock":   f.ToBlock,
+		"fromTime":  f.FromTime,
+		"toTime":    f.ToTime,
+		"direction": f.Direction,
+		"minValue":  f.MinValue,
+		"order":     f.Order,
+		"unit":      f.Unit,
+	} {
+		if value != "" {
+			query.Set(name, value)
+		}
+	}
+
+	return query
+}
+
+// Balance of the address at the block, drift is the difference with the node found by the reconciliation
+type Balance struct {
+	Address     string `json:"address"`
+	BlockNumber string `json:"blockNumber"`
+	Balance     string `json:"balance"`
+	Source      string `json:"source"`
+	Drift       string `json:"drift,omitempty"`
+}
+
+// BalanceOptions selects the block and the unit of the balance, the last parsed block and hex are the defaults
+type BalanceOptions struct {
+	Block string
+	Unit  string
+}
+
+// Subscribe subscribes the tenant of the API key to the address, subscribing again does nothing
+func (c *Client) Subscribe(ctx context.Context, address string) error {
+	if err := c.do(ctx, newRequest(http.MethodPut, subscriptionPath(address)), nil); err != nil {
+		return fmt.Errorf("fail subscribe address (%s) in Subscribe: %w", address, err)
+	}
+
+	return nil
+}
+
+// Unsubscribe removes the subscription of the tenant to the address
+func (c *Client) Unsubscribe(ctx context.Context, address string) error {
+	if err := c.do(ctx, newRequest(http.MethodDelete, subscriptionPath(address)), nil); err != nil {
+		return fmt.Errorf("fail unsubscribe address (%s) in Unsubscribe: %w", address, err)
+	}
+
+	return nil
+}
+
+// GetTransactions returns the page of transactions of the subscribed address, NextCursor of the page
+// is passed as the cursor of the filter to get the next page
+func (c *Client) GetTransactions(ctx context.Context, address string, filter TransactionFilter) (TransactionPage, error) {
+	page := TransactionPage{}
+	path := "/v1/addresses/" + pathEscape(address) + "/transactions"
+	if err := c.do(ctx, newRequest(http.MethodGet, path).withQuery(filter.query()), &page); err != nil {
+		return TransactionPage{}, fmt.Errorf("fail get transactions of address (%s) in GetTransactions: %w", address, err)
+	}
+
+	return page, nil
+}
+
+// Transactions iterates over transactions of the subscribed address from the cursor of the filter
+func (c *Client) Transactions(address string, filter TransactionFilter) *TransactionIterator {
+	return newTransactionIterator(filter.Cursor, func(ctx context.Context, cursor string) (TransactionPage, error) {
+		filter.Cursor = cursor
+
+		return c.GetTransactions(ctx, address, filter)
+	})
+}
+
+// GetBalance returns the balance of the subscribed address
+func (c *Client) GetBalance(ctx context.Context, address string, opts BalanceOptions) (Balance, error) {
+	query := url.Values{}
+	if opts.Block != "" {
+		query.Set("block", opts.Block)
+	}
+	if opts.Unit != "" {
+		query.Set("unit", opts.Unit)
+	}
+
+	balance := Balance{}
+	path := "/v1/addresses/" + pathEscape(address) + "/balance"
+	if err := c.do(ctx, newRequest(http.MethodGet, path).withQuery(query), &balance); err != nil {
+		return Balance{}, fmt.Errorf("fail get balance of address (%s) in GetBalance: %w", address, err)
+	}
+
+	return balance, nil
+}
+
+func subscriptionPath(address string) string {
+	return "/v1/addresses/" + pathEscape(address) + "/subscription"
+}
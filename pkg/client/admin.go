@@ -0,0 +1,46 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+type logLevel struct {
+	Level string `json:"level"`
+}
+
+type configReloadResponse struct {
+	Changed []string `json:"changed"`
+}
+
+// GetLogLevel returns the log level of the service, it needs the admin key
+func (c *Client) GetLogLevel(ctx context.Context) (string, error) {
+	resp := logLevel{}
+	if err := c.do(ctx, newRequest(http.MethodGet, "/v1/admin/log-level"), &resp); err != nil {
+		return "", fmt.Errorf("fail get log level in GetLogLevel: %w", err)
+	}
+
+	return resp.Level, nil
+}
+
+// SetLogLevel sets debug, info, warn or error log level until restart, it needs the admin key
+func (c *Client) SetLogLevel(ctx context.Context, level string) (string, error) {
+	resp := logLevel{}
+	if err := c.do(ctx, newRequest(http.MethodPut, "/v1/admin/log-level").withBody(logLevel{Level: level}), &resp); err != nil {
+		return "", fmt.Errorf("fail set log level (%s) in SetLogLevel: %w", level, err)
+	}
+
+	return resp.Level, nil
+}
+
+// ReloadConfig applies settings changed in the config of the service, env var names of changed settings are returned.
+// The rejected config is returned as ErrConfigRejected with problems in the message. It needs the admin key.
+func (c *Client) ReloadConfig(ctx context.Context) ([]string, error) {
+	resp := configReloadResponse{}
+	if err := c.do(ctx, newRequest(http.MethodPost, "/v1/admin/config/reload"), &resp); err != nil {
+		return nil, fmt.Errorf("fail reload config in ReloadConfig: %w", err)
+	}
+
+	return resp.Changed, nil
+}
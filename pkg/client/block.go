@@ -0,0 +1,37 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+const latestBlock = "latest"
+
+// Block is the parsed block, the number is hex
+type Block struct {
+	Number    string `json:"number"`
+	Hash      string `json:"hash,omitempty"`
+	Status    string `json:"status"`
+	UpdatedAt string `json:"updatedAt"`
+}
+
+// GetBlock returns the parsed block by hex or decimal number
+func (c *Client) GetBlock(ctx context.Context, number string) (Block, error) {
+	block := Block{}
+	if err := c.do(ctx, newRequest(http.MethodGet, "/v1/blocks/"+pathEscape(number)), &block); err != nil {
+		return Block{}, fmt.Errorf("fail get block (%s) in GetBlock: %w", number, err)
+	}
+
+	return block, nil
+}
+
+// GetLatestBlock returns the last parsed block
+func (c *Client) GetLatestBlock(ctx context.Context) (Block, error) {
+	block, err := c.GetBlock(ctx, latestBlock)
+	if err != nil {
+		return Block{}, fmt.Errorf("fail get latest block in GetLatestBlock: %w", err)
+	}
+
+	return block, nil
+}
@@ -0,0 +1,239 @@
+// Package client is the typed client of the blockchain parser HTTP API.
+//
+// Every method takes the context of the call. Responses with other than 2xx status are returned as *Error,
+// it matches sentinel errors of the package with errors.Is, e.g. ErrNotFound or ErrRateLimited.
+// Idempotent requests are retried on network errors, rate limiting and temporary errors of the server.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	apiKeyHeader = "X-API-Key"
+
+	defaultTimeout         = 30 * time.Second
+	defaultMaxRetries      = 2
+	defaultRetryBackoff    = 200 * time.Millisecond
+	defaultMaxRetryBackoff = 5 * time.Second
+
+	// maxErrorBodySize limits the body read from responses which aren't API errors, e.g. of a proxy
+	maxErrorBodySize = 4 << 10
+)
+
+// Config of the client, the API key is the key of the tenant or the admin key for admin endpoints
+type Config struct {
+	BaseURL string
+	APIKey  string
+	// Timeout of one attempt of the request, 30s by default. It's ignored when HTTPClient is set.
+	// Event streams have no timeout, they're ended by the context.
+	Timeout    time.Duration
+	HTTPClient *http.Client
+	// MaxRetries is the count of retries of the request, 2 by default, negative disables retries
+	MaxRetries int
+	// RetryBackoff is the delay before the first retry, it's doubled for every next retry up to MaxRetryBackoff.
+	// Retry-After of the response overrides it. 200ms and 5s by default.
+	RetryBackoff    time.Duration
+	MaxRetryBackoff time.Duration
+}
+
+type Client struct {
+	baseURL *url.URL
+	apiKey  string
+	clnt    *http.Client
+	// streamClnt is the client without timeout for event streams
+	streamClnt *http.Client
+	retry      retryPolicy
+}
+
+func NewClient(cfg Config) (*Client, error) {
+	baseURL, err := url.Parse(strings.TrimSuffix(cfg.BaseURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("fail parse base URL in NewClient: %w", err)
+	}
+	if baseURL.Scheme != "http" && baseURL.Scheme != "https" || baseURL.Host == "" {
+		return nil, fmt.Errorf("base URL (%s) must be http(s) URL with host in NewClient", cfg.BaseURL)
+	}
+
+	clnt := cfg.HTTPClient
+	if clnt == nil {
+		timeout := cfg.Timeout
+		if timeout <= 0 {
+			timeout = defaultTimeout
+		}
+		clnt = &http.Client{Timeout: timeout}
+	}
+	streamClnt := *clnt
+	streamClnt.Timeout = 0
+
+	return &Client{
+		baseURL:    baseURL,
+		apiKey:     cfg.APIKey,
+		clnt:       clnt,
+		streamClnt: &streamClnt,
+		retry:      newRetryPolicy(cfg),
+	}, nil
+}
+
+// request is the call of the API. Idempotent requests are retried on transient errors, other requests
+// are retried only when they're rate limited because the server hasn't processed them.
+type request struct {
+	method     string
+	path       string
+	query      url.Values
+	header     http.Header
+	body       interface{}
+	idempotent bool
+}
+
+func newRequest(method, path string) request {
+	return request{
+		method:     method,
+		path:       path,
+		idempotent: method != http.MethodPost,
+	}
+}
+
+func (r request) withQuery(query url.Values) request {
+	r.query = query
+
+	return r
+}
+
+func (r request) withBody(body interface{}) request {
+	r.body = body
+
+	return r
+}
+
+// do sends the request and decodes JSON response into the result, nil result is skipped.
+// Responses with status other than 2xx are returned as *Error.
+func (c *Client) do(ctx context.Context, req request, result interface{}) error {
+	resp, err := c.send(ctx, c.clnt, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return decodeError(resp)
+	}
+
+	return decodeResponse(req, resp, result)
+}
+
+func decodeResponse(req request, resp *http.Response, result interface{}) error {
+	if result == nil {
+		return nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("fail decode response of %s %s: %w", req.method, req.path, err)
+	}
+
+	return nil
+}
+
+// send sends the request with retries, the response of the last attempt is returned. The caller closes its body.
+func (c *Client) send(ctx context.Context, clnt *http.Client, req request) (*http.Response, error) {
+	var data []byte
+	if req.body != nil {
+		var err error
+		data, err = json.Marshal(req.body)
+		if err != nil {
+			return nil, fmt.Errorf("fail marshal request: %w", err)
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := c.sendOnce(ctx, clnt, req, data)
+
+		delay, retry := c.retry.delay(attempt, req.idempotent, resp, err)
+		if !retry || ctx.Err() != nil {
+			return resp, err
+		}
+
+		if resp != nil {
+			// the body is drained, so the connection is reused
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxErrorBodySize))
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return nil, fmt.Errorf("fail send request %s %s: %w", req.method, req.path, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+func (c *Client) sendOnce(ctx context.Context, clnt *http.Client, req request, data []byte) (*http.Response, error) {
+	u := *c.baseURL
+	u.Path += req.path
+	u.RawQuery = req.query.Encode()
+
+	var body io.Reader
+	if data != nil {
+		body = bytes.NewReader(data)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, req.method, u.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("fail create request: %w", err)
+	}
+	for name, values := range req.header {
+		httpReq.Header[name] = values
+	}
+	if httpReq.Header.Get("Accept") == "" {
+		httpReq.Header.Set("Accept", "application/json")
+	}
+	if data != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	if c.apiKey != "" {
+		httpReq.Header.Set(apiKeyHeader, c.apiKey)
+	}
+
+	resp, err := clnt.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("fail send request %s %s: %w", req.method, req.path, err)
+	}
+
+	return resp, nil
+}
+
+// decodeError reads the error of the API, other bodies are kept in the message as is
+func decodeError(resp *http.Response) error {
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	if err != nil {
+		return fmt.Errorf("fail read error response with status %d: %w", resp.StatusCode, err)
+	}
+
+	apiErr := &Error{}
+	if err := json.Unmarshal(data, apiErr); err != nil || apiErr.Code == "" {
+		apiErr = &Error{Message: strings.TrimSpace(string(data))}
+		if apiErr.Message == "" {
+			apiErr.Message = http.StatusText(resp.StatusCode)
+		}
+	}
+	apiErr.StatusCode = resp.StatusCode
+	apiErr.RetryAfter, _ = parseRetryAfter(resp.Header.Get(retryAfterHeader))
+
+	return apiErr
+}
+
+// pathEscape escapes the value of the path parameter
+func pathEscape(value string) string {
+	return url.PathEscape(value)
+}
@@ -0,0 +1,259 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewClient(t *testing.T) {
+	tests := []struct {
+		name    string
+		baseURL string
+		wantErr bool
+	}{
+		{
+			name:    "base URL with path",
+			baseURL: "https://parser.example/api/",
+		},
+		{
+			name:    "base URL without scheme",
+			baseURL: "parser.example:8000",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewClient(Config{BaseURL: tt.baseURL}); (err != nil) != tt.wantErr {
+				t.Errorf("NewClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClient_do(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/addresses/0xa/subscription" || r.Header.Get(apiKeyHeader) != "key-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"code":"unauthorized","message":"api key is required"}`))
+
+			return
+		}
+
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"code":"subscription_limit_exceeded","message":"subscription limit of the tenant is exceeded","requestId":"req-1"}`))
+	}))
+	defer srv.Close()
+
+	clnt, err := NewClient(Config{BaseURL: srv.URL + "/api", APIKey: "key-1"})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	err = clnt.Subscribe(context.Background(), "0xa")
+	if !HasCode(err, CodeSubscriptionLimit) {
+		t.Fatalf("Subscribe() error = %v, want code %s", err, CodeSubscriptionLimit)
+	}
+
+	apiErr := &Error{}
+	_ = errors.As(err, &apiErr)
+	want := &Error{StatusCode: http.StatusForbidden, Code: CodeSubscriptionLimit, Message: "subscription limit of the tenant is exceeded", RequestID: "req-1"}
+	if !reflect.DeepEqual(apiErr, want) {
+		t.Errorf("Subscribe() error got = %+v, want %+v", apiErr, want)
+	}
+}
+
+func TestClient_send(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		maxRetries   int
+		statuses     []int
+		retryAfter   string
+		wantStatus   int
+		wantAttempts int
+	}{
+		{
+			name:         "idempotent request is retried on unavailable server",
+			method:       http.MethodGet,
+			statuses:     []int{http.StatusServiceUnavailable, http.StatusOK},
+			wantStatus:   http.StatusOK,
+			wantAttempts: 2,
+		},
+		{
+			name:         "retries are limited",
+			method:       http.MethodGet,
+			statuses:     []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway, http.StatusOK},
+			wantStatus:   http.StatusBadGateway,
+			wantAttempts: 3,
+		},
+		{
+			name:         "post isn't retried on unavailable server",
+			method:       http.MethodPost,
+			statuses:     []int{http.StatusServiceUnavailable, http.StatusOK},
+			wantStatus:   http.StatusServiceUnavailable,
+			wantAttempts: 1,
+		},
+		{
+			name:         "post is retried on rate limit after Retry-After",
+			method:       http.MethodPost,
+			statuses:     []int{http.StatusTooManyRequests, http.StatusCreated},
+			retryAfter:   "0",
+			wantStatus:   http.StatusCreated,
+			wantAttempts: 2,
+		},
+		{
+			name:         "negative max retries disables retries",
+			method:       http.MethodGet,
+			maxRetries:   -1,
+			statuses:     []int{http.StatusTooManyRequests, http.StatusOK},
+			wantStatus:   http.StatusTooManyRequests,
+			wantAttempts: 1,
+		},
+		{
+			name:         "client error isn't retried",
+			method:       http.MethodGet,
+			statuses:     []int{http.StatusNotFound, http.StatusOK},
+			wantStatus:   http.StatusNotFound,
+			wantAttempts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.retryAfter != "" {
+					w.Header().Set(retryAfterHeader, tt.retryAfter)
+				}
+				w.WriteHeader(tt.statuses[attempts])
+				attempts++
+			}))
+			defer srv.Close()
+
+			clnt, err := NewClient(Config{BaseURL: srv.URL, MaxRetries: tt.maxRetries, RetryBackoff: time.Millisecond})
+			if err != nil {
+				t.Fatalf("NewClient() error = %v", err)
+			}
+
+			resp, err := clnt.send(context.Background(), clnt.clnt, newRequest(tt.method, "/v1/x"))
+			if err != nil {
+				t.Fatalf("send() error = %v", err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus || attempts != tt.wantAttempts {
+				t.Errorf("send() got status %d after %d attempts, want %d after %d", resp.StatusCode, attempts, tt.wantStatus, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestError_Is(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		target error
+		want   bool
+	}{
+		{
+			name:   "code",
+			err:    &Error{StatusCode: http.StatusNotFound, Code: CodeSubscriberNotFound},
+			target: ErrSubscriberNotFound,
+			want:   true,
+		},
+		{
+			name:   "status",
+			err:    fmt.Errorf("fail: %w", &Error{StatusCode: http.StatusNotFound, Code: CodeSubscriberNotFound}),
+			target: ErrNotFound,
+			want:   true,
+		},
+		{
+			name:   "status of a proxy",
+			err:    &Error{StatusCode: http.StatusGatewayTimeout, Message: "gateway timeout"},
+			target: ErrUnavailable,
+			want:   true,
+		},
+		{
+			name:   "other code",
+			err:    &Error{StatusCode: http.StatusNotFound, Code: CodeJobNotFound},
+			target: ErrWalletNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errors.Is(tt.err, tt.target); got != tt.want {
+				t.Errorf("errors.Is() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTransactionIterator(t *testing.T) {
+	pages := map[string]TransactionPage{
+		"":   {Transactions: []Transaction{{Hash: "0x1"}, {Hash: "0x2"}}, NextCursor: "c1"},
+		"c1": {Transactions: []Transaction{}, NextCursor: "c2"},
+		"c2": {Transactions: []Transaction{{Hash: "0x3"}}},
+	}
+	cursors := make([]string, 0)
+	it := newTransactionIterator("", func(ctx context.Context, cursor string) (TransactionPage, error) {
+		cursors = append(cursors, cursor)
+
+		return pages[cursor], nil
+	})
+
+	hashes := make([]string, 0)
+	for it.Next(context.Background()) {
+		hashes = append(hashes, it.Transaction().Hash)
+	}
+	if it.Err() != nil {
+		t.Fatalf("Err() = %v", it.Err())
+	}
+	if it.Next(context.Background()) {
+		t.Errorf("Next() after the last page = true")
+	}
+
+	if want := []string{"0x1", "0x2", "0x3"}; !reflect.DeepEqual(hashes, want) {
+		t.Errorf("Transaction() got = %v, want %v", hashes, want)
+	}
+	if want := []string{"", "c1", "c2"}; !reflect.DeepEqual(cursors, want) {
+		t.Errorf("cursors got = %v, want %v", cursors, want)
+	}
+}
+
+func TestEventStream_Next(t *testing.T) {
+	body := ": heartbeat\n\n" +
+		"id: 1\nevent: transaction\ndata: {\"id\":1,\"type\":\"transaction\",\"transaction\":{\"hash\":\"0x1\"}}\n\n" +
+		": heartbeat\n\n" +
+		"id: 2\nevent: confirmation\ndata: {\"id\":2,\"type\":\"confirmation\",\"confirmations\":3,\"transaction\":{\"hash\":\"0x1\"}}\n\n"
+	stream := &EventStream{
+		body:   io.NopCloser(strings.NewReader(body)),
+		reader: bufio.NewReader(strings.NewReader(body)),
+	}
+
+	events := make([]Event, 0)
+	for stream.Next() {
+		events = append(events, stream.Event())
+	}
+
+	want := []Event{
+		{ID: 1, Type: "transaction", Transaction: Transaction{Hash: "0x1"}},
+		{ID: 2, Type: "confirmation", Confirmations: 3, Transaction: Transaction{Hash: "0x1"}},
+	}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("Event() got = %+v, want %+v", events, want)
+	}
+	if !errors.Is(stream.Err(), ErrStreamClosed) {
+		t.Errorf("Err() = %v, want %v", stream.Err(), ErrStreamClosed)
+	}
+}
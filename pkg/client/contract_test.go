@@ -0,0 +1,352 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"blockchain-parser/config"
+	"blockchain-parser/internal/setup"
+	"blockchain-parser/pkg/client"
+)
+
+const (
+	contractAdminKey = "admin-key"
+	contractAddress  = "0xa855d1198c67839e596b9a5d7c46f8ea31cfefde"
+	contractOther    = "0xfd4492e70df97a6155c6d244f5ec5b5a39b6f096"
+
+	// contractStartBlock is the first parsed block, the head of the fake node grows from it
+	contractStartBlock = 0x60
+)
+
+// TestClient_contract runs the client against the router of the service set up by the config,
+// the Ethereum node is faked
+func TestClient_contract(t *testing.T) {
+	if testing.Short() {
+		t.Skip("contract test starts the service")
+	}
+
+	node := httptest.NewServer(newFakeNode(contractStartBlock, 50*time.Millisecond))
+	defer node.Close()
+
+	baseURL := startServer(t, node.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	admin := newContractClient(t, baseURL, contractAdminKey)
+
+	waitFor(t, "server is ready", func() bool {
+		report, err := admin.GetReadiness(ctx)
+
+		return err == nil && report.OK()
+	})
+
+	tenant, err := admin.CreateTenant(ctx, client.TenantCreate{Name: "contract"})
+	if err != nil {
+		t.Fatalf("CreateTenant() error = %v", err)
+	}
+	key, err := admin.IssueAPIKey(ctx, tenant.ID)
+	if err != nil {
+		t.Fatalf("IssueAPIKey() error = %v", err)
+	}
+	clnt := newContractClient(t, baseURL, key.Key)
+
+	t.Run("subscription and transactions", func(t *testing.T) {
+		if err := clnt.Subscribe(ctx, contractAddress); err != nil {
+			t.Fatalf("Subscribe() error = %v", err)
+		}
+
+		waitFor(t, "transactions are parsed", func() bool {
+			page, err := clnt.GetTransactions(ctx, contractAddress, client.TransactionFilter{Limit: 3})
+
+			return err == nil && page.NextCursor != ""
+		})
+
+		hashes := map[string]bool{}
+		it := clnt.Transactions(contractAddress, client.TransactionFilter{Limit: 1, Unit: "wei"})
+		for len(hashes) < 3 && it.Next(ctx) {
+			hashes[it.Transaction().Hash+it.Transaction().Kind+it.Transaction().BlockNumber] = true
+		}
+		if err := it.Err(); err != nil || len(hashes) < 3 {
+			t.Fatalf("Transactions() read %d transactions, error = %v", len(hashes), err)
+		}
+
+		page, err := clnt.QueryTransactions(ctx, client.TransactionQuery{Addresses: []string{contractAddress}, Direction: "out", Limit: 1})
+		if err != nil || len(page.Transactions) != 1 || !strings.EqualFold(page.Transactions[0].From, contractAddress) {
+			t.Fatalf("QueryTransactions() got = %+v, error = %v", page, err)
+		}
+
+		lookup, err := clnt.GetTransaction(ctx, page.Transactions[0].Hash, "ether")
+		if err != nil || !lookup.Indexed || lookup.Transaction.Value != "1" {
+			t.Errorf("GetTransaction() got = %+v, error = %v", lookup, err)
+		}
+
+		balance, err := clnt.GetBalance(ctx, contractAddress, client.BalanceOptions{})
+		if err != nil || !strings.EqualFold(balance.Address, contractAddress) {
+			t.Errorf("GetBalance() got = %+v, error = %v", balance, err)
+		}
+
+		_, err = clnt.GetTransactions(ctx, contractOther, client.TransactionFilter{})
+		if !errors.Is(err, client.ErrSubscriberNotFound) || !errors.Is(err, client.ErrNotFound) {
+			t.Errorf("GetTransactions() of not subscribed address error = %v, want %v", err, client.ErrSubscriberNotFound)
+		}
+
+		_, err = clnt.GetTransactions(ctx, "0x1", client.TransactionFilter{})
+		if !errors.Is(err, client.ErrInvalidAddress) || !errors.Is(err, client.ErrInvalidArgument) {
+			t.Errorf("GetTransactions() of invalid address error = %v, want %v", err, client.ErrInvalidAddress)
+		}
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		streamCtx, streamCancel := context.WithTimeout(ctx, 10*time.Second)
+		defer streamCancel()
+
+		stream, err := clnt.Stream(streamCtx, []string{contractAddress}, client.StreamOptions{Unit: "ether"})
+		if err != nil {
+			t.Fatalf("Stream() error = %v", err)
+		}
+		defer stream.Close()
+
+		if !stream.Next() {
+			t.Fatalf("Next() = false, error = %v", stream.Err())
+		}
+		if event := stream.Event(); event.ID == 0 || event.Type == "" {
+			t.Errorf("Event() got = %+v", event)
+		}
+	})
+
+	t.Run("wallets", func(t *testing.T) {
+		wallet, err := clnt.CreateWallet(ctx, client.WalletSave{Name: "main", Addresses: []string{contractAddress}})
+		if err != nil {
+			t.Fatalf("CreateWallet() error = %v", err)
+		}
+
+		wallet, err = clnt.UpdateWallet(ctx, wallet.ID, client.WalletSave{Name: "main", Addresses: []string{contractAddress, contractOther}})
+		if err != nil || len(wallet.Addresses) != 2 {
+			t.Fatalf("UpdateWallet() got = %+v, error = %v", wallet, err)
+		}
+
+		wallets, err := clnt.GetWallets(ctx)
+		if err != nil || len(wallets) != 1 || wallets[0].ID != wallet.ID {
+			t.Errorf("GetWallets() got = %+v, error = %v", wallets, err)
+		}
+
+		it := clnt.WalletTransactions(wallet.ID, client.TransactionFilter{Limit: 1})
+		if !it.Next(ctx) {
+			t.Errorf("WalletTransactions() is empty, error = %v", it.Err())
+		}
+
+		if err := clnt.DeleteWallet(ctx, wallet.ID); err != nil {
+			t.Fatalf("DeleteWallet() error = %v", err)
+		}
+		if _, err := clnt.GetWallet(ctx, wallet.ID); !errors.Is(err, client.ErrWalletNotFound) {
+			t.Errorf("GetWallet() of deleted wallet error = %v, want %v", err, client.ErrWalletNotFound)
+		}
+	})
+
+	t.Run("admin", func(t *testing.T) {
+		if _, err := clnt.GetJobs(ctx); !errors.Is(err, client.ErrForbidden) {
+			t.Errorf("GetJobs() with tenant key error = %v, want %v", err, client.ErrForbidden)
+		}
+
+		jobs, err := admin.GetJobs(ctx)
+		if err != nil || len(jobs) == 0 {
+			t.Fatalf("GetJobs() got = %+v, error = %v", jobs, err)
+		}
+		if job, err := admin.GetJob(ctx, jobs[0].ID); err != nil || job.ID != jobs[0].ID {
+			t.Errorf("GetJob() got = %+v, error = %v", job, err)
+		}
+		if _, err := admin.GetJob(ctx, "unknown"); !errors.Is(err, client.ErrJobNotFound) {
+			t.Errorf("GetJob() of unknown job error = %v, want %v", err, client.ErrJobNotFound)
+		}
+
+		state, err := admin.PauseParsing(ctx)
+		if err != nil || !state.Paused {
+			t.Errorf("PauseParsing() got = %+v, error = %v", state, err)
+		}
+		state, err = admin.ResumeParsing(ctx)
+		if err != nil || state.Paused {
+			t.Errorf("ResumeParsing() got = %+v, error = %v", state, err)
+		}
+		state, err = admin.ScaleWorkers(ctx, 1)
+		if err != nil || state.Workers != 1 {
+			t.Errorf("ScaleWorkers() got = %+v, error = %v", state, err)
+		}
+
+		level, err := admin.SetLogLevel(ctx, "warn")
+		if err != nil || level != "warn" {
+			t.Errorf("SetLogLevel() got = %s, error = %v", level, err)
+		}
+
+		task, err := admin.CreateReparseTask(ctx, client.ReparseCreate{
+			From:      strconv.Itoa(contractStartBlock),
+			To:        strconv.Itoa(contractStartBlock + 1),
+			Addresses: []string{contractAddress},
+		})
+		if err != nil {
+			t.Fatalf("CreateReparseTask() error = %v", err)
+		}
+		if got, err := admin.GetReparseTask(ctx, task.ID); err != nil || got.ID != task.ID {
+			t.Errorf("GetReparseTask() got = %+v, error = %v", got, err)
+		}
+
+		if _, err := admin.ReloadConfig(ctx); err != nil {
+			t.Errorf("ReloadConfig() error = %v", err)
+		}
+
+		if err := admin.RevokeAPIKey(ctx, tenant.ID, key.ID); err != nil {
+			t.Fatalf("RevokeAPIKey() error = %v", err)
+		}
+		if err := clnt.Unsubscribe(ctx, contractAddress); !errors.Is(err, client.ErrUnauthorized) {
+			t.Errorf("Unsubscribe() with revoked key error = %v, want %v", err, client.ErrUnauthorized)
+		}
+	})
+}
+
+// startServer configures the service by env vars and starts it on a free port, the service is stopped
+// on the end of the test
+func startServer(t *testing.T, nodeURL string) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("fail find free port: %v", err)
+	}
+	port := strconv.Itoa(listener.Addr().(*net.TCPAddr).Port)
+	_ = listener.Close()
+
+	for name, value := range map[string]string{
+		"BLOCKCHAIN_PARSER_SERVER_HOST":                      "127.0.0.1",
+		"BLOCKCHAIN_PARSER_SERVER_PORT":                      port,
+		"BLOCKCHAIN_PARSER_ETH_HTTP_CLIENT_HOST":             nodeURL,
+		"BLOCKCHAIN_PARSER_ETH_HTTP_CLIENT_TIMEOUT":          "2s",
+		"BLOCKCHAIN_PARSER_PARSER_WORKER_COUNT_WORKERS":      "2",
+		"BLOCKCHAIN_PARSER_PARSER_WORKER_INTERVAL":           "50ms",
+		"BLOCKCHAIN_PARSER_PARSER_WORKER_START_BLOCK_NUMBER": fmt.Sprintf("0x%x", contractStartBlock),
+		"BLOCKCHAIN_PARSER_STREAM_HEARTBEAT_INTERVAL":        "1s",
+		"BLOCKCHAIN_PARSER_AUTH_ADMIN_KEY":                   contractAdminKey,
+		"BLOCKCHAIN_PARSER_LOG_LEVEL":                        "error",
+	} {
+		t.Setenv(name, value)
+	}
+
+	cfg, err := config.Load("")
+	if err != nil {
+		t.Fatalf("config.Load() error = %v", err)
+	}
+
+	srv := &setup.Server{}
+	srv.Configure(cfg, "")
+	srv.Start(context.Background())
+	t.Cleanup(srv.Stop)
+
+	return "http://127.0.0.1:" + port
+}
+
+func newContractClient(t *testing.T, baseURL, apiKey string) *client.Client {
+	t.Helper()
+
+	clnt, err := client.NewClient(client.Config{BaseURL: baseURL, APIKey: apiKey, RetryBackoff: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	return clnt
+}
+
+func waitFor(t *testing.T, what string, ok func() bool) {
+	t.Helper()
+
+	for deadline := time.Now().Add(10 * time.Second); time.Now().Before(deadline); time.Sleep(50 * time.Millisecond) {
+		if ok() {
+			return
+		}
+	}
+
+	t.Fatalf("timeout of waiting for: %s", what)
+}
+
+// newFakeNode serves JSON-RPC of the Ethereum node, the head grows by one block every interval. Every block has
+// a transfer from contractAddress, a transfer between other addresses and a withdrawal to contractAddress.
+func newFakeNode(startBlock int64, interval time.Duration) http.Handler {
+	start := time.Now()
+	head := func() int64 {
+		return startBlock + int64(time.Since(start)/interval)
+	}
+
+	txn := func(number int64, index int) map[string]interface{} {
+		from, to := contractAddress, contractOther
+		if index == 1 {
+			from, to = contractOther, "0x000000000000000000000000000000000000dead"
+		}
+
+		return map[string]interface{}{
+			"hash":             fmt.Sprintf("0x%064x", number*10+int64(index)),
+			"from":             from,
+			"to":               to,
+			"value":            "0xde0b6b3a7640000",
+			"blockNumber":      fmt.Sprintf("0x%x", number),
+			"blockHash":        fmt.Sprintf("0x%064x", number),
+			"transactionIndex": fmt.Sprintf("0x%x", index),
+			"gas":              "0x5208",
+			"gasPrice":         "0x3b9aca00",
+		}
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := struct {
+			ID     int32         `json:"id"`
+			Method string        `json:"method"`
+			Params []interface{} `json:"params"`
+		}{}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+
+		var result interface{}
+		switch req.Method {
+		case "eth_blockNumber":
+			result = fmt.Sprintf("0x%x", head())
+		case "eth_getBlockByNumber":
+			number, _ := strconv.ParseInt(fmt.Sprint(req.Params[0]), 0, 64)
+			if number <= head() {
+				result = map[string]interface{}{
+					"number":       fmt.Sprintf("0x%x", number),
+					"hash":         fmt.Sprintf("0x%064x", number),
+					"parentHash":   fmt.Sprintf("0x%064x", number-1),
+					"timestamp":    fmt.Sprintf("0x%x", 1700000000+number*12),
+					"transactions": []interface{}{txn(number, 0), txn(number, 1)},
+					"withdrawals": []interface{}{map[string]interface{}{
+						"index":          fmt.Sprintf("0x%x", number),
+						"validatorIndex": "0x10",
+						"address":        contractAddress,
+						"amount":         "0x3b9aca00",
+					}},
+				}
+			}
+		case "eth_getBalance":
+			result = "0x56bc75e2d63100000"
+		case "eth_getTransactionByHash":
+			result = txn(head()-1, 0)
+		case "eth_getTransactionReceipt":
+			result = map[string]interface{}{"gasUsed": "0x5208", "effectiveGasPrice": "0x3b9aca00", "status": "0x1"}
+		default:
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"jsonrpc": "2.0",
+				"id":      req.ID,
+				"error":   map[string]interface{}{"code": -32601, "message": "method not found"},
+			})
+
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": result})
+	})
+}
@@ -0,0 +1,150 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Codes of API errors
+const (
+	CodeInvalidArgument     = "invalid_argument"
+	CodeInvalidAddress      = "invalid_address"
+	CodeNotFound            = "not_found"
+	CodeMethodNotAllowed    = "method_not_allowed"
+	CodeUnauthorized        = "unauthorized"
+	CodeForbidden           = "forbidden"
+	CodeRateLimited         = "rate_limited"
+	CodeSubscriptionLimit   = "subscription_limit_exceeded"
+	CodeSubscriberNotFound  = "subscriber_not_found"
+	CodeTransactionNotFound = "transaction_not_found"
+	CodeWalletNotFound      = "wallet_not_found"
+	CodeTenantNotFound      = "tenant_not_found"
+	CodeAPIKeyNotFound      = "api_key_not_found"
+	CodeJobNotFound         = "job_not_found"
+	CodeJobRunning          = "job_running"
+	CodeJobPaused           = "job_paused"
+	CodeReparseTaskNotFound = "reparse_task_not_found"
+	CodeConfigRejected      = "config_rejected"
+	CodeBlockNotFound       = "block_not_found"
+	CodeBlockNotParsed      = "block_not_parsed"
+	CodeBalanceNotTracked   = "balance_not_tracked"
+	CodeNodeTimeout         = "node_timeout"
+	CodeNodeError           = "node_error"
+	CodeRequestTimeout      = "request_timeout"
+	CodeRequestCanceled     = "request_canceled"
+	CodeInternal            = "internal_error"
+)
+
+// Errors matched by *Error with errors.Is. Errors of statuses match every code of the status,
+// e.g. ErrNotFound matches ErrWalletNotFound.
+var (
+	ErrInvalidArgument = errors.New("invalid argument")
+	ErrUnauthorized    = errors.New("unauthorized")
+	ErrForbidden       = errors.New("forbidden")
+	ErrNotFound        = errors.New("not found")
+	ErrConflict        = errors.New("conflict")
+	ErrRateLimited     = errors.New("rate limited")
+	// ErrUnavailable is the temporary error of the server or the node, the request can be retried later
+	ErrUnavailable = errors.New("unavailable")
+
+	ErrInvalidAddress      = errors.New("invalid address")
+	ErrSubscriptionLimit   = errors.New("subscription limit of the tenant is exceeded")
+	ErrSubscriberNotFound  = errors.New("subscriber not found")
+	ErrTransactionNotFound = errors.New("transaction not found")
+	ErrWalletNotFound      = errors.New("wallet not found")
+	ErrTenantNotFound      = errors.New("tenant not found")
+	ErrAPIKeyNotFound      = errors.New("api key not found")
+	ErrJobNotFound         = errors.New("job not found")
+	ErrJobRunning          = errors.New("job is running")
+	ErrJobPaused           = errors.New("job is paused")
+	ErrReparseTaskNotFound = errors.New("reparse task not found")
+	ErrConfigRejected      = errors.New("config is rejected")
+	ErrBlockNotFound       = errors.New("block not found")
+	ErrBlockNotParsed      = errors.New("block is not parsed yet")
+	ErrBalanceNotTracked   = errors.New("balance of the address is not tracked")
+	ErrNodeTimeout         = errors.New("node doesn't respond")
+	ErrNodeError           = errors.New("node responded with error")
+	ErrRequestTimeout      = errors.New("request isn't processed in time")
+)
+
+var codeErrors = map[string]error{
+	CodeInvalidAddress:      ErrInvalidAddress,
+	CodeSubscriptionLimit:   ErrSubscriptionLimit,
+	CodeSubscriberNotFound:  ErrSubscriberNotFound,
+	CodeTransactionNotFound: ErrTransactionNotFound,
+	CodeWalletNotFound:      ErrWalletNotFound,
+	CodeTenantNotFound:      ErrTenantNotFound,
+	CodeAPIKeyNotFound:      ErrAPIKeyNotFound,
+	CodeJobNotFound:         ErrJobNotFound,
+	CodeJobRunning:          ErrJobRunning,
+	CodeJobPaused:           ErrJobPaused,
+	CodeReparseTaskNotFound: ErrReparseTaskNotFound,
+	CodeConfigRejected:      ErrConfigRejected,
+	CodeBlockNotFound:       ErrBlockNotFound,
+	CodeBlockNotParsed:      ErrBlockNotParsed,
+	CodeBalanceNotTracked:   ErrBalanceNotTracked,
+	CodeNodeTimeout:         ErrNodeTimeout,
+	CodeNodeError:           ErrNodeError,
+	CodeRequestTimeout:      ErrRequestTimeout,
+}
+
+var statusErrors = map[int]error{
+	http.StatusBadRequest:          ErrInvalidArgument,
+	http.StatusUnauthorized:        ErrUnauthorized,
+	http.StatusForbidden:           ErrForbidden,
+	http.StatusNotFound:            ErrNotFound,
+	http.StatusConflict:            ErrConflict,
+	http.StatusTooManyRequests:     ErrRateLimited,
+	http.StatusBadGateway:          ErrUnavailable,
+	http.StatusServiceUnavailable:  ErrUnavailable,
+	http.StatusGatewayTimeout:      ErrUnavailable,
+	http.StatusUnprocessableEntity: ErrInvalidArgument,
+}
+
+// Error is the error response of the API. Code is empty if the response isn't the API error,
+// e.g. of a proxy, then the message is the body of the response.
+type Error struct {
+	StatusCode int               `json:"-"`
+	Code       string            `json:"code"`
+	Message    string            `json:"message"`
+	Details    map[string]string `json:"details,omitempty"`
+	RequestID  string            `json:"requestId,omitempty"`
+	// RetryAfter is the delay of Retry-After header of rate limited response
+	RetryAfter time.Duration `json:"-"`
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("api error %d", e.StatusCode)
+	if e.Code != "" {
+		msg += " " + e.Code
+	}
+	msg += ": " + e.Message
+	if argument, ok := e.Details["argument"]; ok {
+		msg += " (" + argument + ")"
+	}
+	if e.RequestID != "" {
+		msg += ", request id " + e.RequestID
+	}
+
+	return msg
+}
+
+// Is matches the error of the code and the error of the status
+func (e *Error) Is(target error) bool {
+	if err, ok := codeErrors[e.Code]; ok && err == target {
+		return true
+	}
+
+	err, ok := statusErrors[e.StatusCode]
+
+	return ok && err == target
+}
+
+// HasCode reports whether the error is the API error with the code
+func HasCode(err error, code string) bool {
+	apiErr := &Error{}
+
+	return errors.As(err, &apiErr) && apiErr.Code == code
+}
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+const healthStatusOK = "ok"
+
+// HealthReport is the result of health checks, the status is ok when every check is ok
+type HealthReport struct {
+	Status string        `json:"status"`
+	Checks []HealthCheck `json:"checks"`
+}
+
+type HealthCheck struct {
+	Name    string            `json:"name"`
+	Status  string            `json:"status"`
+	Message string            `json:"message,omitempty"`
+	Details map[string]string `json:"details,omitempty"`
+}
+
+// OK reports whether every check is ok
+func (r HealthReport) OK() bool {
+	return r.Status == healthStatusOK
+}
+
+// GetLiveness returns the report of /healthz
+func (c *Client) GetLiveness(ctx context.Context) (HealthReport, error) {
+	return c.healthReport(ctx, "GetLiveness", "/healthz")
+}
+
+// GetReadiness returns the report of /readyz, the failed report is returned without error, see HealthReport.OK
+func (c *Client) GetReadiness(ctx context.Context) (HealthReport, error) {
+	return c.healthReport(ctx, "GetReadiness", "/readyz")
+}
+
+func (c *Client) healthReport(ctx context.Context, method, path string) (HealthReport, error) {
+	// 503 is the failed report which isn't retried, the instance isn't ready until the cause is fixed
+	req := newRequest(http.MethodGet, path)
+	req.idempotent = false
+
+	resp, err := c.send(ctx, c.clnt, req)
+	if err != nil {
+		return HealthReport{}, fmt.Errorf("fail get health report in %s: %w", method, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusServiceUnavailable {
+		return HealthReport{}, fmt.Errorf("fail get health report in %s: %w", method, decodeError(resp))
+	}
+
+	report := HealthReport{}
+	if err := decodeResponse(req, resp, &report); err != nil {
+		return HealthReport{}, fmt.Errorf("fail get health report in %s: %w", method, err)
+	}
+
+	return report, nil
+}
@@ -0,0 +1,63 @@
+package client
+
+import "context"
+
+// TransactionIterator reads transactions page by page, the next page is requested when the previous one is read.
+//
+//	it := clnt.Transactions(address, client.TransactionFilter{Limit: 500})
+//	for it.Next(ctx) {
+//		txn := it.Transaction()
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type TransactionIterator struct {
+	fetch func(ctx context.Context, cursor string) (TransactionPage, error)
+
+	page   []Transaction
+	txn    Transaction
+	cursor string
+	last   bool
+	err    error
+}
+
+func newTransactionIterator(cursor string, fetch func(ctx context.Context, cursor string) (TransactionPage, error)) *TransactionIterator {
+	return &TransactionIterator{
+		fetch:  fetch,
+		cursor: cursor,
+	}
+}
+
+// Next moves to the next transaction, it returns false when transactions are over or the page isn't read
+func (it *TransactionIterator) Next(ctx context.Context) bool {
+	for len(it.page) == 0 {
+		if it.last || it.err != nil {
+			return false
+		}
+
+		page, err := it.fetch(ctx, it.cursor)
+		if err != nil {
+			it.err = err
+
+			return false
+		}
+
+		it.page = page.Transactions
+		it.cursor = page.NextCursor
+		it.last = page.NextCursor == ""
+	}
+
+	it.txn, it.page = it.page[0], it.page[1:]
+
+	return true
+}
+
+// Transaction returns the current transaction
+func (it *TransactionIterator) Transaction() Transaction {
+	return it.txn
+}
+
+// Err returns the error of the page request which stopped the iteration
+func (it *TransactionIterator) Err() error {
+	return it.err
+}
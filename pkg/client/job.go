@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Job is the background job with recent runs, durations are in time.Duration format
+type Job struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	Schedule   string   `json:"schedule"`
+	Jitter     string   `json:"jitter"`
+	Overlap    string   `json:"overlap"`
+	RunTimeout string   `json:"runTimeout,omitempty"`
+	Paused     bool     `json:"paused"`
+	Running    int      `json:"running"`
+	Skipped    uint64   `json:"skipped"`
+	NextRunAt  string   `json:"nextRunAt,omitempty"`
+	History    []JobRun `json:"history"`
+}
+
+type JobRun struct {
+	ID         uint64 `json:"id"`
+	Trigger    string `json:"trigger"`
+	Status     string `json:"status"`
+	QueuedAt   string `json:"queuedAt,omitempty"`
+	StartedAt  string `json:"startedAt,omitempty"`
+	FinishedAt string `json:"finishedAt,omitempty"`
+	Duration   string `json:"duration,omitempty"`
+	Error      string `json:"error,omitempty"`
+	Panic      string `json:"panic,omitempty"`
+}
+
+type jobsResponse struct {
+	Jobs []Job `json:"jobs"`
+}
+
+// GetJobs returns background jobs, it needs the admin key
+func (c *Client) GetJobs(ctx context.Context) ([]Job, error) {
+	resp := jobsResponse{}
+	if err := c.do(ctx, newRequest(http.MethodGet, "/v1/admin/jobs"), &resp); err != nil {
+		return nil, fmt.Errorf("fail get jobs in GetJobs: %w", err)
+	}
+
+	return resp.Jobs, nil
+}
+
+// GetJob returns the background job, it needs the admin key
+func (c *Client) GetJob(ctx context.Context, id string) (Job, error) {
+	job := Job{}
+	if err := c.do(ctx, newRequest(http.MethodGet, "/v1/admin/jobs/"+pathEscape(id)), &job); err != nil {
+		return Job{}, fmt.Errorf("fail get job (%s) in GetJob: %w", id, err)
+	}
+
+	return job, nil
+}
+
+// TriggerJob runs the job out of its schedule, the queued run is returned. It needs the admin key.
+func (c *Client) TriggerJob(ctx context.Context, id string) (JobRun, error) {
+	run := JobRun{}
+	if err := c.do(ctx, newRequest(http.MethodPost, "/v1/admin/jobs/"+pathEscape(id)+"/runs"), &run); err != nil {
+		return JobRun{}, fmt.Errorf("fail trigger job (%s) in TriggerJob: %w", id, err)
+	}
+
+	return run, nil
+}
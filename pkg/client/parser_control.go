@@ -0,0 +1,52 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// ParserState is the count of parser workers and runs in progress
+type ParserState struct {
+	Workers int  `json:"workers"`
+	Paused  bool `json:"paused"`
+	Running int  `json:"running"`
+}
+
+type workersUpdate struct {
+	Count int `json:"count"`
+}
+
+// GetParserState returns the state of parser workers, it needs the admin key
+func (c *Client) GetParserState(ctx context.Context) (ParserState, error) {
+	return c.parserState(ctx, newRequest(http.MethodGet, "/v1/admin/parser"), "fail get parser state in GetParserState")
+}
+
+// ScaleWorkers sets the count of parser workers, it needs the admin key
+func (c *Client) ScaleWorkers(ctx context.Context, count int) (ParserState, error) {
+	req := newRequest(http.MethodPut, "/v1/admin/parser/workers").withBody(workersUpdate{Count: count})
+
+	return c.parserState(ctx, req, fmt.Sprintf("fail scale parser workers to %d in ScaleWorkers", count))
+}
+
+// PauseParsing stops starting new runs of parser workers, it needs the admin key
+func (c *Client) PauseParsing(ctx context.Context) (ParserState, error) {
+	return c.parserState(ctx, newRequest(http.MethodPost, "/v1/admin/parser/pause"), "fail pause parsing in PauseParsing")
+}
+
+// ResumeParsing resumes paused parser workers, it needs the admin key
+func (c *Client) ResumeParsing(ctx context.Context) (ParserState, error) {
+	return c.parserState(ctx, newRequest(http.MethodPost, "/v1/admin/parser/resume"), "fail resume parsing in ResumeParsing")
+}
+
+func (c *Client) parserState(ctx context.Context, req request, failMsg string) (ParserState, error) {
+	// pausing and resuming twice has the same result, so the requests are retried
+	req.idempotent = true
+
+	state := ParserState{}
+	if err := c.do(ctx, req, &state); err != nil {
+		return ParserState{}, fmt.Errorf("%s: %w", failMsg, err)
+	}
+
+	return state, nil
+}
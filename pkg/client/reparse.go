@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// ReparseTask parses the range of blocks again for the addresses, block numbers are hex
+type ReparseTask struct {
+	ID                  string   `json:"id"`
+	From                string   `json:"from"`
+	To                  string   `json:"to"`
+	Addresses           []string `json:"addresses"`
+	Status              string   `json:"status"`
+	NextBlock           string   `json:"nextBlock"`
+	ParsedBlocks        uint64   `json:"parsedBlocks"`
+	TotalBlocks         uint64   `json:"totalBlocks"`
+	MatchedTransactions int      `json:"matchedTransactions"`
+	Error               string   `json:"error,omitempty"`
+	CreatedAt           string   `json:"createdAt"`
+	StartedAt           string   `json:"startedAt,omitempty"`
+	FinishedAt          string   `json:"finishedAt,omitempty"`
+}
+
+// ReparseCreate is the range of blocks to parse again, block numbers are hex or decimal
+type ReparseCreate struct {
+	From      string   `json:"from"`
+	To        string   `json:"to"`
+	Addresses []string `json:"addresses"`
+}
+
+type reparseTasksResponse struct {
+	Tasks []ReparseTask `json:"tasks"`
+}
+
+// CreateReparseTask queues the reparse task, it needs the admin key
+func (c *Client) CreateReparseTask(ctx context.Context, create ReparseCreate) (ReparseTask, error) {
+	task := ReparseTask{}
+	if err := c.do(ctx, newRequest(http.MethodPost, "/v1/admin/reparse").withBody(create), &task); err != nil {
+		return ReparseTask{}, fmt.Errorf("fail create reparse task in CreateReparseTask: %w", err)
+	}
+
+	return task, nil
+}
+
+// GetReparseTasks returns reparse tasks, it needs the admin key
+func (c *Client) GetReparseTasks(ctx context.Context) ([]ReparseTask, error) {
+	resp := reparseTasksResponse{}
+	if err := c.do(ctx, newRequest(http.MethodGet, "/v1/admin/reparse"), &resp); err != nil {
+		return nil, fmt.Errorf("fail get reparse tasks in GetReparseTasks: %w", err)
+	}
+
+	return resp.Tasks, nil
+}
+
+// GetReparseTask returns the reparse task, it needs the admin key
+func (c *Client) GetReparseTask(ctx context.Context, id string) (ReparseTask, error) {
+	task := ReparseTask{}
+	if err := c.do(ctx, newRequest(http.MethodGet, "/v1/admin/reparse/"+pathEscape(id)), &task); err != nil {
+		return ReparseTask{}, fmt.Errorf("fail get reparse task (%s) in GetReparseTask: %w", id, err)
+	}
+
+	return task, nil
+}
+
+// CancelReparseTask cancels the queued or running task, finished task is returned as is. It needs the admin key.
+func (c *Client) CancelReparseTask(ctx context.Context, id string) (ReparseTask, error) {
+	task := ReparseTask{}
+	if err := c.do(ctx, newRequest(http.MethodDelete, "/v1/admin/reparse/"+pathEscape(id)), &task); err != nil {
+		return ReparseTask{}, fmt.Errorf("fail cancel reparse task (%s) in CancelReparseTask: %w", id, err)
+	}
+
+	return task, nil
+}
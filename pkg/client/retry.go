@@ -0,0 +1,82 @@
+package client
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const retryAfterHeader = "Retry-After"
+
+// retryPolicy decides whether the attempt is retried and how long to wait before the retry
+type retryPolicy struct {
+	maxRetries int
+	backoff    time.Duration
+	maxBackoff time.Duration
+}
+
+func newRetryPolicy(cfg Config) retryPolicy {
+	policy := retryPolicy{
+		maxRetries: cfg.MaxRetries,
+		backoff:    cfg.RetryBackoff,
+		maxBackoff: cfg.MaxRetryBackoff,
+	}
+	if policy.maxRetries == 0 {
+		policy.maxRetries = defaultMaxRetries
+	}
+	if policy.backoff <= 0 {
+		policy.backoff = defaultRetryBackoff
+	}
+	if policy.maxBackoff <= 0 {
+		policy.maxBackoff = defaultMaxRetryBackoff
+	}
+
+	return policy
+}
+
+// delay returns the delay before the retry of the attempt. Rate limited requests are retried after Retry-After,
+// idempotent requests are retried on network errors and on 502, 503 and 504 statuses as well.
+func (p retryPolicy) delay(attempt int, idempotent bool, resp *http.Response, err error) (time.Duration, bool) {
+	if attempt >= p.maxRetries {
+		return 0, false
+	}
+
+	if err != nil {
+		// the request may have been processed, only idempotent requests are sent again
+		return p.backoffDelay(attempt), idempotent
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		if retryAfter, ok := parseRetryAfter(resp.Header.Get(retryAfterHeader)); ok {
+			return retryAfter, true
+		}
+
+		return p.backoffDelay(attempt), true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return p.backoffDelay(attempt), idempotent
+	}
+
+	return 0, false
+}
+
+// backoffDelay doubles the backoff for every attempt, random half of the delay spreads retries of clients
+func (p retryPolicy) backoffDelay(attempt int) time.Duration {
+	delay := p.backoff << attempt
+	if delay > p.maxBackoff || delay <= 0 {
+		delay = p.maxBackoff
+	}
+
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// parseRetryAfter reads Retry-After in seconds, the API doesn't send HTTP dates
+func parseRetryAfter(value string) (time.Duration, bool) {
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+
+	return time.Duration(seconds) * time.Second, true
+}
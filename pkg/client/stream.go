@@ -0,0 +1,148 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const lastEventIDHeader = "Last-Event-ID"
+
+// ErrStreamClosed is returned by the stream closed by the server, e.g. on shutdown. The stream is opened again
+// with the ID of the last event to get missed events.
+var ErrStreamClosed = errors.New("stream is closed by the server")
+
+// Event of the stream. Transaction events are sent when the transaction is parsed, confirmation events
+// when the block of the transaction gets new confirmations.
+type Event struct {
+	ID            uint64      `json:"id"`
+	Type          string      `json:"type"`
+	Confirmations int         `json:"confirmations"`
+	Transaction   Transaction `json:"transaction"`
+}
+
+// StreamOptions resumes the stream after the event with LastEventID, zero starts from new events.
+// The unit of values is hex, wei or ether.
+type StreamOptions struct {
+	LastEventID uint64
+	Unit        string
+}
+
+// EventStream reads server-sent events of the stream, heartbeats are skipped.
+//
+//	stream, err := clnt.Stream(ctx, []string{address}, client.StreamOptions{})
+//	...
+//	defer stream.Close()
+//	for stream.Next() {
+//		event := stream.Event()
+//	}
+//	if err := stream.Err(); err != nil {
+//		...
+//	}
+type EventStream struct {
+	body   io.ReadCloser
+	reader *bufio.Reader
+	event  Event
+	err    error
+}
+
+// Stream opens the stream of events of the subscribed addresses, it lasts until the context is done or it's closed
+func (c *Client) Stream(ctx context.Context, addresses []string, opts StreamOptions) (*EventStream, error) {
+	if len(addresses) == 0 {
+		return nil, errors.New("address is required in Stream")
+	}
+
+	query := url.Values{}
+	if opts.Unit != "" {
+		query.Set("unit", opts.Unit)
+	}
+
+	req := newRequest(http.MethodGet, "/v1/addresses/"+pathEscape(strings.Join(addresses, ","))+"/stream").withQuery(query)
+	req.header = http.Header{"Accept": []string{"text/event-stream"}}
+	if opts.LastEventID > 0 {
+		req.header.Set(lastEventIDHeader, strconv.FormatUint(opts.LastEventID, 10))
+	}
+
+	resp, err := c.send(ctx, c.streamClnt, req)
+	if err != nil {
+		return nil, fmt.Errorf("fail open stream in Stream: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+
+		return nil, fmt.Errorf("fail open stream in Stream: %w", decodeError(resp))
+	}
+
+	return &EventStream{
+		body:   resp.Body,
+		reader: bufio.NewReader(resp.Body),
+	}, nil
+}
+
+// Next waits for the next event, it returns false when the stream is ended
+func (s *EventStream) Next() bool {
+	if s.err != nil {
+		return false
+	}
+
+	data := strings.Builder{}
+	for {
+		line, err := s.reader.ReadString('\n')
+		if errors.Is(err, io.EOF) {
+			s.err = ErrStreamClosed
+
+			return false
+		}
+		if err != nil {
+			s.err = fmt.Errorf("fail read stream: %w", err)
+
+			return false
+		}
+
+		line = strings.TrimRight(line, "\r\n")
+		switch {
+		case line == "":
+			// the blank line ends the event, heartbeats are comments without data
+			if data.Len() == 0 {
+				continue
+			}
+
+			event := Event{}
+			if err := json.Unmarshal([]byte(data.String()), &event); err != nil {
+				s.err = fmt.Errorf("fail decode event: %w", err)
+
+				return false
+			}
+			s.event = event
+
+			return true
+		case strings.HasPrefix(line, "data:"):
+			if data.Len() > 0 {
+				data.WriteString("\n")
+			}
+			data.WriteString(strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+}
+
+// Event returns the current event
+func (s *EventStream) Event() Event {
+	return s.event
+}
+
+// Err returns the error which ended the stream, ErrStreamClosed if the server closed it
+func (s *EventStream) Err() error {
+	return s.err
+}
+
+// Close ends the stream
+func (s *EventStream) Close() error {
+	return s.body.Close()
+}
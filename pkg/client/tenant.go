@@ -0,0 +1,90 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Tenant owns subscriptions, wallets and API keys. Zero cap of subscriptions is the server default.
+type Tenant struct {
+	ID               string `json:"id"`
+	Name             string `json:"name"`
+	MaxSubscriptions int    `json:"maxSubscriptions,omitempty"`
+	CreatedAt        string `json:"createdAt"`
+}
+
+type TenantCreate struct {
+	Name             string `json:"name"`
+	MaxSubscriptions int    `json:"maxSubscriptions"`
+}
+
+// APIKey of the tenant, the key itself is returned only when it's issued
+type APIKey struct {
+	ID        string `json:"id"`
+	TenantID  string `json:"tenantId"`
+	Key       string `json:"key,omitempty"`
+	CreatedAt string `json:"createdAt"`
+	RevokedAt string `json:"revokedAt,omitempty"`
+}
+
+type tenantsResponse struct {
+	Tenants []Tenant `json:"tenants"`
+}
+
+type apiKeysResponse struct {
+	Keys []APIKey `json:"keys"`
+}
+
+// CreateTenant creates the tenant, it needs the admin key
+func (c *Client) CreateTenant(ctx context.Context, create TenantCreate) (Tenant, error) {
+	tenant := Tenant{}
+	if err := c.do(ctx, newRequest(http.MethodPost, "/v1/admin/tenants").withBody(create), &tenant); err != nil {
+		return Tenant{}, fmt.Errorf("fail create tenant in CreateTenant: %w", err)
+	}
+
+	return tenant, nil
+}
+
+// GetTenants returns tenants, it needs the admin key
+func (c *Client) GetTenants(ctx context.Context) ([]Tenant, error) {
+	resp := tenantsResponse{}
+	if err := c.do(ctx, newRequest(http.MethodGet, "/v1/admin/tenants"), &resp); err != nil {
+		return nil, fmt.Errorf("fail get tenants in GetTenants: %w", err)
+	}
+
+	return resp.Tenants, nil
+}
+
+// IssueAPIKey issues the key of the tenant, it needs the admin key
+func (c *Client) IssueAPIKey(ctx context.Context, tenantID string) (APIKey, error) {
+	key := APIKey{}
+	if err := c.do(ctx, newRequest(http.MethodPost, apiKeysPath(tenantID)), &key); err != nil {
+		return APIKey{}, fmt.Errorf("fail issue api key of tenant (%s) in IssueAPIKey: %w", tenantID, err)
+	}
+
+	return key, nil
+}
+
+// GetAPIKeys returns keys of the tenant without the keys themselves, it needs the admin key
+func (c *Client) GetAPIKeys(ctx context.Context, tenantID string) ([]APIKey, error) {
+	resp := apiKeysResponse{}
+	if err := c.do(ctx, newRequest(http.MethodGet, apiKeysPath(tenantID)), &resp); err != nil {
+		return nil, fmt.Errorf("fail get api keys of tenant (%s) in GetAPIKeys: %w", tenantID, err)
+	}
+
+	return resp.Keys, nil
+}
+
+// RevokeAPIKey revokes the key of the tenant, it needs the admin key
+func (c *Client) RevokeAPIKey(ctx context.Context, tenantID, keyID string) error {
+	if err := c.do(ctx, newRequest(http.MethodDelete, apiKeysPath(tenantID)+"/"+pathEscape(keyID)), nil); err != nil {
+		return fmt.Errorf("fail revoke api key (%s) of tenant (%s) in RevokeAPIKey: %w", keyID, tenantID, err)
+	}
+
+	return nil
+}
+
+func apiKeysPath(tenantID string) string {
+	return "/v1/admin/tenants/" + pathEscape(tenantID) + "/keys"
+}
@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// TransactionQuery is the filter of transactions of several subscribed addresses, empty fields aren't sent
+type TransactionQuery struct {
+	Addresses []string `json:"addresses"`
+	Limit     int      `json:"limit,omitempty"`
+	Cursor    string   `json:"cursor,omitempty"`
+	Order     string   `json:"order,omitempty"`
+	FromBlock string   `json:"fromBlock,omitempty"`
+	ToBlock   string   `json:"toBlock,omitempty"`
+	FromTime  string   `json:"fromTime,omitempty"`
+	ToTime    string   `json:"toTime,omitempty"`
+	Direction string   `json:"direction,omitempty"`
+	MinValue  string   `json:"minValue,omitempty"`
+	Unit      string   `json:"unit,omitempty"`
+}
+
+// TransactionLookup tells whether the transaction is stored, the reason explains why it isn't
+type TransactionLookup struct {
+	Indexed     bool        `json:"indexed"`
+	Reason      string      `json:"reason"`
+	Explanation string      `json:"explanation"`
+	Transaction Transaction `json:"transaction"`
+}
+
+// QueryTransactions returns the combined page of transactions of the subscribed addresses
+func (c *Client) QueryTransactions(ctx context.Context, query TransactionQuery) (TransactionPage, error) {
+	page := TransactionPage{}
+
+	// the query only reads transactions, so it's retried like GET requests
+	req := newRequest(http.MethodPost, "/v1/transactions/query").withBody(query)
+	req.idempotent = true
+
+	if err := c.do(ctx, req, &page); err != nil {
+		return TransactionPage{}, fmt.Errorf("fail query transactions in QueryTransactions: %w", err)
+	}
+
+	return page, nil
+}
+
+// QueryTransactionsIterator iterates over the combined transactions of the subscribed addresses from the cursor of the query
+func (c *Client) QueryTransactionsIterator(query TransactionQuery) *TransactionIterator {
+	return newTransactionIterator(query.Cursor, func(ctx context.Context, cursor string) (TransactionPage, error) {
+		query.Cursor = cursor
+
+		return c.QueryTransactions(ctx, query)
+	})
+}
+
+// GetTransaction looks the transaction up by hash, the unit is hex, wei or ether
+func (c *Client) GetTransaction(ctx context.Context, hash, unit string) (TransactionLookup, error) {
+	query := url.Values{}
+	if unit != "" {
+		query.Set("unit", unit)
+	}
+
+	lookup := TransactionLookup{}
+	req := newRequest(http.MethodGet, "/v1/transactions/"+pathEscape(hash)).withQuery(query)
+	if err := c.do(ctx, req, &lookup); err != nil {
+		return TransactionLookup{}, fmt.Errorf("fail get transaction (%s) in GetTransaction: %w", hash, err)
+	}
+
+	return lookup, nil
+}
@@ -0,0 +1,95 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Wallet groups addresses of one owner, the addresses are subscribed by the tenant of the wallet
+type Wallet struct {
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	Addresses []string `json:"addresses"`
+	CreatedAt string   `json:"createdAt"`
+	UpdatedAt string   `json:"updatedAt"`
+}
+
+// WalletSave is the wallet to create or the new state of the wallet
+type WalletSave struct {
+	Name      string   `json:"name"`
+	Addresses []string `json:"addresses"`
+}
+
+type walletsResponse struct {
+	Wallets []Wallet `json:"wallets"`
+}
+
+func (c *Client) CreateWallet(ctx context.Context, save WalletSave) (Wallet, error) {
+	wallet := Wallet{}
+	if err := c.do(ctx, newRequest(http.MethodPost, "/v1/wallets").withBody(save), &wallet); err != nil {
+		return Wallet{}, fmt.Errorf("fail create wallet in CreateWallet: %w", err)
+	}
+
+	return wallet, nil
+}
+
+func (c *Client) GetWallets(ctx context.Context) ([]Wallet, error) {
+	resp := walletsResponse{}
+	if err := c.do(ctx, newRequest(http.MethodGet, "/v1/wallets"), &resp); err != nil {
+		return nil, fmt.Errorf("fail get wallets in GetWallets: %w", err)
+	}
+
+	return resp.Wallets, nil
+}
+
+func (c *Client) GetWallet(ctx context.Context, id string) (Wallet, error) {
+	wallet := Wallet{}
+	if err := c.do(ctx, newRequest(http.MethodGet, walletPath(id)), &wallet); err != nil {
+		return Wallet{}, fmt.Errorf("fail get wallet (%s) in GetWallet: %w", id, err)
+	}
+
+	return wallet, nil
+}
+
+// UpdateWallet replaces the name and the addresses of the wallet
+func (c *Client) UpdateWallet(ctx context.Context, id string, save WalletSave) (Wallet, error) {
+	wallet := Wallet{}
+	if err := c.do(ctx, newRequest(http.MethodPut, walletPath(id)).withBody(save), &wallet); err != nil {
+		return Wallet{}, fmt.Errorf("fail update wallet (%s) in UpdateWallet: %w", id, err)
+	}
+
+	return wallet, nil
+}
+
+func (c *Client) DeleteWallet(ctx context.Context, id string) error {
+	if err := c.do(ctx, newRequest(http.MethodDelete, walletPath(id)), nil); err != nil {
+		return fmt.Errorf("fail delete wallet (%s) in DeleteWallet: %w", id, err)
+	}
+
+	return nil
+}
+
+// GetWalletTransactions returns the combined page of transactions of the wallet addresses
+func (c *Client) GetWalletTransactions(ctx context.Context, id string, filter TransactionFilter) (TransactionPage, error) {
+	page := TransactionPage{}
+	req := newRequest(http.MethodGet, walletPath(id)+"/transactions").withQuery(filter.query())
+	if err := c.do(ctx, req, &page); err != nil {
+		return TransactionPage{}, fmt.Errorf("fail get transactions of wallet (%s) in GetWalletTransactions: %w", id, err)
+	}
+
+	return page, nil
+}
+
+// WalletTransactions iterates over transactions of the wallet addresses from the cursor of the filter
+func (c *Client) WalletTransactions(id string, filter TransactionFilter) *TransactionIterator {
+	return newTransactionIterator(filter.Cursor, func(ctx context.Context, cursor string) (TransactionPage, error) {
+		filter.Cursor = cursor
+
+		return c.GetWalletTransactions(ctx, id, filter)
+	})
+}
+
+func walletPath(id string) string {
+	return "/v1/wallets/" + pathEscape(id)
+}
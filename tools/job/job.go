@@ -2,72 +2,523 @@ package job
 
 import (
 	"context"
-	"log"
+	"errors"
+	"fmt"
+	"math/rand"
 	"runtime/debug"
+	"sync"
 	"time"
+
+	"blockchain-parser/tools/logger"
+)
+
+const (
+	// DefaultHistorySize is the count of kept runs when the policy doesn't set it
+	DefaultHistorySize = 20
+)
+
+var (
+	ErrNotFound   = errors.New("job not found")
+	ErrRunning    = errors.New("job is running")
+	ErrNotStarted = errors.New("job isn't started")
+	ErrStopped    = errors.New("job is stopped")
+	ErrPaused     = errors.New("job is paused")
+)
+
+// Observer is called after every run of the job, e.g. to collect run durations
+type Observer func(name string, duration time.Duration, err error)
+
+// OverlapPolicy decides what happens when the run is due while the previous run is still running
+type OverlapPolicy string
+
+const (
+	// OverlapSkip drops the run
+	OverlapSkip OverlapPolicy = "skip"
+	// OverlapQueue runs it after the previous run, at most one run is queued
+	OverlapQueue OverlapPolicy = "queue"
+	// OverlapAllow runs it concurrently
+	OverlapAllow OverlapPolicy = "allow"
+)
+
+func ParseOverlapPolicy(value string) (OverlapPolicy, error) {
+	switch policy := OverlapPolicy(value); policy {
+	case OverlapSkip, OverlapQueue, OverlapAllow:
+		return policy, nil
+	}
+
+	return "", fmt.Errorf("unknown overlap policy %q, use skip, queue or allow", value)
+}
+
+// Policy controls how runs are scheduled, zero policy runs exactly on schedule and skips overlapping runs
+type Policy struct {
+	// Jitter is the max random delay added to every scheduled run, it spreads runs of job copies
+	Jitter time.Duration
+	// Overlap is OverlapSkip when it isn't set
+	Overlap OverlapPolicy
+	// Timeout cancels the context of the run, zero means no timeout
+	Timeout time.Duration
+	// HistorySize is the count of kept runs, 20 when it isn't set
+	HistorySize int
+}
+
+type Trigger string
+
+const (
+	TriggerSchedule Trigger = "schedule"
+	TriggerManual   Trigger = "manual"
 )
 
+type RunStatus string
+
+const (
+	RunStatusQueued      RunStatus = "queued"
+	RunStatusRunning     RunStatus = "running"
+	RunStatusSucceeded   RunStatus = "succeeded"
+	RunStatusFailed      RunStatus = "failed"
+	RunStatusPanicked    RunStatus = "panicked"
+	RunStatusInterrupted RunStatus = "interrupted"
+)
+
+// Run is the record of the run in the history
+type Run struct {
+	ID         uint64
+	Trigger    Trigger
+	Status     RunStatus
+	QueuedAt   time.Time
+	StartedAt  time.Time
+	FinishedAt time.Time
+	Error      string
+	Panic      string
+}
+
+// Status is the snapshot of the job, the history is ordered from the newest run
+type Status struct {
+	ID        string
+	Name      string
+	Schedule  string
+	Policy    Policy
+	Paused    bool
+	Running   int
+	Skipped   uint64
+	NextRunAt time.Time
+	History   []Run
+}
+
 type Job struct {
 	run      func(ctx context.Context) error
+	id       string
 	name     string
-	interval time.Duration
+	schedule Schedule
+	policy   Policy
+	observer Observer
+	log      logger.Logger
 
-	stop chan struct{}
+	ctx        context.Context
+	reschedule chan struct{}
+	stop       chan struct{}
+	done       chan struct{}
+	cancel     context.CancelFunc
+	stopOnce   sync.Once
+	runs       sync.WaitGroup
+
+	mu        sync.Mutex
+	stopped   bool
+	paused    bool
+	running   int
+	queued    *Run
+	skipped   uint64
+	lastRunID uint64
+	nextRunAt time.Time
+	history   []Run
 }
 
+// NewJob creates the job, the logger is passed to run in the context, so it can carry fields like worker ID
 func NewJob(
 	run func(ctx context.Context) error,
 	name string,
-	interval time.Duration,
+	schedule Schedule,
+	policy Policy,
+	log logger.Logger,
 ) *Job {
 	return &Job{
-		run:      run,
-		name:     name,
-		interval: interval,
-		stop:     make(chan struct{}),
+		run:        run,
+		id:         name,
+		name:       name,
+		schedule:   schedule,
+		policy:     normalizePolicy(policy),
+		log:        log.With(logger.String("job", name)),
+		reschedule: make(chan struct{}, 1),
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
 	}
 }
 
+func normalizePolicy(policy Policy) Policy {
+	if policy.Overlap == "" {
+		policy.Overlap = OverlapSkip
+	}
+	if policy.HistorySize <= 0 {
+		policy.HistorySize = DefaultHistorySize
+	}
+
+	return policy
+}
+
+// ID is unique among jobs, copies of the job get it from Jobs.Add
+func (j *Job) ID() string {
+	return j.id
+}
+
+// Start runs the job by the schedule until Stop. The context of runs is cancelled by Stop.
 func (j *Job) Start(ctx context.Context) {
-	ticker := time.NewTicker(j.interval)
+	ctx, cancel := context.WithCancel(logger.NewContext(ctx, j.log))
+
+	j.mu.Lock()
+	j.ctx, j.cancel = ctx, cancel
+	schedule := j.schedule
+	j.mu.Unlock()
+
+	timer := time.NewTimer(j.scheduleNext(time.Now()))
 
 	go func() {
-		log.Printf("job %s started", j.name)
+		defer close(j.done)
+		defer j.runs.Wait()
+		defer timer.Stop()
+
+		j.log.Info("job started", logger.String("schedule", schedule.String()))
 
 		for {
+			// stop wins over the timer when both are ready
 			select {
 			case <-j.stop:
-				break
+				return
 			default:
 			}
 
 			select {
-			case <-ticker.C:
-				func() {
-					defer func() {
-						if r := recover(); r != nil {
-							log.Println("stacktrace from panic: \n" + string(debug.Stack()))
-						}
-
-						log.Printf("run %s", j.name)
-
-						if err := j.run(ctx); err != nil {
-							log.Printf("fail run job %s: %s", j.name, err)
-						}
-
-						log.Printf("finish %s", j.name)
-					}()
-				}()
+			case <-timer.C:
+				j.mu.Lock()
+				_, err := j.dispatchLocked(TriggerSchedule)
+				switch {
+				case errors.Is(err, ErrRunning):
+					j.log.Debug("job run is skipped, previous run is still running")
+				case errors.Is(err, ErrPaused):
+					j.log.Debug("job run is skipped, job is paused")
+				}
+				j.mu.Unlock()
+
+				timer.Reset(j.scheduleNext(time.Now()))
+			case <-j.reschedule:
+				if !timer.Stop() {
+					<-timer.C
+				}
+				timer.Reset(j.scheduleNext(time.Now()))
 			case <-j.stop:
-				break
+				return
 			}
 		}
 	}()
 }
 
-func (j *Job) Stop() {
-	j.stop <- struct{}{}
-	close(j.stop)
+// Trigger runs the job out of schedule by the overlap policy. ErrRunning is returned when the run is skipped,
+// ErrPaused when the job is paused.
+func (j *Job) Trigger() (Run, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.ctx == nil {
+		return Run{}, ErrNotStarted
+	}
+
+	run, err := j.dispatchLocked(TriggerManual)
+	if err != nil {
+		return Run{}, err
+	}
+
+	j.log.Info("job run is triggered manually", logger.Uint64("run_id", run.ID))
+
+	return run, nil
+}
+
+// Pause stops starting new runs, current runs aren't interrupted and finish their work.
+// The queued run is dropped. The job can be paused before it's started.
+func (j *Job) Pause() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.paused {
+		return
+	}
+	j.paused = true
+
+	if j.queued != nil {
+		queued := *j.queued
+		j.queued = nil
+		queued.Status = RunStatusInterrupted
+		queued.FinishedAt = time.Now()
+		j.updateHistoryLocked(queued)
+	}
+
+	j.log.Info("job paused")
+}
+
+// Resume starts scheduled runs again, the next run is at the next scheduled time
+func (j *Job) Resume() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if !j.paused {
+		return
+	}
+	j.paused = false
+
+	j.log.Info("job resumed")
+}
+
+// Reschedule replaces the schedule and the policy, the next run is scheduled by the new schedule from now.
+// Current runs keep their timeout.
+func (j *Job) Reschedule(schedule Schedule, policy Policy) {
+	j.mu.Lock()
+	j.schedule = schedule
+	j.policy = normalizePolicy(policy)
+	j.mu.Unlock()
+
+	select {
+	case j.reschedule <- struct{}{}:
+	default:
+		// the pending reschedule reads the latest schedule
+	}
+
+	j.log.Info("job rescheduled", logger.String("schedule", schedule.String()))
+}
+
+// Observe sets the observer of runs, it must be set before the job is started
+func (j *Job) Observe(observer Observer) {
+	j.observer = observer
+}
+
+func (j *Job) Status() Status {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	history := make([]Run, 0, len(j.history))
+	for i := len(j.history) - 1; i >= 0; i-- {
+		history = append(history, j.history[i])
+	}
+
+	return Status{
+		ID:        j.id,
+		Name:      j.name,
+		Schedule:  j.schedule.String(),
+		Policy:    j.policy,
+		Paused:    j.paused,
+		Running:   j.running,
+		Skipped:   j.skipped,
+		NextRunAt: j.nextRunAt,
+		History:   history,
+	}
+}
 
-	log.Printf("job %s stopped", j.name)
+// Stop stops scheduling of runs, cancels the context of current runs and waits until they return.
+// The error is returned when runs don't return before the context is done.
+func (j *Job) Stop(ctx context.Context) error {
+	j.mu.Lock()
+	cancel := j.cancel
+	j.stopped = true
+	j.mu.Unlock()
+
+	if cancel == nil {
+		return nil
+	}
+
+	j.stopOnce.Do(func() {
+		close(j.stop)
+		cancel()
+	})
+
+	select {
+	case <-j.done:
+		j.log.Info("job stopped")
+
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("job %s isn't stopped: %w", j.id, ctx.Err())
+	}
+}
+
+// scheduleNext returns the delay of the next scheduled run with the jitter
+func (j *Job) scheduleNext(now time.Time) time.Duration {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	next := j.schedule.Next(now)
+	if next.IsZero() {
+		// the schedule has no more runs, the timer never fires
+		next = now.Add(100 * 365 * 24 * time.Hour)
+	}
+	if j.policy.Jitter > 0 {
+		next = next.Add(time.Duration(rand.Int63n(int64(j.policy.Jitter))))
+	}
+	j.nextRunAt = next
+
+	return next.Sub(now)
+}
+
+func (j *Job) dispatchLocked(trigger Trigger) (Run, error) {
+	if j.stopped {
+		return Run{}, ErrStopped
+	}
+	if j.paused {
+		return Run{}, ErrPaused
+	}
+
+	if j.running > 0 {
+		switch j.policy.Overlap {
+		case OverlapQueue:
+			// the queued run covers all runs requested while the current one is running
+			if j.queued != nil {
+				return *j.queued, nil
+			}
+
+			run := j.newRunLocked(trigger)
+			run.Status = RunStatusQueued
+			run.QueuedAt = time.Now()
+			j.queued = &run
+			j.appendHistoryLocked(run)
+
+			return run, nil
+		case OverlapAllow:
+		default:
+			j.skipped++
+
+			return Run{}, ErrRunning
+		}
+	}
+
+	return j.startLocked(j.newRunLocked(trigger)), nil
+}
+
+func (j *Job) newRunLocked(trigger Trigger) Run {
+	j.lastRunID++
+
+	return Run{
+		ID:      j.lastRunID,
+		Trigger: trigger,
+	}
+}
+
+func (j *Job) startLocked(run Run) Run {
+	run.Status = RunStatusRunning
+	run.StartedAt = time.Now()
+	if run.QueuedAt.IsZero() {
+		j.appendHistoryLocked(run)
+	} else {
+		j.updateHistoryLocked(run)
+	}
+
+	j.running++
+	j.runs.Add(1)
+
+	go j.execute(j.ctx, run, j.policy.Timeout)
+
+	return run
+}
+
+func (j *Job) execute(ctx context.Context, run Run, timeout time.Duration) {
+	defer j.runs.Done()
+
+	log := j.log.With(logger.Uint64("run_id", run.ID))
+	runCtx := logger.NewContext(ctx, log)
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(runCtx, timeout)
+		defer cancel()
+	}
+
+	log.Debug("run job", logger.String("trigger", string(run.Trigger)))
+
+	panicValue, err := j.call(runCtx)
+
+	run.FinishedAt = time.Now()
+	duration := run.FinishedAt.Sub(run.StartedAt)
+
+	switch {
+	case panicValue != "":
+		run.Status = RunStatusPanicked
+		run.Panic = panicValue
+		err = fmt.Errorf("job panicked: %s", panicValue)
+	case err != nil && ctx.Err() != nil:
+		// the run is interrupted by Stop, it isn't a failure of the job
+		log.Info("job run interrupted", logger.Err(err))
+		run.Status = RunStatusInterrupted
+		run.Error = err.Error()
+		err = nil
+	case err != nil:
+		if errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+			err = fmt.Errorf("job run timed out after %s: %w", timeout, err)
+		}
+		log.Error("fail run job", logger.Err(err))
+		run.Status = RunStatusFailed
+		run.Error = err.Error()
+	default:
+		run.Status = RunStatusSucceeded
+	}
+
+	if j.observer != nil {
+		j.observer(j.name, duration, err)
+	}
+
+	log.Debug("finish job", logger.Duration("duration", duration))
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.updateHistoryLocked(run)
+	j.running--
+
+	if j.queued != nil && j.running == 0 {
+		queued := *j.queued
+		j.queued = nil
+
+		if j.stopped {
+			queued.Status = RunStatusInterrupted
+			queued.FinishedAt = time.Now()
+			j.updateHistoryLocked(queued)
+
+			return
+		}
+
+		j.startLocked(queued)
+	}
+}
+
+// call runs the job and recovers its panic, the panic is returned with the stacktrace
+func (j *Job) call(ctx context.Context) (panicValue string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			stack := string(debug.Stack())
+			logger.FromContext(ctx, j.log).Error("job panicked", logger.Any("panic", r), logger.String("stacktrace", stack))
+			panicValue = fmt.Sprint(r)
+		}
+	}()
+
+	return "", j.run(ctx)
+}
+
+func (j *Job) appendHistoryLocked(run Run) {
+	j.history = append(j.history, run)
+	if len(j.history) > j.policy.HistorySize {
+		j.history = append(j.history[:0], j.history[len(j.history)-j.policy.HistorySize:]...)
+	}
+}
+
+// updateHistoryLocked replaces the run in the history, the run is dropped when it's already evicted
+func (j *Job) updateHistoryLocked(run Run) {
+	for i := len(j.history) - 1; i >= 0; i-- {
+		if j.history[i].ID == run.ID {
+			j.history[i] = run
+
+			return
+		}
+	}
 }
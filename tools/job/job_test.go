@@ -0,0 +1,298 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"blockchain-parser/tools/logger"
+)
+
+func TestJob_Stop(t *testing.T) {
+	tests := []struct {
+		name    string
+		run     func(ctx context.Context) error
+		wantErr error
+	}{
+		{
+			name: "run is cancelled",
+			run: func(ctx context.Context) error {
+				<-ctx.Done()
+
+				return ctx.Err()
+			},
+			wantErr: nil,
+		},
+		{
+			name: "run ignores cancellation",
+			run: func(ctx context.Context) error {
+				time.Sleep(time.Second)
+
+				return nil
+			},
+			wantErr: context.DeadlineExceeded,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			started := make(chan struct{})
+			var observedErr error
+			j := NewJob(func(ctx context.Context) error {
+				close(started)
+
+				return tt.run(ctx)
+			}, "test", Every(time.Millisecond), Policy{}, logger.Nop())
+			j.Observe(func(_ string, _ time.Duration, err error) {
+				observedErr = err
+			})
+
+			j.Start(context.Background())
+			<-started
+
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+
+			if err := j.Stop(ctx); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Stop() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && observedErr != nil {
+				t.Errorf("observed error = %v, want nil for interrupted run", observedErr)
+			}
+		})
+	}
+}
+
+func TestJob_Trigger(t *testing.T) {
+	tests := []struct {
+		name        string
+		overlap     OverlapPolicy
+		wantErr     error
+		wantStatus  RunStatus
+		wantHistory []RunStatus
+	}{
+		{
+			name:        "skip",
+			overlap:     OverlapSkip,
+			wantErr:     ErrRunning,
+			wantHistory: []RunStatus{RunStatusSucceeded},
+		},
+		{
+			name:        "queue",
+			overlap:     OverlapQueue,
+			wantStatus:  RunStatusQueued,
+			wantHistory: []RunStatus{RunStatusSucceeded, RunStatusSucceeded},
+		},
+		{
+			name:        "allow",
+			overlap:     OverlapAllow,
+			wantStatus:  RunStatusRunning,
+			wantHistory: []RunStatus{RunStatusSucceeded, RunStatusSucceeded},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			release := make(chan struct{})
+			started := make(chan struct{}, 2)
+			j := NewJob(func(ctx context.Context) error {
+				started <- struct{}{}
+				<-release
+
+				return nil
+			}, "test", Every(time.Hour), Policy{Overlap: tt.overlap}, logger.Nop())
+
+			j.Start(context.Background())
+			defer func() {
+				_ = j.Stop(context.Background())
+			}()
+
+			if _, err := j.Trigger(); err != nil {
+				t.Fatalf("Trigger() error = %v", err)
+			}
+			<-started
+
+			run, err := j.Trigger()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Trigger() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if run.Status != tt.wantStatus {
+				t.Errorf("Trigger() status = %v, want %v", run.Status, tt.wantStatus)
+			}
+
+			close(release)
+			for i := 1; i < len(tt.wantHistory); i++ {
+				<-started
+			}
+
+			// runs finish after the job function returns
+			var got []RunStatus
+			for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+				status := j.Status()
+				got = got[:0]
+				for _, run := range status.History {
+					got = append(got, run.Status)
+				}
+				if status.Running == 0 && reflect.DeepEqual(got, tt.wantHistory) {
+					break
+				}
+			}
+			if !reflect.DeepEqual(got, tt.wantHistory) {
+				t.Errorf("history = %v, want %v", got, tt.wantHistory)
+			}
+		})
+	}
+}
+
+func TestJob_History(t *testing.T) {
+	runs := 0
+	started := make(chan struct{})
+	j := NewJob(func(ctx context.Context) error {
+		runs++
+		switch runs {
+		case 1:
+			return errors.New("node timeout")
+		case 2:
+			panic("nil block")
+		case 3:
+			<-ctx.Done()
+
+			return ctx.Err()
+		}
+
+		close(started)
+		<-ctx.Done()
+
+		return ctx.Err()
+	}, "test", Every(time.Hour), Policy{Timeout: 100 * time.Millisecond, HistorySize: 3}, logger.Nop())
+
+	var observed []error
+	j.Observe(func(_ string, _ time.Duration, err error) {
+		observed = append(observed, err)
+	})
+
+	j.Start(context.Background())
+	for i := 0; i < 3; i++ {
+		if _, err := j.Trigger(); err != nil {
+			t.Fatalf("Trigger() error = %v", err)
+		}
+		for j.Status().Running > 0 {
+			time.Sleep(time.Millisecond)
+		}
+	}
+	if _, err := j.Trigger(); err != nil {
+		t.Fatalf("Trigger() error = %v", err)
+	}
+	<-started
+	if err := j.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+
+	status := j.Status()
+	got := make([]RunStatus, 0, len(status.History))
+	for _, run := range status.History {
+		got = append(got, run.Status)
+	}
+
+	// the first run is evicted, the third one times out and the fourth one is interrupted by Stop
+	want := []RunStatus{RunStatusInterrupted, RunStatusFailed, RunStatusPanicked}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("history = %v, want %v", got, want)
+	}
+	if status.History[2].Panic != "nil block" {
+		t.Errorf("panic = %q, want %q", status.History[2].Panic, "nil block")
+	}
+	if !errors.Is(observed[2], context.DeadlineExceeded) {
+		t.Errorf("observed error of timed out run = %v, want %v", observed[2], context.DeadlineExceeded)
+	}
+	if observed[3] != nil {
+		t.Errorf("observed error of interrupted run = %v, want nil", observed[3])
+	}
+}
+
+func TestJob_Reschedule(t *testing.T) {
+	ran := make(chan struct{}, 1)
+	j := NewJob(func(ctx context.Context) error {
+		select {
+		case ran <- struct{}{}:
+		default:
+		}
+
+		return nil
+	}, "test", Every(time.Hour), Policy{}, logger.Nop())
+
+	j.Start(context.Background())
+	defer j.Stop(context.Background())
+
+	j.Reschedule(Every(10*time.Millisecond), Policy{Overlap: OverlapQueue})
+
+	select {
+	case <-ran:
+	case <-time.After(time.Second):
+		t.Fatalf("job isn't run by the new schedule")
+	}
+
+	status := j.Status()
+	if status.Schedule != "@every 10ms" || status.Policy.Overlap != OverlapQueue || status.Policy.HistorySize != DefaultHistorySize {
+		t.Errorf("Status() got schedule = %s, policy = %v", status.Schedule, status.Policy)
+	}
+}
+
+func TestJobs_AddRemove(t *testing.T) {
+	newJob := func(run func(ctx context.Context) error) *Job {
+		return NewJob(run, "worker", Every(time.Hour), Policy{}, logger.Nop())
+	}
+
+	jobs := &Jobs{}
+	jobs.Add(newJob(func(ctx context.Context) error { return nil }))
+	jobs.Add(newJob(func(ctx context.Context) error { return nil }))
+	jobs.Start(context.Background())
+
+	started := make(chan struct{})
+	paused := newJob(func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+
+		return ctx.Err()
+	})
+	jobs.Add(paused)
+	if paused.ID() != "worker-2" {
+		t.Fatalf("ID() = %s, want worker-2", paused.ID())
+	}
+
+	// the job added after start is started, the pause doesn't interrupt its run
+	if _, err := jobs.Trigger("worker-2"); err != nil {
+		t.Fatalf("Trigger() error = %v", err)
+	}
+	<-started
+	if err := jobs.Pause("worker-2"); err != nil {
+		t.Fatalf("Pause() error = %v", err)
+	}
+	if status, _ := jobs.Status("worker-2"); !status.Paused || status.Running != 1 {
+		t.Errorf("Status() = %+v, want paused with 1 running run", status)
+	}
+	if _, err := jobs.Trigger("worker-2"); !errors.Is(err, ErrPaused) {
+		t.Errorf("Trigger() error = %v, wantErr %v", err, ErrPaused)
+	}
+
+	if err := jobs.Remove(context.Background(), "worker-1"); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if err := jobs.Remove(context.Background(), "worker-1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Remove() error = %v, wantErr %v", err, ErrNotFound)
+	}
+
+	// the lowest free index is reused
+	jobs.Add(newJob(func(ctx context.Context) error { return nil }))
+	var got []string
+	for _, status := range jobs.Statuses() {
+		got = append(got, status.ID)
+	}
+	if want := []string{"worker-0", "worker-2", "worker-1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Statuses() IDs = %v, want %v", got, want)
+	}
+
+	if err := jobs.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+}
@@ -2,33 +2,205 @@ package job
 
 import (
 	"context"
+	"fmt"
 	"sync"
 )
 
-type Jobs []*Job
+// Jobs is the set of jobs, jobs can be added and removed after the set is started
+type Jobs struct {
+	mu       sync.RWMutex
+	jobs     []*Job
+	observer Observer
+	ctx      context.Context
+	stopped  bool
+}
 
+// Add adds the job and starts it when the set is started. Copies of the job with the same name get IDs
+// with the lowest free index, e.g. parser_worker-1.
 func (jobs *Jobs) Add(job *Job) {
-	*jobs = append(*jobs, job)
+	jobs.mu.Lock()
+	defer jobs.mu.Unlock()
+
+	for i := 0; ; i++ {
+		id := fmt.Sprintf("%s-%d", job.name, i)
+		if jobs.getLocked(id) == nil {
+			job.id = id
+
+			break
+		}
+	}
+
+	if jobs.observer != nil {
+		job.Observe(jobs.observer)
+	}
+
+	jobs.jobs = append(jobs.jobs, job)
+
+	if jobs.ctx != nil && !jobs.stopped {
+		job.Start(jobs.ctx)
+	}
+}
+
+// Remove stops the job and removes it from the set, see Job.Stop
+func (jobs *Jobs) Remove(ctx context.Context, id string) error {
+	jobs.mu.Lock()
+	job := jobs.getLocked(id)
+	if job == nil {
+		jobs.mu.Unlock()
+
+		return fmt.Errorf("fail remove job %s: %w", id, ErrNotFound)
+	}
+
+	for i := range jobs.jobs {
+		if jobs.jobs[i] == job {
+			jobs.jobs = append(jobs.jobs[:i], jobs.jobs[i+1:]...)
+
+			break
+		}
+	}
+	jobs.mu.Unlock()
+
+	return job.Stop(ctx)
+}
+
+// Observe sets the observer of runs of all jobs, including jobs added later
+func (jobs *Jobs) Observe(observer Observer) {
+	jobs.mu.Lock()
+	defer jobs.mu.Unlock()
+
+	jobs.observer = observer
+	for _, job := range jobs.jobs {
+		job.Observe(observer)
+	}
 }
 
 func (jobs *Jobs) Start(ctx context.Context) {
-	for _, job := range *jobs {
+	jobs.mu.Lock()
+	defer jobs.mu.Unlock()
+
+	jobs.ctx = ctx
+	for _, job := range jobs.jobs {
 		job.Start(ctx)
 	}
 }
 
-func (jobs *Jobs) Stop() {
+// Stop stops all jobs concurrently, the first error of jobs which aren't stopped before the context is done is returned.
+// Jobs added after Stop aren't started.
+func (jobs *Jobs) Stop(ctx context.Context) error {
+	jobs.mu.Lock()
+	jobs.stopped = true
+	list := append([]*Job(nil), jobs.jobs...)
+	jobs.mu.Unlock()
+
 	wg := sync.WaitGroup{}
+	errs := make([]error, len(list))
 
-	for _, job := range *jobs {
-		job := job
+	for i, job := range list {
+		i, job := i, job
 
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			job.Stop()
+			errs[i] = job.Stop(ctx)
 		}()
 	}
 
 	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (jobs *Jobs) Statuses() []Status {
+	jobs.mu.RLock()
+	defer jobs.mu.RUnlock()
+
+	statuses := make([]Status, 0, len(jobs.jobs))
+	for _, job := range jobs.jobs {
+		statuses = append(statuses, job.Status())
+	}
+
+	return statuses
+}
+
+func (jobs *Jobs) Status(id string) (Status, error) {
+	job, err := jobs.get(id)
+	if err != nil {
+		return Status{}, err
+	}
+
+	return job.Status(), nil
+}
+
+// Trigger runs the job out of schedule, see Job.Trigger
+func (jobs *Jobs) Trigger(id string) (Run, error) {
+	job, err := jobs.get(id)
+	if err != nil {
+		return Run{}, err
+	}
+
+	return job.Trigger()
+}
+
+// Pause pauses the job, see Job.Pause
+func (jobs *Jobs) Pause(id string) error {
+	job, err := jobs.get(id)
+	if err != nil {
+		return err
+	}
+
+	job.Pause()
+
+	return nil
+}
+
+// Resume resumes the job, see Job.Resume
+func (jobs *Jobs) Resume(id string) error {
+	job, err := jobs.get(id)
+	if err != nil {
+		return err
+	}
+
+	job.Resume()
+
+	return nil
+}
+
+// Reschedule changes the schedule and the policy of the job, see Job.Reschedule
+func (jobs *Jobs) Reschedule(id string, schedule Schedule, policy Policy) error {
+	job, err := jobs.get(id)
+	if err != nil {
+		return err
+	}
+
+	job.Reschedule(schedule, policy)
+
+	return nil
+}
+
+func (jobs *Jobs) get(id string) (*Job, error) {
+	jobs.mu.RLock()
+	defer jobs.mu.RUnlock()
+
+	job := jobs.getLocked(id)
+	if job == nil {
+		return nil, fmt.Errorf("fail get job %s: %w", id, ErrNotFound)
+	}
+
+	return job, nil
+}
+
+func (jobs *Jobs) getLocked(id string) *Job {
+	for _, job := range jobs.jobs {
+		if job.id == id {
+			return job
+		}
+	}
+
+	return nil
 }
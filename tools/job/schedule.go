@@ -0,0 +1,239 @@
+package job
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule returns the time of the next run after the given time
+type Schedule interface {
+	Next(t time.Time) time.Time
+	String() string
+}
+
+type intervalSchedule struct {
+	interval time.Duration
+}
+
+// Every runs the job every interval after the previous scheduled time
+func Every(interval time.Duration) Schedule {
+	return intervalSchedule{interval: interval}
+}
+
+func (s intervalSchedule) Next(t time.Time) time.Time {
+	return t.Add(s.interval)
+}
+
+func (s intervalSchedule) String() string {
+	return "@every " + s.interval.String()
+}
+
+// ParseSchedule parses the interval ("30s", "@every 30s") or the cron expression ("*/5 * * * *")
+func ParseSchedule(value string) (Schedule, error) {
+	value = strings.TrimSpace(value)
+
+	intervalValue := strings.TrimSpace(strings.TrimPrefix(value, "@every"))
+	if interval, err := time.ParseDuration(intervalValue); err == nil {
+		if interval <= 0 {
+			return nil, errors.New("interval must be positive")
+		}
+
+		return Every(interval), nil
+	}
+
+	return ParseCron(value)
+}
+
+// cron fields, every field is a bit set of allowed values
+type cronSchedule struct {
+	expr    string
+	minute  uint64
+	hour    uint64
+	dom     uint64
+	month   uint64
+	dow     uint64
+	domStar bool
+	dowStar bool
+}
+
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12},
+	{name: "day of week", min: 0, max: 7},
+}
+
+var cronDescriptors = map[string]string{
+	"@yearly":  "0 0 1 1 *",
+	"@monthly": "0 0 1 * *",
+	"@weekly":  "0 0 * * 0",
+	"@daily":   "0 0 * * *",
+	"@hourly":  "0 * * * *",
+}
+
+// ParseCron parses the standard 5-field cron expression: minute, hour, day of month, month, day of week.
+// Fields support *, lists, ranges and steps, e.g. "*/15 9-17 * * 1-5". Descriptors like @hourly are supported too.
+// Times are evaluated in UTC.
+func ParseCron(expr string) (Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	spec := expr
+	if descriptor, ok := cronDescriptors[expr]; ok {
+		spec = descriptor
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("cron expression must have %d fields, got %d", len(cronFields), len(fields))
+	}
+
+	values := make([]uint64, len(fields))
+	for i, field := range fields {
+		bits, err := parseCronField(field, cronFields[i])
+		if err != nil {
+			return nil, fmt.Errorf("fail parse %s field (%s): %w", cronFields[i].name, field, err)
+		}
+		values[i] = bits
+	}
+
+	// 7 is Sunday as well as 0
+	dow := values[4]
+	if dow&(1<<7) != 0 {
+		dow |= 1
+	}
+
+	return cronSchedule{
+		expr:    expr,
+		minute:  values[0],
+		hour:    values[1],
+		dom:     values[2],
+		month:   values[3],
+		dow:     dow,
+		domStar: fields[2] == "*" || fields[2] == "?",
+		dowStar: fields[4] == "*" || fields[4] == "?",
+	}, nil
+}
+
+func parseCronField(value string, field cronField) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(value, ",") {
+		rangeValue, stepValue, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepValue)
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %s", stepValue)
+			}
+		}
+
+		from, to := field.min, field.max
+		switch {
+		case rangeValue == "*" || rangeValue == "?":
+		case strings.Contains(rangeValue, "-"):
+			fromValue, toValue, _ := strings.Cut(rangeValue, "-")
+			var err error
+			if from, err = parseCronValue(fromValue, field); err != nil {
+				return 0, err
+			}
+			if to, err = parseCronValue(toValue, field); err != nil {
+				return 0, err
+			}
+			if from > to {
+				return 0, fmt.Errorf("invalid range %s", rangeValue)
+			}
+		default:
+			var err error
+			if from, err = parseCronValue(rangeValue, field); err != nil {
+				return 0, err
+			}
+			// "5/15" means from 5 to the max with the step
+			to = from
+			if hasStep {
+				to = field.max
+			}
+		}
+
+		for v := from; v <= to; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+
+	return bits, nil
+}
+
+func parseCronValue(value string, field cronField) (int, error) {
+	v, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %s", value)
+	}
+	if v < field.min || v > field.max {
+		return 0, fmt.Errorf("value %d is out of range %d-%d", v, field.min, field.max)
+	}
+
+	return v, nil
+}
+
+// Next returns the first matching minute after the time. Zero time is returned when nothing matches
+// in 5 years, e.g. for "0 0 30 2 *".
+func (s cronSchedule) Next(t time.Time) time.Time {
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+
+			continue
+		}
+		if !s.matchDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = t.Truncate(time.Hour).Add(time.Hour)
+
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
+
+// matchDay follows cron: when both day fields are restricted, the day matches either of them
+func (s cronSchedule) matchDay(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+
+	switch {
+	case s.domStar && s.dowStar:
+		return true
+	case s.domStar:
+		return dowMatch
+	case s.dowStar:
+		return domMatch
+	}
+
+	return domMatch || dowMatch
+}
+
+func (s cronSchedule) String() string {
+	return s.expr
+}
@@ -0,0 +1,104 @@
+package job
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSchedule_Next(t *testing.T) {
+	// Wednesday
+	now := time.Date(2024, time.January, 31, 10, 7, 30, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			name:  "interval",
+			value: "30s",
+			want:  now.Add(30 * time.Second),
+		},
+		{
+			name:  "every",
+			value: "@every 5m",
+			want:  now.Add(5 * time.Minute),
+		},
+		{
+			name:  "every 15 minutes",
+			value: "*/15 * * * *",
+			want:  time.Date(2024, time.January, 31, 10, 15, 0, 0, time.UTC),
+		},
+		{
+			name:  "list and range of hours",
+			value: "0 9-10,18 * * *",
+			want:  time.Date(2024, time.January, 31, 18, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "next month",
+			value: "30 2 1 * *",
+			want:  time.Date(2024, time.February, 1, 2, 30, 0, 0, time.UTC),
+		},
+		{
+			name:  "leap day",
+			value: "0 0 29 2 *",
+			want:  time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "sunday as 7",
+			value: "0 12 * * 7",
+			want:  time.Date(2024, time.February, 4, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "day of month or day of week",
+			value: "0 0 15 * 5",
+			want:  time.Date(2024, time.February, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "descriptor",
+			value: "@daily",
+			want:  time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "no matching day",
+			value: "0 0 30 2 *",
+			want:  time.Time{},
+		},
+		{
+			name:    "negative interval",
+			value:   "-1s",
+			wantErr: true,
+		},
+		{
+			name:    "too few fields",
+			value:   "* * * *",
+			wantErr: true,
+		},
+		{
+			name:    "out of range",
+			value:   "60 * * * *",
+			wantErr: true,
+		},
+		{
+			name:    "zero step",
+			value:   "*/0 * * * *",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseSchedule(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSchedule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if got := schedule.Next(now); !got.Equal(tt.want) {
+				t.Errorf("Next() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
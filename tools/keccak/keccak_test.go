@@ -0,0 +1,45 @@
+package keccak
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestSum256(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{
+			name: "empty",
+			data: "",
+			want: "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470",
+		},
+		{
+			name: "short",
+			data: "abc",
+			want: "4e03657aea45a94fc7d47ba826c8d667c0d1e6e33a64a036ec44f58fa12d6c45",
+		},
+		{
+			name: "one block without padding",
+			data: strings.Repeat("a", rate),
+			want: "a6c4d403279fe3e0af03729caada8374b5ca54d8065329a3ebcaeb4b60aa386e",
+		},
+		{
+			name: "several blocks",
+			data: strings.Repeat("a", 1000),
+			want: "b6a4ac1f51884d71f30fa397a5e155de3099e11fc0edef5d08b646e621e19de9",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sum := Sum256([]byte(tt.data))
+			if got := hex.EncodeToString(sum[:]); got != tt.want {
+				t.Errorf("Sum256() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,311 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// leveled logger with structured fields which writes text or JSON lines.
+// Level is shared by a logger and loggers derived by With, so it can be changed at runtime.
+
+type Level int32
+
+const (
+	LevelDebug Level = iota - 1
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelWarn:
+		return "warn"
+	case LevelError:
+		return "error"
+	}
+
+	return "level(" + strconv.Itoa(int(l)) + ")"
+}
+
+func ParseLevel(value string) (Level, error) {
+	switch strings.ToLower(value) {
+	case "debug":
+		return LevelDebug, nil
+	case "info":
+		return LevelInfo, nil
+	case "warn", "warning":
+		return LevelWarn, nil
+	case "error":
+		return LevelError, nil
+	}
+
+	return 0, fmt.Errorf("unknown level %q, level is one of debug, info, warn, error", value)
+}
+
+// LevelVar is the minimal level of messages which can be changed concurrently
+type LevelVar struct {
+	level atomic.Int32
+}
+
+func NewLevelVar(level Level) *LevelVar {
+	v := &LevelVar{}
+	v.Set(level)
+
+	return v
+}
+
+func (v *LevelVar) Level() Level {
+	return Level(v.level.Load())
+}
+
+func (v *LevelVar) Set(level Level) {
+	v.level.Store(int32(level))
+}
+
+type Format string
+
+const (
+	FormatText Format = "text"
+	FormatJSON Format = "json"
+)
+
+func ParseFormat(value string) (Format, error) {
+	switch Format(strings.ToLower(value)) {
+	case FormatText:
+		return FormatText, nil
+	case FormatJSON:
+		return FormatJSON, nil
+	}
+
+	return "", fmt.Errorf("unknown format %q, format is one of text, json", value)
+}
+
+// Field is a key-value pair of the message
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+func String(key, value string) Field {
+	return Field{Key: key, Value: value}
+}
+
+func Int(key string, value int) Field {
+	return Field{Key: key, Value: value}
+}
+
+func Uint64(key string, value uint64) Field {
+	return Field{Key: key, Value: value}
+}
+
+func Duration(key string, value time.Duration) Field {
+	return Field{Key: key, Value: value.String()}
+}
+
+// Stringer keeps fmt.Stringer values like addresses and block numbers in their text form
+func Stringer(key string, value fmt.Stringer) Field {
+	return Field{Key: key, Value: value.String()}
+}
+
+func Err(err error) Field {
+	if err == nil {
+		return Field{Key: "error", Value: nil}
+	}
+
+	return Field{Key: "error", Value: err.Error()}
+}
+
+func Any(key string, value interface{}) Field {
+	return Field{Key: key, Value: value}
+}
+
+type Logger interface {
+	Debug(msg string, fields ...Field)
+	Info(msg string, fields ...Field)
+	Warn(msg string, fields ...Field)
+	Error(msg string, fields ...Field)
+	// With returns a logger which adds the fields to every message
+	With(fields ...Field) Logger
+}
+
+type output struct {
+	w  io.Writer
+	mu sync.Mutex
+}
+
+type logger struct {
+	out    *output
+	format Format
+	level  *LevelVar
+	fields []Field
+	now    func() time.Time
+}
+
+// New creates a logger writing to w, messages below the level are skipped
+func New(w io.Writer, format Format, level *LevelVar) Logger {
+	return &logger{
+		out:    &output{w: w},
+		format: format,
+		level:  level,
+		now:    time.Now,
+	}
+}
+
+func (l *logger) Debug(msg string, fields ...Field) {
+	l.log(LevelDebug, msg, fields)
+}
+
+func (l *logger) Info(msg string, fields ...Field) {
+	l.log(LevelInfo, msg, fields)
+}
+
+func (l *logger) Warn(msg string, fields ...Field) {
+	l.log(LevelWarn, msg, fields)
+}
+
+func (l *logger) Error(msg string, fields ...Field) {
+	l.log(LevelError, msg, fields)
+}
+
+func (l *logger) With(fields ...Field) Logger {
+	derived := *l
+	derived.fields = make([]Field, 0, len(l.fields)+len(fields))
+	derived.fields = append(derived.fields, l.fields...)
+	derived.fields = append(derived.fields, fields...)
+
+	return &derived
+}
+
+func (l *logger) log(level Level, msg string, fields []Field) {
+	if level < l.level.Level() {
+		return
+	}
+
+	buf := bytes.Buffer{}
+	now := l.now().UTC()
+	if l.format == FormatJSON {
+		writeJSON(&buf, now, level, msg, l.fields, fields)
+	} else {
+		writeText(&buf, now, level, msg, l.fields, fields)
+	}
+	buf.WriteByte('\n')
+
+	l.out.mu.Lock()
+	defer l.out.mu.Unlock()
+
+	_, _ = l.out.w.Write(buf.Bytes())
+}
+
+// writeText writes "<time> <LEVEL> <message> key=value ...", values with spaces or quotes are quoted
+func writeText(buf *bytes.Buffer, now time.Time, level Level, msg string, fieldSets ...[]Field) {
+	buf.WriteString(now.Format(time.RFC3339Nano))
+	buf.WriteByte(' ')
+	buf.WriteString(strings.ToUpper(level.String()))
+	buf.WriteByte(' ')
+	buf.WriteString(msg)
+
+	for _, fields := range fieldSets {
+		for _, field := range fields {
+			buf.WriteByte(' ')
+			buf.WriteString(field.Key)
+			buf.WriteByte('=')
+			buf.WriteString(textValue(field.Value))
+		}
+	}
+}
+
+func textValue(value interface{}) string {
+	var s string
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		s = v
+	case error:
+		s = v.Error()
+	default:
+		s = fmt.Sprint(v)
+	}
+
+	if s == "" || strings.ContainsAny(s, " =\"\n\t") {
+		return strconv.Quote(s)
+	}
+
+	return s
+}
+
+// writeJSON writes an object with time, level, msg keys followed by fields in order
+func writeJSON(buf *bytes.Buffer, now time.Time, level Level, msg string, fieldSets ...[]Field) {
+	buf.WriteString(`{"time":`)
+	writeJSONValue(buf, now.Format(time.RFC3339Nano))
+	buf.WriteString(`,"level":`)
+	writeJSONValue(buf, level.String())
+	buf.WriteString(`,"msg":`)
+	writeJSONValue(buf, msg)
+
+	for _, fields := range fieldSets {
+		for _, field := range fields {
+			buf.WriteByte(',')
+			writeJSONValue(buf, field.Key)
+			buf.WriteByte(':')
+			writeJSONValue(buf, field.Value)
+		}
+	}
+
+	buf.WriteByte('}')
+}
+
+func writeJSONValue(buf *bytes.Buffer, value interface{}) {
+	if err, ok := value.(error); ok {
+		value = err.Error()
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		data, _ = json.Marshal(fmt.Sprint(value))
+	}
+
+	buf.Write(data)
+}
+
+type nop struct{}
+
+// Nop returns a logger which skips all messages, e.g. for tests
+func Nop() Logger {
+	return nop{}
+}
+
+func (nop) Debug(string, ...Field) {}
+func (nop) Info(string, ...Field)  {}
+func (nop) Warn(string, ...Field)  {}
+func (nop) Error(string, ...Field) {}
+func (n nop) With(...Field) Logger { return n }
+
+type contextKey struct{}
+
+// NewContext returns the context with the logger, e.g. a logger with request ID or worker ID fields
+func NewContext(ctx context.Context, l Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// FromContext returns the logger of the context or the fallback if the context has no logger
+func FromContext(ctx context.Context, fallback Logger) Logger {
+	if l, ok := ctx.Value(contextKey{}).(Logger); ok {
+		return l
+	}
+
+	return fallback
+}
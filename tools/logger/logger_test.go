@@ -0,0 +1,85 @@
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLogger(t *testing.T) {
+	now := time.Date(2023, 11, 14, 22, 32, 44, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		format Format
+		level  Level
+		log    func(l Logger)
+		want   string
+	}{
+		{
+			name:   "text",
+			format: FormatText,
+			level:  LevelInfo,
+			log: func(l Logger) {
+				l.With(Int("worker_id", 1)).Info("block parsed", Uint64("block_number", 16), String("hash", ""))
+			},
+			want: "2023-11-14T22:32:44Z INFO block parsed worker_id=1 block_number=16 hash=\"\"\n",
+		},
+		{
+			name:   "text quotes values",
+			format: FormatText,
+			level:  LevelInfo,
+			log: func(l Logger) {
+				l.Error("request failed", Err(errors.New(`call "eth_blockNumber" failed`)))
+			},
+			want: "2023-11-14T22:32:44Z ERROR request failed error=\"call \\\"eth_blockNumber\\\" failed\"\n",
+		},
+		{
+			name:   "json",
+			format: FormatJSON,
+			level:  LevelDebug,
+			log: func(l Logger) {
+				l.With(String("request_id", "a1")).Debug("request", Int("status", 500), Err(errors.New("fail")))
+			},
+			want: `{"time":"2023-11-14T22:32:44Z","level":"debug","msg":"request","request_id":"a1","status":500,"error":"fail"}` + "\n",
+		},
+		{
+			name:   "message below level is skipped",
+			format: FormatJSON,
+			level:  LevelWarn,
+			log: func(l Logger) {
+				l.Info("skipped")
+			},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := bytes.Buffer{}
+			l := New(&buf, tt.format, NewLevelVar(tt.level))
+			l.(*logger).now = func() time.Time { return now }
+
+			tt.log(l)
+
+			if buf.String() != tt.want {
+				t.Errorf("log got = %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestLevelVar_Set(t *testing.T) {
+	buf := bytes.Buffer{}
+	level := NewLevelVar(LevelInfo)
+	l := New(&buf, FormatText, level).With(String("a", "b"))
+
+	l.Debug("skipped")
+	level.Set(LevelDebug)
+	l.Debug("written")
+
+	if !bytes.Contains(buf.Bytes(), []byte("written")) || bytes.Contains(buf.Bytes(), []byte("skipped")) {
+		t.Errorf("log got = %q, want only message after level change", buf.String())
+	}
+}
@@ -0,0 +1,316 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// minimal metrics registry which writes Prometheus text exposition format.
+// Counters, gauges and histograms may have labels, label values are passed on every update
+// in the order of label names. Wrong count of label values is a programming error and panics.
+
+const (
+	// ContentType is the content type of Prometheus text exposition format
+	ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+	typeCounter   = "counter"
+	typeGauge     = "gauge"
+	typeHistogram = "histogram"
+)
+
+// DefBuckets are histogram buckets in seconds suitable for request latencies
+var DefBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+type Registry struct {
+	families []*family
+	names    map[string]struct{}
+	mu       sync.Mutex
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		names: map[string]struct{}{},
+	}
+}
+
+type Counter struct {
+	f *family
+}
+
+// NewCounter registers a counter, the name should have _total suffix
+func (r *Registry) NewCounter(name, help string, labels ...string) *Counter {
+	return &Counter{f: r.register(name, help, typeCounter, labels, nil)}
+}
+
+func (c *Counter) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add increases the counter, negative values are ignored because counters only grow
+func (c *Counter) Add(value float64, labelValues ...string) {
+	if value < 0 {
+		return
+	}
+
+	c.f.update(labelValues, func(s *series) {
+		s.value += value
+	})
+}
+
+type Gauge struct {
+	f *family
+}
+
+func (r *Registry) NewGauge(name, help string, labels ...string) *Gauge {
+	return &Gauge{f: r.register(name, help, typeGauge, labels, nil)}
+}
+
+func (g *Gauge) Set(value float64, labelValues ...string) {
+	g.f.update(labelValues, func(s *series) {
+		s.value = value
+	})
+}
+
+func (g *Gauge) Add(value float64, labelValues ...string) {
+	g.f.update(labelValues, func(s *series) {
+		s.value += value
+	})
+}
+
+// Value returns the current value, zero if the gauge wasn't set
+func (g *Gauge) Value(labelValues ...string) float64 {
+	var value float64
+	g.f.update(labelValues, func(s *series) {
+		value = s.value
+	})
+
+	return value
+}
+
+// NewGaugeFunc registers a gauge without labels which value is taken from the function on every scrape
+func (r *Registry) NewGaugeFunc(name, help string, fn func() float64) {
+	f := r.register(name, help, typeGauge, nil, nil)
+	f.fn = fn
+}
+
+type Histogram struct {
+	f *family
+}
+
+// NewHistogram registers a histogram with upper bounds of buckets in increasing order, +Inf bucket is added implicitly
+func (r *Registry) NewHistogram(name, help string, buckets []float64, labels ...string) *Histogram {
+	return &Histogram{f: r.register(name, help, typeHistogram, labels, buckets)}
+}
+
+func (h *Histogram) Observe(value float64, labelValues ...string) {
+	h.f.update(labelValues, func(s *series) {
+		for i, bound := range h.f.buckets {
+			if value <= bound {
+				s.counts[i]++
+
+				break
+			}
+		}
+		s.sum += value
+		s.count++
+	})
+}
+
+// WriteTo writes all metrics in Prometheus text exposition format, series are sorted by label values
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	r.mu.Lock()
+	families := make([]*family, len(r.families))
+	copy(families, r.families)
+	r.mu.Unlock()
+
+	cw := &countingWriter{w: w}
+	bw := bufio.NewWriter(cw)
+	for _, f := range families {
+		f.write(bw)
+	}
+
+	err := bw.Flush()
+
+	return cw.n, err
+}
+
+// Handler serves metrics for scraping
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", ContentType)
+		w.WriteHeader(http.StatusOK)
+
+		_, _ = r.WriteTo(w)
+	})
+}
+
+func (r *Registry) register(name, help, typ string, labels []string, buckets []float64) *family {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.names[name]; ok {
+		panic(fmt.Sprintf("metric %s is already registered", name))
+	}
+	r.names[name] = struct{}{}
+
+	f := &family{
+		name:    name,
+		help:    help,
+		typ:     typ,
+		labels:  labels,
+		buckets: buckets,
+		series:  map[string]*series{},
+	}
+	r.families = append(r.families, f)
+
+	// metric without labels is exposed with zero value before the first update
+	if len(labels) == 0 {
+		f.update(nil, func(*series) {})
+	}
+
+	return f
+}
+
+type family struct {
+	name    string
+	help    string
+	typ     string
+	labels  []string
+	buckets []float64
+	fn      func() float64
+
+	series map[string]*series
+	mu     sync.Mutex
+}
+
+type series struct {
+	labelValues []string
+	value       float64
+	// counts of observations by bucket (not cumulative), sum and count of histogram observations
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+func (f *family) update(labelValues []string, fn func(s *series)) {
+	if len(labelValues) != len(f.labels) {
+		panic(fmt.Sprintf("metric %s has %d labels, got %d values", f.name, len(f.labels), len(labelValues)))
+	}
+
+	key := strings.Join(labelValues, "\xff")
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	s, ok := f.series[key]
+	if !ok {
+		s = &series{
+			labelValues: append([]string(nil), labelValues...),
+			counts:      make([]uint64, len(f.buckets)),
+		}
+		f.series[key] = s
+	}
+
+	fn(s)
+}
+
+func (f *family) write(w *bufio.Writer) {
+	fmt.Fprintf(w, "# HELP %s %s\n", f.name, escapeHelp(f.help))
+	fmt.Fprintf(w, "# TYPE %s %s\n", f.name, f.typ)
+
+	if f.fn != nil {
+		fmt.Fprintf(w, "%s %s\n", f.name, formatValue(f.fn()))
+
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	keys := make([]string, 0, len(f.series))
+	for key := range f.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		s := f.series[key]
+
+		if f.typ != typeHistogram {
+			fmt.Fprintf(w, "%s%s %s\n", f.name, formatLabels(f.labels, s.labelValues, ""), formatValue(s.value))
+
+			continue
+		}
+
+		var cumulative uint64
+		for i, bound := range f.buckets {
+			cumulative += s.counts[i]
+			fmt.Fprintf(w, "%s_bucket%s %d\n", f.name, formatLabels(f.labels, s.labelValues, formatValue(bound)), cumulative)
+		}
+		fmt.Fprintf(w, "%s_bucket%s %d\n", f.name, formatLabels(f.labels, s.labelValues, "+Inf"), s.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", f.name, formatLabels(f.labels, s.labelValues, ""), formatValue(s.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", f.name, formatLabels(f.labels, s.labelValues, ""), s.count)
+	}
+}
+
+// formatLabels formats label pairs, le is the bucket label of histograms and it's skipped when empty
+func formatLabels(names, values []string, le string) string {
+	pairs := make([]string, 0, len(names)+1)
+	for i, name := range names {
+		pairs = append(pairs, name+`="`+escapeLabelValue(values[i])+`"`)
+	}
+	if le != "" {
+		pairs = append(pairs, `le="`+le+`"`)
+	}
+
+	if len(pairs) == 0 {
+		return ""
+	}
+
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func formatValue(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	}
+
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+var (
+	helpReplacer       = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelValueReplacer = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(help string) string {
+	return helpReplacer.Replace(help)
+}
+
+func escapeLabelValue(value string) string {
+	return labelValueReplacer.Replace(value)
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.n += int64(n)
+
+	return n, err
+}
@@ -0,0 +1,67 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRegistry_WriteTo(t *testing.T) {
+	r := NewRegistry()
+
+	counter := r.NewCounter("requests_total", "Count of requests", "method", "status")
+	counter.Inc("GET", "200")
+	counter.Inc("GET", "200")
+	counter.Add(3, "POST", "500")
+	counter.Add(-1, "POST", "500")
+
+	gauge := r.NewGauge("head_block", "Head block of the node")
+	gauge.Set(10)
+	gauge.Add(2)
+
+	r.NewCounter("failures_total", "Count of failures")
+
+	r.NewGaugeFunc("subscribers", "Count of subscribers\nwith escaped help", func() float64 { return 7 })
+
+	histogram := r.NewHistogram("duration_seconds", "Duration", []float64{0.1, 1}, "path")
+	histogram.Observe(0.05, `/a"b`)
+	histogram.Observe(0.5, `/a"b`)
+	histogram.Observe(2, `/a"b`)
+
+	want := `# HELP requests_total Count of requests
+# TYPE requests_total counter
+requests_total{method="GET",status="200"} 2
+requests_total{method="POST",status="500"} 3
+# HELP head_block Head block of the node
+# TYPE head_block gauge
+head_block 12
+# HELP failures_total Count of failures
+# TYPE failures_total counter
+failures_total 0
+# HELP subscribers Count of subscribers\nwith escaped help
+# TYPE subscribers gauge
+subscribers 7
+# HELP duration_seconds Duration
+# TYPE duration_seconds histogram
+duration_seconds_bucket{path="/a\"b",le="0.1"} 1
+duration_seconds_bucket{path="/a\"b",le="1"} 2
+duration_seconds_bucket{path="/a\"b",le="+Inf"} 3
+duration_seconds_sum{path="/a\"b"} 2.55
+duration_seconds_count{path="/a\"b"} 3
+`
+
+	buf := strings.Builder{}
+	n, err := r.WriteTo(&buf)
+	if err != nil {
+		t.Errorf("WriteTo() error = %v, wantErr %v", err, nil)
+		return
+	}
+	if buf.String() != want {
+		t.Errorf("WriteTo() got = %s, want %s", buf.String(), want)
+	}
+	if n != int64(len(want)) {
+		t.Errorf("WriteTo() n got = %d, want %d", n, len(want))
+	}
+	if got := gauge.Value(); got != 12 {
+		t.Errorf("Value() got = %v, want %v", got, 12)
+	}
+}
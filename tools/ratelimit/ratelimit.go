@@ -0,0 +1,121 @@
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// token bucket limiter keyed by client. A bucket is refilled with Rate tokens per second up to Burst tokens,
+// a request takes one token. Full buckets are equal to absent ones, so they are dropped to keep memory bounded.
+
+const sweepInterval = time.Minute
+
+// Limit is the refill rate in tokens per second and the bucket capacity
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Result describes the bucket after the request, it's used for RateLimit-* and Retry-After headers
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset is the time until the bucket is full again
+	Reset time.Duration
+	// RetryAfter is the time until the next token, zero when the request is allowed
+	RetryAfter time.Duration
+}
+
+type bucket struct {
+	tokens    float64
+	limit     Limit
+	updatedAt time.Time
+}
+
+type Limiter struct {
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+	mu        sync.Mutex
+}
+
+func New() *Limiter {
+	return &Limiter{
+		buckets:   map[string]*bucket{},
+		lastSweep: time.Now(),
+		now:       time.Now,
+	}
+}
+
+// Allow takes a token from the bucket of the key. The limit is passed on every call,
+// so one limiter serves keys with different limits, e.g. per route.
+func (l *Limiter) Allow(key string, limit Limit) Result {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{
+			tokens:    float64(limit.Burst),
+			limit:     limit,
+			updatedAt: now,
+		}
+		l.buckets[key] = b
+	}
+
+	b.limit = limit
+	b.tokens = refill(b, now)
+	b.updatedAt = now
+
+	result := Result{
+		Limit: limit.Burst,
+	}
+
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = tokensDuration(1-b.tokens, limit)
+	}
+
+	result.Remaining = int(math.Floor(b.tokens))
+	result.Reset = tokensDuration(float64(limit.Burst)-b.tokens, limit)
+
+	return result
+}
+
+// sweep drops buckets which are full at the moment. It's called under the lock not more often than sweepInterval.
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+
+	for key, b := range l.buckets {
+		if refill(b, now) >= float64(b.limit.Burst) {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+func refill(b *bucket, now time.Time) float64 {
+	elapsed := now.Sub(b.updatedAt).Seconds()
+	if elapsed < 0 {
+		elapsed = 0
+	}
+
+	return math.Min(float64(b.limit.Burst), b.tokens+elapsed*b.limit.Rate)
+}
+
+func tokensDuration(tokens float64, limit Limit) time.Duration {
+	if tokens <= 0 || limit.Rate <= 0 {
+		return 0
+	}
+
+	return time.Duration(tokens / limit.Rate * float64(time.Second))
+}
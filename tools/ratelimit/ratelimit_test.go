@@ -0,0 +1,74 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLimiter_Allow(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	limit := Limit{Rate: 2, Burst: 3}
+
+	l := New()
+	l.now = func() time.Time { return now }
+	l.lastSweep = now
+
+	tests := []struct {
+		name    string
+		elapsed time.Duration
+		key     string
+		want    Result
+	}{
+		{
+			name: "full bucket",
+			key:  "a",
+			want: Result{Allowed: true, Limit: 3, Remaining: 2, Reset: 500 * time.Millisecond},
+		},
+		{
+			name: "second token",
+			key:  "a",
+			want: Result{Allowed: true, Limit: 3, Remaining: 1, Reset: time.Second},
+		},
+		{
+			name: "last token",
+			key:  "a",
+			want: Result{Allowed: true, Limit: 3, Remaining: 0, Reset: 1500 * time.Millisecond},
+		},
+		{
+			name: "empty bucket",
+			key:  "a",
+			want: Result{Allowed: false, Limit: 3, Remaining: 0, Reset: 1500 * time.Millisecond, RetryAfter: 500 * time.Millisecond},
+		},
+		{
+			name: "another key has own bucket",
+			key:  "b",
+			want: Result{Allowed: true, Limit: 3, Remaining: 2, Reset: 500 * time.Millisecond},
+		},
+		{
+			name:    "bucket is refilled",
+			elapsed: 500 * time.Millisecond,
+			key:     "a",
+			want:    Result{Allowed: true, Limit: 3, Remaining: 0, Reset: 1500 * time.Millisecond},
+		},
+		{
+			name:    "bucket isn't refilled above burst",
+			elapsed: time.Hour,
+			key:     "a",
+			want:    Result{Allowed: true, Limit: 3, Remaining: 2, Reset: 500 * time.Millisecond},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now = now.Add(tt.elapsed)
+
+			if got := l.Allow(tt.key, limit); got != tt.want {
+				t.Errorf("Allow() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	if _, ok := l.buckets["b"]; ok {
+		t.Errorf("Allow() full bucket of b isn't swept")
+	}
+}
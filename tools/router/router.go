@@ -0,0 +1,191 @@
+package router
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// minimal router with path parameters, e.g. /v1/addresses/{address}/transactions.
+// Static segments take precedence over parameters, so /v1/blocks/latest is matched before /v1/blocks/{number}.
+
+type paramsKey struct{}
+
+type route struct {
+	pattern  string
+	segments []string
+	handlers map[string]http.HandlerFunc
+}
+
+type Router struct {
+	routes []*route
+
+	// NotFound is called when no route matches the path
+	NotFound http.HandlerFunc
+	// MethodNotAllowed is called when the path matches but the method doesn't, Allow header is already set
+	MethodNotAllowed http.HandlerFunc
+}
+
+func New() *Router {
+	return &Router{
+		NotFound: http.NotFound,
+		MethodNotAllowed: func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		},
+	}
+}
+
+// Handle registers the handler for the method and the pattern. Pattern segments in braces are parameters,
+// a parameter matches one non-empty segment.
+func (rt *Router) Handle(method, pattern string, handler http.HandlerFunc) {
+	segments := splitPath(pattern)
+
+	for _, r := range rt.routes {
+		if equalSegments(r.segments, segments) {
+			r.handlers[method] = handler
+
+			return
+		}
+	}
+
+	rt.routes = append(rt.routes, &route{
+		pattern:  pattern,
+		segments: segments,
+		handlers: map[string]http.HandlerFunc{method: handler},
+	})
+}
+
+func (rt *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	matched, params := rt.find(r.URL.Path)
+	if matched == nil {
+		rt.NotFound(w, r)
+
+		return
+	}
+
+	handler, ok := matched.handlers[r.Method]
+	if !ok {
+		w.Header().Set("Allow", strings.Join(matched.methods(), ", "))
+		rt.MethodNotAllowed(w, r)
+
+		return
+	}
+
+	if len(params) > 0 {
+		r = r.WithContext(context.WithValue(r.Context(), paramsKey{}, params))
+	}
+
+	handler(w, r)
+}
+
+// Pattern returns the pattern of the route matching the path, e.g. for metrics labels, or false if no route matches
+func (rt *Router) Pattern(path string) (string, bool) {
+	matched, _ := rt.find(path)
+	if matched == nil {
+		return "", false
+	}
+
+	return matched.pattern, true
+}
+
+func (rt *Router) find(path string) (*route, map[string]string) {
+	segments := splitPath(path)
+
+	var (
+		matched *route
+		params  map[string]string
+	)
+	for _, candidate := range rt.routes {
+		candidateParams, ok := candidate.match(segments)
+		if !ok {
+			continue
+		}
+
+		if matched == nil || candidate.moreSpecific(matched) {
+			matched, params = candidate, candidateParams
+		}
+	}
+
+	return matched, params
+}
+
+// Param returns value of the path parameter or empty string if the route has no such parameter
+func Param(r *http.Request, name string) string {
+	params, _ := r.Context().Value(paramsKey{}).(map[string]string)
+
+	return params[name]
+}
+
+func (r *route) match(path []string) (map[string]string, bool) {
+	if len(path) != len(r.segments) {
+		return nil, false
+	}
+
+	params := map[string]string{}
+	for i, segment := range r.segments {
+		if name, ok := paramName(segment); ok {
+			if path[i] == "" {
+				return nil, false
+			}
+			params[name] = path[i]
+
+			continue
+		}
+
+		if segment != path[i] {
+			return nil, false
+		}
+	}
+
+	return params, true
+}
+
+// moreSpecific reports whether the route has a static segment where the other route has a parameter first
+func (r *route) moreSpecific(other *route) bool {
+	for i := range r.segments {
+		_, isParam := paramName(r.segments[i])
+		_, otherIsParam := paramName(other.segments[i])
+		if isParam != otherIsParam {
+			return otherIsParam
+		}
+	}
+
+	return false
+}
+
+func (r *route) methods() []string {
+	methods := make([]string, 0, len(r.handlers))
+	for method := range r.handlers {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+
+	return methods
+}
+
+func paramName(segment string) (string, bool) {
+	if len(segment) > 2 && strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+		return segment[1 : len(segment)-1], true
+	}
+
+	return "", false
+}
+
+func equalSegments(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func splitPath(path string) []string {
+	return strings.Split(strings.TrimPrefix(path, "/"), "/")
+}
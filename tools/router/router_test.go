@@ -0,0 +1,143 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRouter_ServeHTTP(t *testing.T) {
+	rt := New()
+	rt.Handle(http.MethodGet, "/v1/blocks/{number}", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("block " + Param(r, "number")))
+	})
+	rt.Handle(http.MethodGet, "/v1/blocks/latest", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("latest"))
+	})
+	rt.Handle(http.MethodGet, "/v1/addresses/{address}/transactions", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("transactions " + Param(r, "address")))
+	})
+	rt.Handle(http.MethodPut, "/v1/wallets/{id}", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("put " + Param(r, "id")))
+	})
+	rt.Handle(http.MethodDelete, "/v1/wallets/{id}", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("delete " + Param(r, "id")))
+	})
+
+	tests := []struct {
+		name      string
+		method    string
+		path      string
+		wantCode  int
+		wantBody  string
+		wantAllow string
+	}{
+		{
+			name:     "path parameter",
+			method:   http.MethodGet,
+			path:     "/v1/blocks/0x10",
+			wantCode: http.StatusOK,
+			wantBody: "block 0x10",
+		},
+		{
+			name:     "static segment takes precedence over parameter",
+			method:   http.MethodGet,
+			path:     "/v1/blocks/latest",
+			wantCode: http.StatusOK,
+			wantBody: "latest",
+		},
+		{
+			name:     "parameter in the middle",
+			method:   http.MethodGet,
+			path:     "/v1/addresses/0x41da31/transactions",
+			wantCode: http.StatusOK,
+			wantBody: "transactions 0x41da31",
+		},
+		{
+			name:     "several methods of one path",
+			method:   http.MethodDelete,
+			path:     "/v1/wallets/5",
+			wantCode: http.StatusOK,
+			wantBody: "delete 5",
+		},
+		{
+			name:      "method not allowed",
+			method:    http.MethodPost,
+			path:      "/v1/wallets/5",
+			wantCode:  http.StatusMethodNotAllowed,
+			wantAllow: "DELETE, PUT",
+		},
+		{
+			name:     "empty parameter",
+			method:   http.MethodGet,
+			path:     "/v1/addresses//transactions",
+			wantCode: http.StatusNotFound,
+			wantBody: "404 page not found\n",
+		},
+		{
+			name:     "unknown path",
+			method:   http.MethodGet,
+			path:     "/v1/blocks/0x10/transactions",
+			wantCode: http.StatusNotFound,
+			wantBody: "404 page not found\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			rt.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+
+			if w.Code != tt.wantCode {
+				t.Errorf("code got = %d, want %d", w.Code, tt.wantCode)
+			}
+			if w.Body.String() != tt.wantBody {
+				t.Errorf("body got = %q, want %q", w.Body.String(), tt.wantBody)
+			}
+			if got := w.Header().Get("Allow"); got != tt.wantAllow {
+				t.Errorf("Allow got = %q, want %q", got, tt.wantAllow)
+			}
+		})
+	}
+}
+
+func TestRouter_Pattern(t *testing.T) {
+	rt := New()
+	rt.Handle(http.MethodGet, "/v1/blocks/{number}", func(w http.ResponseWriter, r *http.Request) {})
+	rt.Handle(http.MethodGet, "/v1/blocks/latest", func(w http.ResponseWriter, r *http.Request) {})
+
+	tests := []struct {
+		name   string
+		path   string
+		want   string
+		wantOk bool
+	}{
+		{
+			name:   "parameter",
+			path:   "/v1/blocks/0x10",
+			want:   "/v1/blocks/{number}",
+			wantOk: true,
+		},
+		{
+			name:   "static segment",
+			path:   "/v1/blocks/latest",
+			want:   "/v1/blocks/latest",
+			wantOk: true,
+		},
+		{
+			name:   "unknown path",
+			path:   "/v1/wallets",
+			want:   "",
+			wantOk: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := rt.Pattern(tt.path)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("Pattern() got = %q, %v, want %q, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
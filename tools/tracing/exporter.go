@@ -0,0 +1,260 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// StdoutExporter writes every span as a JSON line, it's useful for local debugging
+type StdoutExporter struct {
+	w  io.Writer
+	mu sync.Mutex
+}
+
+func NewStdoutExporter(w io.Writer) *StdoutExporter {
+	return &StdoutExporter{w: w}
+}
+
+type stdoutSpan struct {
+	Name          string                 `json:"name"`
+	Kind          string                 `json:"kind"`
+	TraceID       string                 `json:"traceId"`
+	SpanID        string                 `json:"spanId"`
+	ParentSpanID  string                 `json:"parentSpanId,omitempty"`
+	Start         time.Time              `json:"start"`
+	End           time.Time              `json:"end"`
+	Duration      string                 `json:"duration"`
+	Attributes    map[string]interface{} `json:"attributes,omitempty"`
+	Status        string                 `json:"status"`
+	StatusMessage string                 `json:"statusMessage,omitempty"`
+}
+
+func (e *StdoutExporter) Export(_ context.Context, spans []SpanData) error {
+	buf := bytes.Buffer{}
+	enc := json.NewEncoder(&buf)
+	for _, span := range spans {
+		s := stdoutSpan{
+			Name:          span.Name,
+			Kind:          span.Kind.String(),
+			TraceID:       span.SpanContext.TraceID.String(),
+			SpanID:        span.SpanContext.SpanID.String(),
+			Start:         span.Start,
+			End:           span.End,
+			Duration:      span.End.Sub(span.Start).String(),
+			Status:        span.Status.String(),
+			StatusMessage: span.StatusMessage,
+		}
+		if span.Parent.IsValid() {
+			s.ParentSpanID = span.Parent.String()
+		}
+		if len(span.Attributes) > 0 {
+			s.Attributes = make(map[string]interface{}, len(span.Attributes))
+			for _, attr := range span.Attributes {
+				s.Attributes[attr.Key] = attr.Value
+			}
+		}
+
+		if err := enc.Encode(s); err != nil {
+			return fmt.Errorf("fail encode span (%s): %w", span.Name, err)
+		}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if _, err := e.w.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("fail write spans: %w", err)
+	}
+
+	return nil
+}
+
+// OTLPExporter sends spans to OpenTelemetry collector by OTLP/HTTP with JSON encoding
+type OTLPExporter struct {
+	clnt        http.Client
+	endpoint    string
+	headers     map[string]string
+	serviceName string
+}
+
+// NewOTLPExporter creates the exporter, the endpoint is the full URL, e.g. http://localhost:4318/v1/traces
+func NewOTLPExporter(endpoint, serviceName string, headers map[string]string, timeout time.Duration) *OTLPExporter {
+	return &OTLPExporter{
+		clnt: http.Client{
+			Timeout: timeout,
+		},
+		endpoint:    endpoint,
+		headers:     headers,
+		serviceName: serviceName,
+	}
+}
+
+// OTLP JSON mapping of ExportTraceServiceRequest, IDs are hex and 64-bit integers are strings
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	ParentSpanID      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              int             `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	Status            otlpStatus      `json:"status"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpAttribute struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+const (
+	otlpScopeName = "blockchain-parser/tools/tracing"
+
+	// span kinds of OTLP, 1 is internal, 2 is server, 3 is client
+	otlpSpanKindInternal = 1
+	otlpSpanKindServer   = 2
+	otlpSpanKindClient   = 3
+)
+
+func (e *OTLPExporter) Export(ctx context.Context, spans []SpanData) error {
+	body := otlpRequest{
+		ResourceSpans: []otlpResourceSpans{
+			{
+				Resource: otlpResource{
+					Attributes: []otlpAttribute{mapAttributeToOTLP(String("service.name", e.serviceName))},
+				},
+				ScopeSpans: []otlpScopeSpans{
+					{
+						Scope: otlpScope{Name: otlpScopeName},
+						Spans: make([]otlpSpan, 0, len(spans)),
+					},
+				},
+			},
+		},
+	}
+
+	scopeSpans := &body.ResourceSpans[0].ScopeSpans[0]
+	for _, span := range spans {
+		scopeSpans.Spans = append(scopeSpans.Spans, mapSpanToOTLP(span))
+	}
+
+	buf := bytes.Buffer{}
+	if err := json.NewEncoder(&buf).Encode(body); err != nil {
+		return fmt.Errorf("fail marshal spans: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.endpoint, &buf)
+	if err != nil {
+		return fmt.Errorf("fail create export request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range e.headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := e.clnt.Do(req)
+	if err != nil {
+		return fmt.Errorf("fail send spans: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("collector responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func mapSpanToOTLP(span SpanData) otlpSpan {
+	s := otlpSpan{
+		TraceID:           span.SpanContext.TraceID.String(),
+		SpanID:            span.SpanContext.SpanID.String(),
+		Name:              span.Name,
+		Kind:              otlpSpanKindInternal,
+		StartTimeUnixNano: strconv.FormatInt(span.Start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(span.End.UnixNano(), 10),
+		Status: otlpStatus{
+			Code:    int(span.Status),
+			Message: span.StatusMessage,
+		},
+	}
+
+	switch span.Kind {
+	case SpanKindServer:
+		s.Kind = otlpSpanKindServer
+	case SpanKindClient:
+		s.Kind = otlpSpanKindClient
+	}
+
+	if span.Parent.IsValid() {
+		s.ParentSpanID = span.Parent.String()
+	}
+
+	for _, attr := range span.Attributes {
+		s.Attributes = append(s.Attributes, mapAttributeToOTLP(attr))
+	}
+
+	return s
+}
+
+func mapAttributeToOTLP(attr Attribute) otlpAttribute {
+	value := otlpValue{}
+	switch v := attr.Value.(type) {
+	case string:
+		value.StringValue = &v
+	case int64:
+		s := strconv.FormatInt(v, 10)
+		value.IntValue = &s
+	case bool:
+		value.BoolValue = &v
+	case float64:
+		value.DoubleValue = &v
+	default:
+		s := fmt.Sprint(v)
+		value.StringValue = &s
+	}
+
+	return otlpAttribute{Key: attr.Key, Value: value}
+}
@@ -0,0 +1,86 @@
+package tracing
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+// propagation of span context by W3C Trace Context, e.g.
+// traceparent: 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01
+
+const (
+	TraceparentHeader = "traceparent"
+
+	traceparentVersion = "00"
+	flagSampled        = 0x01
+)
+
+// Inject sets traceparent header of the current span of the context
+func Inject(ctx context.Context, header http.Header) {
+	sc := SpanFromContext(ctx).SpanContext()
+	if !sc.IsValid() {
+		return
+	}
+
+	header.Set(TraceparentHeader, FormatTraceparent(sc))
+}
+
+// Extract reads span context of the caller from traceparent header
+func Extract(header http.Header) (SpanContext, bool) {
+	return ParseTraceparent(header.Get(TraceparentHeader))
+}
+
+func FormatTraceparent(sc SpanContext) string {
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+
+	return traceparentVersion + "-" + sc.TraceID.String() + "-" + sc.SpanID.String() + "-" + flags
+}
+
+// ParseTraceparent parses the header value, values of unknown future versions are parsed by the known prefix
+func ParseTraceparent(value string) (SpanContext, bool) {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) < 4 {
+		return SpanContext{}, false
+	}
+
+	version, traceID, spanID, flags := parts[0], parts[1], parts[2], parts[3]
+	if len(version) != 2 || version == "ff" || (version == traceparentVersion && len(parts) != 4) {
+		return SpanContext{}, false
+	}
+	if !isLowerHex(version) || !isLowerHex(traceID) || !isLowerHex(spanID) || !isLowerHex(flags) {
+		return SpanContext{}, false
+	}
+
+	sc := SpanContext{}
+	if len(traceID) != 2*len(sc.TraceID) || len(spanID) != 2*len(sc.SpanID) || len(flags) != 2 {
+		return SpanContext{}, false
+	}
+
+	_, _ = hex.Decode(sc.TraceID[:], []byte(traceID))
+	_, _ = hex.Decode(sc.SpanID[:], []byte(spanID))
+
+	var flagsValue [1]byte
+	_, _ = hex.Decode(flagsValue[:], []byte(flags))
+	sc.Sampled = flagsValue[0]&flagSampled != 0
+
+	if !sc.IsValid() {
+		return SpanContext{}, false
+	}
+
+	return sc, true
+}
+
+func isLowerHex(value string) bool {
+	for _, c := range value {
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f') {
+			return false
+		}
+	}
+
+	return true
+}
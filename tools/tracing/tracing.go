@@ -0,0 +1,442 @@
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// minimal in-process tracer. Spans are kept in the context, child spans inherit the trace ID and
+// the sampling decision of the parent. Ended sampled spans are queued and exported in batches.
+
+const (
+	defaultQueueSize     = 2048
+	defaultMaxBatchSize  = 512
+	defaultFlushInterval = 5 * time.Second
+)
+
+type TraceID [16]byte
+
+func (id TraceID) IsValid() bool {
+	return id != TraceID{}
+}
+
+func (id TraceID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+type SpanID [8]byte
+
+func (id SpanID) IsValid() bool {
+	return id != SpanID{}
+}
+
+func (id SpanID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// SpanContext identifies the span across process boundaries
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+	Sampled bool
+}
+
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID.IsValid() && sc.SpanID.IsValid()
+}
+
+type SpanKind int
+
+const (
+	SpanKindInternal SpanKind = iota
+	SpanKindServer
+	SpanKindClient
+)
+
+func (k SpanKind) String() string {
+	switch k {
+	case SpanKindServer:
+		return "server"
+	case SpanKindClient:
+		return "client"
+	}
+
+	return "internal"
+}
+
+type StatusCode int
+
+const (
+	StatusUnset StatusCode = iota
+	StatusOK
+	StatusError
+)
+
+func (c StatusCode) String() string {
+	switch c {
+	case StatusOK:
+		return "ok"
+	case StatusError:
+		return "error"
+	}
+
+	return "unset"
+}
+
+// Attribute is a key-value pair of the span, values are string, int64, bool or float64
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+func String(key, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+func Int(key string, value int) Attribute {
+	return Attribute{Key: key, Value: int64(value)}
+}
+
+// Uint64 keeps values like block numbers, values above max int64 are stored as strings
+func Uint64(key string, value uint64) Attribute {
+	if value > math.MaxInt64 {
+		return Attribute{Key: key, Value: strconv.FormatUint(value, 10)}
+	}
+
+	return Attribute{Key: key, Value: int64(value)}
+}
+
+func Bool(key string, value bool) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// SpanData is the ended span passed to exporters
+type SpanData struct {
+	Name          string
+	Kind          SpanKind
+	SpanContext   SpanContext
+	Parent        SpanID
+	Start         time.Time
+	End           time.Time
+	Attributes    []Attribute
+	Status        StatusCode
+	StatusMessage string
+}
+
+// Exporter sends ended spans to the backend
+type Exporter interface {
+	Export(ctx context.Context, spans []SpanData) error
+}
+
+type Tracer struct {
+	exporter    Exporter
+	sampleRatio float64
+	onError     func(err error)
+
+	queue   chan SpanData
+	flushCh chan chan struct{}
+	stop    chan struct{}
+	done    chan struct{}
+	once    sync.Once
+}
+
+// NewTracer creates the tracer which samples the ratio of root spans (0..1) and exports them by the exporter.
+// Nil exporter disables tracing, spans are created for propagation only. Export errors are passed to onError.
+func NewTracer(exporter Exporter, sampleRatio float64, onError func(err error)) *Tracer {
+	t := &Tracer{
+		exporter:    exporter,
+		sampleRatio: sampleRatio,
+		onError:     onError,
+		queue:       make(chan SpanData, defaultQueueSize),
+		flushCh:     make(chan chan struct{}),
+		stop:        make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+
+	if exporter == nil {
+		t.sampleRatio = 0
+		close(t.done)
+
+		return t
+	}
+
+	go t.run()
+
+	return t
+}
+
+// Start starts the root span of the tracer, it's a child of the span or the remote span of the context if any
+func (t *Tracer) Start(ctx context.Context, name string, kind SpanKind, attrs ...Attribute) (context.Context, *Span) {
+	span := &Span{
+		tracer: t,
+		data: SpanData{
+			Name:  name,
+			Kind:  kind,
+			Start: time.Now(),
+		},
+	}
+
+	var parent SpanContext
+	if parentSpan := spanFromContext(ctx); parentSpan != nil {
+		parent = parentSpan.data.SpanContext
+	} else if remote, ok := ctx.Value(remoteSpanContextKey{}).(SpanContext); ok {
+		parent = remote
+	}
+
+	if parent.IsValid() {
+		span.data.SpanContext.TraceID = parent.TraceID
+		span.data.SpanContext.Sampled = parent.Sampled
+		span.data.Parent = parent.SpanID
+	} else {
+		span.data.SpanContext.TraceID = newTraceID()
+		span.data.SpanContext.Sampled = t.sample(span.data.SpanContext.TraceID)
+	}
+	span.data.SpanContext.SpanID = newSpanID()
+
+	if span.data.SpanContext.Sampled {
+		span.data.Attributes = append(span.data.Attributes, attrs...)
+	}
+
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+// Flush waits until queued spans are exported
+func (t *Tracer) Flush() {
+	select {
+	case <-t.done:
+		return
+	default:
+	}
+
+	ch := make(chan struct{})
+	select {
+	case t.flushCh <- ch:
+		<-ch
+	case <-t.done:
+	}
+}
+
+// Shutdown exports queued spans and stops the tracer, spans ended after shutdown are dropped
+func (t *Tracer) Shutdown(ctx context.Context) error {
+	t.once.Do(func() {
+		close(t.stop)
+	})
+
+	select {
+	case <-t.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (t *Tracer) sample(traceID TraceID) bool {
+	switch {
+	case t.sampleRatio >= 1:
+		return true
+	case t.sampleRatio <= 0:
+		return false
+	}
+
+	// trace ID is random, so its lower bytes are used as the sampling value and every service
+	// of the trace with the same ratio makes the same decision
+	return binary.BigEndian.Uint64(traceID[8:]) < uint64(t.sampleRatio*math.MaxUint64)
+}
+
+func (t *Tracer) enqueue(data SpanData) {
+	select {
+	case <-t.stop:
+		return
+	default:
+	}
+
+	select {
+	case t.queue <- data:
+	default:
+		// the queue is full when the exporter doesn't keep up, spans are dropped to not block the caller
+	}
+}
+
+func (t *Tracer) run() {
+	defer close(t.done)
+
+	ticker := time.NewTicker(defaultFlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]SpanData, 0, defaultMaxBatchSize)
+	export := func() {
+		if len(batch) == 0 {
+			return
+		}
+
+		if err := t.exporter.Export(context.Background(), batch); err != nil && t.onError != nil {
+			t.onError(err)
+		}
+		batch = make([]SpanData, 0, defaultMaxBatchSize)
+	}
+	drain := func() {
+		for {
+			select {
+			case data := <-t.queue:
+				batch = append(batch, data)
+				if len(batch) >= defaultMaxBatchSize {
+					export()
+				}
+			default:
+				export()
+
+				return
+			}
+		}
+	}
+
+	for {
+		select {
+		case data := <-t.queue:
+			batch = append(batch, data)
+			if len(batch) >= defaultMaxBatchSize {
+				export()
+			}
+		case <-ticker.C:
+			export()
+		case ch := <-t.flushCh:
+			drain()
+			close(ch)
+		case <-t.stop:
+			drain()
+
+			return
+		}
+	}
+}
+
+// Span is the traced operation. Methods of nil span do nothing, so callers don't check whether tracing is enabled.
+type Span struct {
+	tracer *Tracer
+	data   SpanData
+	mu     sync.Mutex
+	ended  bool
+}
+
+func (s *Span) SpanContext() SpanContext {
+	if s == nil {
+		return SpanContext{}
+	}
+
+	return s.data.SpanContext
+}
+
+func (s *Span) SetAttributes(attrs ...Attribute) {
+	if s == nil || !s.data.SpanContext.Sampled {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.Attributes = append(s.data.Attributes, attrs...)
+}
+
+// RecordError marks the span as failed, nil error is ignored
+func (s *Span) RecordError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.Status = StatusError
+	s.data.StatusMessage = err.Error()
+}
+
+// End finishes the span, only the first call has effect
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+
+		return
+	}
+	s.ended = true
+	s.data.End = time.Now()
+	data := s.data
+	s.mu.Unlock()
+
+	if data.SpanContext.Sampled {
+		s.tracer.enqueue(data)
+	}
+}
+
+type spanKey struct{}
+
+type tracerKey struct{}
+
+type remoteSpanContextKey struct{}
+
+// NewContext returns the context with the tracer, Start of the package starts root spans by this tracer
+func NewContext(ctx context.Context, t *Tracer) context.Context {
+	return context.WithValue(ctx, tracerKey{}, t)
+}
+
+// ContextWithRemoteSpanContext sets the parent of the next root span, e.g. taken from traceparent header
+func ContextWithRemoteSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	return context.WithValue(ctx, remoteSpanContextKey{}, sc)
+}
+
+// SpanFromContext returns the current span of the context, nil when there is none
+func SpanFromContext(ctx context.Context) *Span {
+	return spanFromContext(ctx)
+}
+
+// Start starts the child of the current span of the context. Without the current span the root span is started
+// by the tracer of the context, without the tracer the span is nil.
+func Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, *Span) {
+	return StartKind(ctx, name, SpanKindInternal, attrs...)
+}
+
+func StartKind(ctx context.Context, name string, kind SpanKind, attrs ...Attribute) (context.Context, *Span) {
+	tracer, _ := ctx.Value(tracerKey{}).(*Tracer)
+	if parent := spanFromContext(ctx); parent != nil {
+		tracer = parent.tracer
+	}
+	if tracer == nil {
+		return ctx, nil
+	}
+
+	return tracer.Start(ctx, name, kind, attrs...)
+}
+
+func spanFromContext(ctx context.Context) *Span {
+	span, _ := ctx.Value(spanKey{}).(*Span)
+
+	return span
+}
+
+func newTraceID() TraceID {
+	var id TraceID
+	for !id.IsValid() {
+		_, _ = rand.Read(id[:])
+	}
+
+	return id
+}
+
+func newSpanID() SpanID {
+	var id SpanID
+	for !id.IsValid() {
+		_, _ = rand.Read(id[:])
+	}
+
+	return id
+}
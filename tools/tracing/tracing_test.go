@@ -0,0 +1,158 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseTraceparent(t *testing.T) {
+	traceID := TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36}
+	spanID := SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7}
+
+	tests := []struct {
+		name   string
+		value  string
+		want   SpanContext
+		wantOk bool
+	}{
+		{
+			name:   "sampled",
+			value:  "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			want:   SpanContext{TraceID: traceID, SpanID: spanID, Sampled: true},
+			wantOk: true,
+		},
+		{
+			name:   "not sampled",
+			value:  "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00",
+			want:   SpanContext{TraceID: traceID, SpanID: spanID},
+			wantOk: true,
+		},
+		{
+			name:   "future version with extra fields",
+			value:  "01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+			want:   SpanContext{TraceID: traceID, SpanID: spanID, Sampled: true},
+			wantOk: true,
+		},
+		{
+			name:  "extra fields of version 00",
+			value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+		},
+		{
+			name:  "invalid version",
+			value: "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		},
+		{
+			name:  "zero trace id",
+			value: "00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		},
+		{
+			name:  "upper case",
+			value: "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+		},
+		{
+			name:  "short span id",
+			value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902-01",
+		},
+		{
+			name: "empty",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseTraceparent(tt.value)
+			if ok != tt.wantOk {
+				t.Fatalf("ParseTraceparent() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseTraceparent() got = %v, want %v", got, tt.want)
+			}
+			if ok && tt.value[:2] == traceparentVersion && FormatTraceparent(got) != tt.value {
+				t.Errorf("FormatTraceparent() got = %s, want %s", FormatTraceparent(got), tt.value)
+			}
+		})
+	}
+}
+
+func TestTracer_OTLPExport(t *testing.T) {
+	requests := make(chan otlpRequest, 1)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/traces" || r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		req := otlpRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+		requests <- req
+	}))
+	defer receiver.Close()
+
+	var exportErr error
+	exporter := NewOTLPExporter(receiver.URL+"/v1/traces", "blockchain-parser", nil, time.Second)
+	tracer := NewTracer(exporter, 1, func(err error) { exportErr = err })
+
+	remote, _ := ParseTraceparent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	ctx := ContextWithRemoteSpanContext(NewContext(context.Background(), tracer), remote)
+
+	ctx, root := StartKind(ctx, "GET /v1/blocks/{number}", SpanKindServer, String("http.method", "GET"))
+	_, child := Start(ctx, "rpc eth_blockNumber", Uint64("block_number", 16))
+	child.RecordError(errors.New("node timeout"))
+	child.End()
+	root.End()
+
+	if err := tracer.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if exportErr != nil {
+		t.Fatalf("Export() error = %v", exportErr)
+	}
+
+	var req otlpRequest
+	select {
+	case req = <-requests:
+	default:
+		t.Fatal("receiver got no spans")
+	}
+
+	got := req.ResourceSpans[0].ScopeSpans[0].Spans
+	if len(got) != 2 {
+		t.Fatalf("count spans = %d, want 2", len(got))
+	}
+
+	childSpan, rootSpan := got[0], got[1]
+	if rootSpan.TraceID != remote.TraceID.String() || rootSpan.ParentSpanID != remote.SpanID.String() || rootSpan.Kind != otlpSpanKindServer {
+		t.Errorf("root span = %+v, want child of the remote span", rootSpan)
+	}
+	if childSpan.TraceID != rootSpan.TraceID || childSpan.ParentSpanID != rootSpan.SpanID {
+		t.Errorf("child span = %+v, want child of the root span", childSpan)
+	}
+	if childSpan.Status != (otlpStatus{Code: int(StatusError), Message: "node timeout"}) {
+		t.Errorf("child span status = %+v, want error", childSpan.Status)
+	}
+	if value := childSpan.Attributes[0].Value.IntValue; value == nil || *value != "16" {
+		t.Errorf("child span attributes = %+v, want block_number 16", childSpan.Attributes)
+	}
+}
+
+func TestStart_WithoutTracer(t *testing.T) {
+	ctx, span := Start(context.Background(), "processBlock")
+	if span != nil || SpanFromContext(ctx) != nil {
+		t.Errorf("Start() span = %v, want nil", span)
+	}
+
+	// methods of nil span are safe to call
+	span.SetAttributes(String("key", "value"))
+	span.RecordError(errors.New("error"))
+	span.End()
+}
@@ -0,0 +1,234 @@
+package websocket
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// minimal server side implementation of RFC 6455, enough for pushing text messages
+
+const (
+	acceptGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+	OpText   = 0x1
+	OpBinary = 0x2
+	OpClose  = 0x8
+	OpPing   = 0x9
+	OpPong   = 0xA
+
+	maxControlPayload = 125
+	maxMessageSize    = 1 << 20
+)
+
+var (
+	ErrNotWebSocket   = errors.New("not a websocket handshake")
+	ErrMessageTooBig  = errors.New("websocket message is too big")
+	ErrUnmaskedFrame  = errors.New("client frame is not masked")
+	ErrHijackNotAvail = errors.New("connection doesn't support hijacking")
+)
+
+type Conn struct {
+	conn net.Conn
+	rw   *bufio.ReadWriter
+
+	writeMu sync.Mutex
+}
+
+func IsUpgrade(r *http.Request) bool {
+	return headerContains(r.Header, "Connection", "upgrade") && headerContains(r.Header, "Upgrade", "websocket")
+}
+
+func Upgrade(w http.ResponseWriter, r *http.Request) (*Conn, error) {
+	if r.Method != http.MethodGet || !IsUpgrade(r) {
+		return nil, ErrNotWebSocket
+	}
+
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if key == "" || r.Header.Get("Sec-WebSocket-Version") != "13" {
+		return nil, ErrNotWebSocket
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		return nil, ErrHijackNotAvail
+	}
+
+	conn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, fmt.Errorf("fail hijack connection: %w", err)
+	}
+
+	hash := sha1.Sum([]byte(key + acceptGUID))
+	accept := base64.StdEncoding.EncodeToString(hash[:])
+
+	_, _ = rw.WriteString("HTTP/1.1 101 Switching Protocols\r\n")
+	_, _ = rw.WriteString("Upgrade: websocket\r\n")
+	_, _ = rw.WriteString("Connection: Upgrade\r\n")
+	_, _ = rw.WriteString("Sec-WebSocket-Accept: " + accept + "\r\n\r\n")
+	if err := rw.Flush(); err != nil {
+		_ = conn.Close()
+
+		return nil, fmt.Errorf("fail write handshake: %w", err)
+	}
+
+	return &Conn{
+		conn: conn,
+		rw:   rw,
+	}, nil
+}
+
+func (c *Conn) WriteText(data []byte) error {
+	return c.writeFrame(OpText, data)
+}
+
+func (c *Conn) WritePing() error {
+	return c.writeFrame(OpPing, nil)
+}
+
+// Read returns the next data message. Pings are answered and a close frame ends reading with io.EOF.
+func (c *Conn) Read() (int, []byte, error) {
+	var (
+		message []byte
+		opcode  int
+	)
+
+	for {
+		fin, op, payload, err := c.readFrame()
+		if err != nil {
+			return 0, nil, err
+		}
+
+		switch op {
+		case OpPing:
+			if err := c.writeFrame(OpPong, payload); err != nil {
+				return 0, nil, err
+			}
+
+			continue
+		case OpPong:
+			continue
+		case OpClose:
+			_ = c.writeFrame(OpClose, payload)
+
+			return 0, nil, io.EOF
+		case 0:
+		default:
+			opcode = op
+			message = message[:0]
+		}
+
+		if len(message)+len(payload) > maxMessageSize {
+			return 0, nil, ErrMessageTooBig
+		}
+		message = append(message, payload...)
+
+		if fin {
+			return opcode, message, nil
+		}
+	}
+}
+
+func (c *Conn) Close() error {
+	_ = c.writeFrame(OpClose, nil)
+
+	return c.conn.Close()
+}
+
+func (c *Conn) writeFrame(opcode int, payload []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	header := make([]byte, 0, 10)
+	header = append(header, 0x80|byte(opcode))
+
+	switch {
+	case len(payload) <= maxControlPayload:
+		header = append(header, byte(len(payload)))
+	case len(payload) <= 0xFFFF:
+		header = append(header, 126, 0, 0)
+		binary.BigEndian.PutUint16(header[2:], uint16(len(payload)))
+	default:
+		header = append(header, 127, 0, 0, 0, 0, 0, 0, 0, 0)
+		binary.BigEndian.PutUint64(header[2:], uint64(len(payload)))
+	}
+
+	if _, err := c.rw.Write(header); err != nil {
+		return err
+	}
+	if _, err := c.rw.Write(payload); err != nil {
+		return err
+	}
+
+	return c.rw.Flush()
+}
+
+func (c *Conn) readFrame() (bool, int, []byte, error) {
+	head := make([]byte, 2)
+	if _, err := io.ReadFull(c.rw, head); err != nil {
+		return false, 0, nil, err
+	}
+
+	fin := head[0]&0x80 != 0
+	opcode := int(head[0] & 0x0F)
+	masked := head[1]&0x80 != 0
+	length := uint64(head[1] & 0x7F)
+
+	if !masked {
+		return false, 0, nil, ErrUnmaskedFrame
+	}
+
+	switch length {
+	case 126:
+		ext := make([]byte, 2)
+		if _, err := io.ReadFull(c.rw, ext); err != nil {
+			return false, 0, nil, err
+		}
+		length = uint64(binary.BigEndian.Uint16(ext))
+	case 127:
+		ext := make([]byte, 8)
+		if _, err := io.ReadFull(c.rw, ext); err != nil {
+			return false, 0, nil, err
+		}
+		length = binary.BigEndian.Uint64(ext)
+	}
+
+	if length > maxMessageSize {
+		return false, 0, nil, ErrMessageTooBig
+	}
+
+	mask := make([]byte, 4)
+	if _, err := io.ReadFull(c.rw, mask); err != nil {
+		return false, 0, nil, err
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(c.rw, payload); err != nil {
+		return false, 0, nil, err
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+
+	return fin, opcode, payload, nil
+}
+
+func headerContains(header http.Header, name, value string) bool {
+	for _, v := range header.Values(name) {
+		for _, token := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(token), value) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,338 @@
+package yaml
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Parser of the YAML subset used by config files: block mappings, block and flow sequences of scalars,
+// plain, single and double quoted scalars and comments. Anchors, tags, multi-line scalars, flow mappings
+// and multiple documents aren't supported.
+//
+// Mappings are decoded to map[string]interface{}, sequences to []interface{}, scalars to string,
+// null and empty values to nil. Scalars aren't typed, "1" and "true" stay strings.
+
+type line struct {
+	number int
+	indent int
+	text   string
+}
+
+type parser struct {
+	lines []line
+	pos   int
+}
+
+// Parse decodes the document, its root must be a mapping. Empty document is an empty mapping.
+func Parse(data []byte) (map[string]interface{}, error) {
+	lines, err := splitLines(string(data))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(lines) == 0 {
+		return map[string]interface{}{}, nil
+	}
+
+	p := &parser{lines: lines}
+	if isSequenceItem(lines[0].text) {
+		return nil, fmt.Errorf("line %d: document must be a mapping", lines[0].number)
+	}
+
+	root, err := p.parseMapping(lines[0].indent)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.pos < len(p.lines) {
+		return nil, fmt.Errorf("line %d: unexpected indentation", p.lines[p.pos].number)
+	}
+
+	return root, nil
+}
+
+// splitLines drops comments, blank lines and the document start marker
+func splitLines(data string) ([]line, error) {
+	lines := make([]line, 0)
+	for i, text := range strings.Split(data, "\n") {
+		text = strings.TrimRight(stripComment(text), " \t\r")
+
+		trimmed := strings.TrimLeft(text, " ")
+		if trimmed == "" || trimmed == "---" {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "\t") {
+			return nil, fmt.Errorf("line %d: tabs aren't allowed in indentation", i+1)
+		}
+
+		lines = append(lines, line{
+			number: i + 1,
+			indent: len(text) - len(trimmed),
+			text:   trimmed,
+		})
+	}
+
+	return lines, nil
+}
+
+// stripComment cuts # which starts the line or follows whitespace outside of quotes
+func stripComment(text string) string {
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		// apostrophes inside plain scalars don't start quotes
+		case (c == '"' || c == '\'') && (i == 0 || strings.IndexByte(" \t[,", text[i-1]) >= 0):
+			quote = c
+		case c == '#' && (i == 0 || text[i-1] == ' ' || text[i-1] == '\t'):
+			return text[:i]
+		}
+	}
+
+	return text
+}
+
+func isSequenceItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+func (p *parser) parseMapping(indent int) (map[string]interface{}, error) {
+	mapping := map[string]interface{}{}
+
+	for p.pos < len(p.lines) {
+		l := p.lines[p.pos]
+		if l.indent < indent {
+			break
+		}
+		if l.indent > indent {
+			return nil, fmt.Errorf("line %d: unexpected indentation", l.number)
+		}
+		if isSequenceItem(l.text) {
+			return nil, fmt.Errorf("line %d: expected key, got sequence item", l.number)
+		}
+
+		key, rest, err := splitKey(l)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := mapping[key]; ok {
+			return nil, fmt.Errorf("line %d: duplicate key %s", l.number, key)
+		}
+		p.pos++
+
+		if rest != "" {
+			mapping[key], err = parseInline(rest, l.number)
+			if err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		// the value is the nested block, a sequence may have the same indentation as its key
+		mapping[key] = nil
+		if p.pos < len(p.lines) {
+			next := p.lines[p.pos]
+			switch {
+			case next.indent >= indent && isSequenceItem(next.text):
+				mapping[key], err = p.parseSequence(next.indent)
+			case next.indent > indent:
+				mapping[key], err = p.parseMapping(next.indent)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return mapping, nil
+}
+
+func (p *parser) parseSequence(indent int) ([]interface{}, error) {
+	sequence := make([]interface{}, 0)
+
+	for p.pos < len(p.lines) {
+		l := p.lines[p.pos]
+		if l.indent < indent || !isSequenceItem(l.text) {
+			break
+		}
+		if l.indent > indent {
+			return nil, fmt.Errorf("line %d: unexpected indentation", l.number)
+		}
+		p.pos++
+
+		item := strings.TrimSpace(strings.TrimPrefix(l.text, "-"))
+		if item == "" || isSequenceItem(item) || strings.HasPrefix(item, "[") || isKeyValue(item) {
+			return nil, fmt.Errorf("line %d: sequence items must be scalars", l.number)
+		}
+
+		value, err := parseScalar(item, l.number)
+		if err != nil {
+			return nil, err
+		}
+		sequence = append(sequence, value)
+
+		if p.pos < len(p.lines) && p.lines[p.pos].indent > indent {
+			return nil, fmt.Errorf("line %d: sequence items must be scalars", p.lines[p.pos].number)
+		}
+	}
+
+	return sequence, nil
+}
+
+// splitKey splits "key: value" by the first colon followed by space outside of quotes
+func splitKey(l line) (string, string, error) {
+	i := keySeparator(l.text)
+	if i < 0 {
+		return "", "", fmt.Errorf("line %d: expected key: value", l.number)
+	}
+
+	// plain keys are taken as is, e.g. null is the string key
+	key := strings.TrimSpace(l.text[:i])
+	if strings.HasPrefix(key, `"`) || strings.HasPrefix(key, "'") {
+		value, err := parseScalar(key, l.number)
+		if err != nil {
+			return "", "", err
+		}
+		key, _ = value.(string)
+	}
+	if key == "" {
+		return "", "", fmt.Errorf("line %d: key must not be empty", l.number)
+	}
+
+	return key, strings.TrimSpace(l.text[i+1:]), nil
+}
+
+func isKeyValue(text string) bool {
+	return keySeparator(text) >= 0
+}
+
+func keySeparator(text string) int {
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case (c == '"' || c == '\'') && i == 0:
+			quote = c
+		case c == ':' && (i == len(text)-1 || text[i+1] == ' '):
+			return i
+		}
+	}
+
+	return -1
+}
+
+func parseInline(text string, number int) (interface{}, error) {
+	switch {
+	case strings.HasPrefix(text, "["):
+		return parseFlowSequence(text, number)
+	case text == "{}":
+		return map[string]interface{}{}, nil
+	case strings.HasPrefix(text, "{"):
+		return nil, fmt.Errorf("line %d: flow mappings aren't supported", number)
+	case strings.HasPrefix(text, "|"), strings.HasPrefix(text, ">"):
+		return nil, fmt.Errorf("line %d: multi-line scalars aren't supported", number)
+	case strings.HasPrefix(text, "&"), strings.HasPrefix(text, "*"), strings.HasPrefix(text, "!"):
+		return nil, fmt.Errorf("line %d: anchors, aliases and tags aren't supported", number)
+	}
+
+	return parseScalar(text, number)
+}
+
+// parseFlowSequence parses "[a, 'b', "c"]", nested collections aren't supported
+func parseFlowSequence(text string, number int) ([]interface{}, error) {
+	if !strings.HasSuffix(text, "]") {
+		return nil, fmt.Errorf("line %d: flow sequence must end with ]", number)
+	}
+	inner := strings.TrimSpace(text[1 : len(text)-1])
+
+	sequence := make([]interface{}, 0)
+	if inner == "" {
+		return sequence, nil
+	}
+
+	var (
+		quote byte
+		start int
+		items []string
+	)
+	for i := 0; i < len(inner); i++ {
+		c := inner[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '[' || c == ']' || c == '{' || c == '}':
+			return nil, fmt.Errorf("line %d: nested collections aren't supported", number)
+		case c == ',':
+			items = append(items, inner[start:i])
+			start = i + 1
+		}
+	}
+	items = append(items, inner[start:])
+
+	for _, item := range items {
+		value, err := parseScalar(strings.TrimSpace(item), number)
+		if err != nil {
+			return nil, err
+		}
+		sequence = append(sequence, value)
+	}
+
+	return sequence, nil
+}
+
+func parseScalar(text string, number int) (interface{}, error) {
+	switch {
+	case text == "" || text == "~" || text == "null" || text == "Null" || text == "NULL":
+		return nil, nil
+	case strings.HasPrefix(text, `"`):
+		value, err := strconv.Unquote(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid double quoted scalar %s", number, text)
+		}
+
+		return value, nil
+	case strings.HasPrefix(text, "'"):
+		if len(text) < 2 || !strings.HasSuffix(text, "'") {
+			return nil, fmt.Errorf("line %d: invalid single quoted scalar %s", number, text)
+		}
+		inner := text[1 : len(text)-1]
+		if strings.Contains(strings.ReplaceAll(inner, "''", ""), "'") {
+			return nil, fmt.Errorf("line %d: invalid single quoted scalar %s", number, text)
+		}
+
+		return strings.ReplaceAll(inner, "''", "'"), nil
+	}
+
+	return text, nil
+}
+
+// Scalar formats the string as a scalar which is parsed back to the same string, it's double quoted when needed
+func Scalar(s string) string {
+	if s == "" || s != strings.TrimSpace(s) || strings.ContainsAny(s[:1], "-?:,[]{}#&*!|>'\"%@`~") ||
+		strings.Contains(s, ": ") || strings.Contains(s, " #") || strings.HasSuffix(s, ":") ||
+		strings.EqualFold(s, "null") || strings.ContainsAny(s, "\n\r\t") {
+		return strconv.Quote(s)
+	}
+
+	return s
+}
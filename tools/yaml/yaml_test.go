@@ -0,0 +1,140 @@
+package yaml
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name: "empty document",
+			data: "# only comment\n---\n",
+			want: map[string]interface{}{},
+		},
+		{
+			name: "nested mappings",
+			data: `
+server:
+  host: 0.0.0.0   # all interfaces
+  port: "8000"
+parser_worker:
+  interval: 5s
+  empty:
+log: {}
+`,
+			want: map[string]interface{}{
+				"server": map[string]interface{}{
+					"host": "0.0.0.0",
+					"port": "8000",
+				},
+				"parser_worker": map[string]interface{}{
+					"interval": "5s",
+					"empty":    nil,
+				},
+				"log": map[string]interface{}{},
+			},
+		},
+		{
+			name: "sequences",
+			data: `
+addresses:
+- 0xa855d1198c67839e596b9a5d7c46f8ea31cfefde
+- '0xfd4492e70df97a6155c6d244f5ec5b5a39b6f096'
+routes:
+  - GET /v1/blocks/{number}=5s
+headers: [Authorization=Bearer x, "X-Tenant=a, b"]
+none: []
+`,
+			want: map[string]interface{}{
+				"addresses": []interface{}{
+					"0xa855d1198c67839e596b9a5d7c46f8ea31cfefde",
+					"0xfd4492e70df97a6155c6d244f5ec5b5a39b6f096",
+				},
+				"routes":  []interface{}{"GET /v1/blocks/{number}=5s"},
+				"headers": []interface{}{"Authorization=Bearer x", "X-Tenant=a, b"},
+				"none":    []interface{}{},
+			},
+		},
+		{
+			name: "quoted scalars",
+			data: `
+double: "a: b # c\t"
+single: 'it''s'
+plain: don't #comment
+url: http://localhost:4318/v1/traces
+null: ~
+`,
+			want: map[string]interface{}{
+				"double": "a: b # c\t",
+				"single": "it's",
+				"plain":  "don't",
+				"url":    "http://localhost:4318/v1/traces",
+				"null":   nil,
+			},
+		},
+		{
+			name:    "duplicate key",
+			data:    "a: 1\na: 2\n",
+			wantErr: true,
+		},
+		{
+			name:    "unexpected indentation",
+			data:    "a: 1\n  b: 2\n",
+			wantErr: true,
+		},
+		{
+			name:    "tab indentation",
+			data:    "a:\n\tb: 2\n",
+			wantErr: true,
+		},
+		{
+			name:    "nested sequence item",
+			data:    "a:\n  - b: 1\n",
+			wantErr: true,
+		},
+		{
+			name:    "multi-line scalar",
+			data:    "a: |\n  text\n",
+			wantErr: true,
+		},
+		{
+			name:    "root sequence",
+			data:    "- a\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() got = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScalar(t *testing.T) {
+	for _, value := range []string{"", "5s", "@every 5s", "*/5 * * * *", "a: b", "null", " x", "GET /v1/blocks/{number}=5s", "-1", "x\ny"} {
+		got, err := Parse([]byte("key: " + Scalar(value)))
+		if err != nil {
+			t.Fatalf("Parse(%q) error = %v", Scalar(value), err)
+		}
+
+		if got["key"] != value && !(value == "" && got["key"] == nil) {
+			t.Errorf("Scalar(%q) is parsed to %q", value, got["key"])
+		}
+	}
+}